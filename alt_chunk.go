@@ -0,0 +1,76 @@
+package docx
+
+import (
+	"html"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	// altChunkRegex matches the <w:altChunk> elements and captures their relationship id
+	altChunkRegex = regexp.MustCompile(`<w:altChunk\s[^>]*r:id="([^"]+)"`)
+)
+
+// altChunkEscapers maps the file extensions of supported altChunk parts to the function used to escape values.
+// Other formats (e.g. RTF, MHT or embedded docx files) are not supported.
+var altChunkEscapers = map[string]func(string) string{
+	".htm":   html.EscapeString,
+	".html":  html.EscapeString,
+	".xhtml": html.EscapeString,
+	".xml":   html.EscapeString,
+	".txt":   func(s string) string { return s },
+}
+
+// findAltChunks returns the paths of all supported parts which are embedded into the main document using <w:altChunk>.
+// Since those parts are not WordprocessingML, they are not parsed for runs. Instead, placeholders are replaced
+// by a plain text search, hence they must not be fragmented.
+// Parts with an unsupported format are skipped with a warning.
+func (d *Document) findAltChunks() ([]string, error) {
+	matches := altChunkRegex.FindAllSubmatch(d.files[d.mainPart], -1)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	rels, err := d.readRelationships(d.mainPart)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	for _, match := range matches {
+		for _, rel := range rels {
+			if rel.ID != string(match[1]) {
+				continue
+			}
+			part := relationshipTarget(d.mainPart, rel.Target)
+			if _, supported := altChunkEscapers[strings.ToLower(path.Ext(part))]; !supported {
+				log.Printf("altChunk part %s has an unsupported format, placeholders inside of it are not replaced\n", part)
+				continue
+			}
+			parts = append(parts, part)
+		}
+	}
+	return parts, nil
+}
+
+// replaceAltChunks replaces all placeholders of the placeholderMap inside the altChunk parts.
+// The values are escaped according to the format of the part.
+func (d *Document) replaceAltChunks(placeholderMap PlaceholderMap) error {
+	for _, part := range d.altChunkFiles {
+		data, err := d.readFile(part)
+		if err != nil {
+			return err
+		}
+		escape := altChunkEscapers[strings.ToLower(path.Ext(part))]
+
+		text := string(data)
+		for key, value := range placeholderMap {
+			text = strings.Replace(text, AddPlaceholderDelimiter(key), escape(d.plainValue(key, value)), -1)
+		}
+		if text != string(data) {
+			d.writePart(part, []byte(text))
+		}
+	}
+	return nil
+}
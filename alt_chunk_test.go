@@ -0,0 +1,34 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceAll_AltChunk(t *testing.T) {
+	doc, err := Open("./test/alt_chunk.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"name": "Tom & Jerry", "company": "ACME"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	chunk, err := doc.readFile("word/chunk.html")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := "<html><body><p>Dear Tom &amp; Jerry,</p><p>ACME</p></body></html>"
+	if string(chunk) != expected {
+		t.Errorf("altChunk not replaced, want=%s, have=%s", expected, chunk)
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), "Hello Tom &amp; Jerry") {
+		t.Error("main document not replaced")
+	}
+}
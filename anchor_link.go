@@ -0,0 +1,59 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+)
+
+var (
+	// ErrBookmarkNotFound is returned by ReplaceAnchorLink if the document does not contain the bookmark of the anchor.
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+
+	// bookmarkNameRegex matches the start of all bookmarks (<w:bookmarkStart>) and captures their names
+	bookmarkNameRegex = regexp.MustCompile(`<w:bookmarkStart\s[^>]*w:name="([^"]*)"`)
+)
+
+// ReplaceAnchorLink replaces the given key inside the main document with an internal link to the bookmark
+// of the given name (<w:hyperlink w:anchor>), e.g. to link to a section of a generated report. Unlike links to
+// external targets, internal links do not require a relationship. The link text uses the built-in style 'Hyperlink'.
+//
+// If the main document does not contain the key, ErrPlaceholderNotFound is returned.
+// If the main document does not contain a bookmark of the given name, an error wrapping ErrBookmarkNotFound is
+// returned and the document is left unchanged.
+func (d *Document) ReplaceAnchorLink(key, text, anchor string) error {
+	replacer := d.fileReplacers[d.mainPart]
+	if !replacer.contains(key) {
+		return ErrPlaceholderNotFound
+	}
+
+	if !containsBookmark(replacer.Bytes(), anchor) {
+		return fmt.Errorf("%w: %s", ErrBookmarkNotFound, anchor)
+	}
+
+	err := replacer.replace(key, -1, func(placeholder *Placeholder) string {
+		return replacer.splitRun(placeholder.Fragments[0].Run, anchorLink(text, anchor))
+	})
+	if err != nil {
+		return err
+	}
+	return d.SetFile(d.mainPart, replacer.Bytes())
+}
+
+// containsBookmark returns true if the given part contains a bookmark of the given name.
+func containsBookmark(data []byte, name string) bool {
+	for _, match := range bookmarkNameRegex.FindAllSubmatch(data, -1) {
+		if html.UnescapeString(string(match[1])) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// anchorLink returns an internal hyperlink to the given bookmark, containing a single run with the text.
+func anchorLink(text, anchor string) string {
+	return fmt.Sprintf(`<w:hyperlink w:anchor="%s" w:history="1">`, html.EscapeString(anchor)) +
+		`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t xml:space="preserve">` +
+		escapeTextValue(text, `<w:t xml:space="preserve">`) + `</w:t></w:r></w:hyperlink>`
+}
@@ -0,0 +1,45 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceAnchorLink(t *testing.T) {
+	doc, err := Open("./test/anchor_link.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceAnchorLink("results", "the results", "results"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	expected := `<w:t xml:space="preserve">See </w:t></w:r><w:hyperlink w:anchor="results" w:history="1">` +
+		`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t xml:space="preserve">the results</w:t></w:r></w:hyperlink>` +
+		`<w:r><w:t xml:space="preserve"> for details.</w:t></w:r>`
+	if !strings.Contains(documentXml, expected) {
+		t.Errorf("anchor link was not inserted:\n%s", documentXml)
+	}
+
+	// the document is left unchanged if the bookmark is missing
+	err = doc.ReplaceAnchorLink("appendix", "Appendix", "appendix")
+	if !errors.Is(err, ErrBookmarkNotFound) {
+		t.Errorf("expected ErrBookmarkNotFound, have=%v", err)
+	}
+	if string(doc.GetFile(DocumentXml)) != documentXml {
+		t.Error("document was modified even though the bookmark is missing")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+
+	if err = doc.ReplaceAnchorLink("missing", "text", "results"); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, have=%v", err)
+	}
+}
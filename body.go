@@ -0,0 +1,165 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"html"
+	"regexp"
+	"sort"
+)
+
+// ErrBodyOutdated is returned by Body.Apply if the main document was modified after the body was read.
+var ErrBodyOutdated = errors.New("main document was modified after the body was read")
+
+// paragraphStyleRegex matches the style of a paragraph (<w:pStyle>) and captures its id
+var paragraphStyleRegex = regexp.MustCompile(`<w:pStyle\s[^>]*w:val="([^"]*)"`)
+
+// Body is a navigable view of the paragraphs of the main document, see Document.Body.
+// The texts of its runs can be changed and written back into the document using Apply.
+type Body struct {
+	Paragraphs []*Paragraph // Paragraphs are all paragraphs of the main document in document order
+
+	doc  *Document
+	data []byte // data is the main document which the body was read from
+}
+
+// Paragraph is a paragraph (<w:p>) of the body. Paragraphs inside of tables and text boxes are included.
+type Paragraph struct {
+	Position            // Position is the position of the paragraph element inside of the main document
+	Style    string     // Style is the id of the paragraph style, e.g. 'Heading1', empty if the paragraph does not have one
+	Runs     []*TextRun // Runs are the runs with text of the paragraph, runs of nested paragraphs are not included
+}
+
+// TextRun is a run with text (<w:r>) of a paragraph.
+type TextRun struct {
+	Properties RunProps // Properties are the run properties as they were parsed, they are not changed by SetText
+
+	run      *Run
+	text     string
+	modified bool
+}
+
+// Body reads the paragraphs and their runs from the main document. Only runs with text are part of the body,
+// all other content (e.g. drawings, fields or bookmarks) is not represented, but is kept as is when the body is applied.
+// The body reflects the main document at the time it was read, it does not change if the document is modified.
+func (d *Document) Body() (*Body, error) {
+	data := d.files[d.mainPart]
+	runParser := NewRunParser(data)
+	if err := runParser.Execute(); err != nil {
+		return nil, err
+	}
+	positions, err := findElements(data, ParagraphElementName)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &Body{doc: d, data: data}
+	for _, position := range positions {
+		body.Paragraphs = append(body.Paragraphs, &Paragraph{Position: position, Style: paragraphStyle(data, position)})
+	}
+	// the paragraphs are numbered by the run parser in document order, starting at 1
+	for _, run := range runParser.Runs().WithText() {
+		if run.Paragraph < 1 || run.Paragraph > len(body.Paragraphs) {
+			continue
+		}
+		paragraph := body.Paragraphs[run.Paragraph-1]
+		paragraph.Runs = append(paragraph.Runs, &TextRun{
+			Properties: run.Properties,
+			run:        run,
+			text:       plainText(data[run.OpenTag.Start:run.CloseTag.End]),
+		})
+	}
+	return body, nil
+}
+
+// paragraphStyle returns the id of the style of the paragraph at the given position, empty if it does not have one.
+func paragraphStyle(data []byte, paragraph Position) string {
+	content := data[paragraph.Start:paragraph.End]
+	openTagEnd := bytes.IndexByte(content, '>')
+	if bytes.HasSuffix(content[:openTagEnd+1], []byte("/>")) {
+		return "" // an empty paragraph
+	}
+	// the paragraph properties are always the first child of the paragraph
+	content = content[openTagEnd+1:]
+	if loc := paragraphPropertiesRegex.FindIndex(content); loc != nil && len(bytes.TrimSpace(content[:loc[0]])) == 0 {
+		if match := paragraphStyleRegex.FindSubmatch(content[loc[0]:loc[1]]); match != nil {
+			return html.UnescapeString(string(match[1]))
+		}
+	}
+	return ""
+}
+
+// Text returns the plain text of the run. Breaks are returned as newlines and tabs as tab characters.
+func (r *TextRun) Text() string {
+	return r.text
+}
+
+// SetText sets the text of the run, newlines are converted into breaks. The change is written into the document by Body.Apply.
+func (r *TextRun) SetText(text string) {
+	r.text = text
+	r.modified = true
+}
+
+// Text returns the plain text of the paragraph, which is the text of all of its runs.
+func (p *Paragraph) Text() string {
+	var text bytes.Buffer
+	for _, run := range p.Runs {
+		text.WriteString(run.Text())
+	}
+	return text.String()
+}
+
+// Apply writes the changed texts of the runs into the main document.
+// The text elements of a changed run (including breaks and tabs between them) are replaced by the new text, all other
+// content of the run (e.g. its properties) stays intact. Afterwards, the body is read again from the main document,
+// hence the paragraphs and runs obtained before are outdated.
+// If the main document was modified after the body was read (e.g. by replacing placeholders), ErrBodyOutdated is returned.
+func (b *Body) Apply() error {
+	if !bytes.Equal(b.data, b.doc.files[b.doc.mainPart]) {
+		return ErrBodyOutdated
+	}
+
+	var modified []*TextRun
+	for _, paragraph := range b.Paragraphs {
+		for _, run := range paragraph.Runs {
+			if run.modified {
+				modified = append(modified, run)
+			}
+		}
+	}
+	if len(modified) == 0 {
+		return nil
+	}
+
+	// replace from the end, so the positions of the preceding runs stay valid
+	sort.Slice(modified, func(i, j int) bool {
+		return modified[i].run.OpenTag.Start > modified[j].run.OpenTag.Start
+	})
+	data := b.data
+	for _, run := range modified {
+		start, end := run.textElements(data)
+		textOpenTag := `<w:t xml:space="preserve">`
+		data = splice(data, int(start), int(end), textOpenTag+escapeTextValue(run.text, textOpenTag)+"</w:t>")
+	}
+
+	b.doc.files[b.doc.mainPart] = data
+	if err := b.doc.parseFile(b.doc.mainPart); err != nil {
+		return err
+	}
+	body, err := b.doc.Body()
+	if err != nil {
+		return err
+	}
+	*b = *body
+	return nil
+}
+
+// textElements returns the start of the first and the end of the last text element of the run.
+func (r *TextRun) textElements(data []byte) (start, end int64) {
+	content := data[r.run.OpenTag.Start:r.run.CloseTag.End]
+	matches := textRegex.FindAllIndex(content, -1)
+	if matches == nil {
+		return r.run.Text.OpenTag.Start, r.run.Text.CloseTag.End
+	}
+	return r.run.OpenTag.Start + int64(matches[0][0]), r.run.OpenTag.Start + int64(matches[len(matches)-1][1])
+}
@@ -0,0 +1,104 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_Body(t *testing.T) {
+	doc, err := Open("./test/body.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	body, err := doc.Body()
+	if err != nil {
+		t.Error("reading the body failed", err)
+		return
+	}
+	expected := []struct {
+		style string
+		runs  []string
+	}{
+		{"Heading1", []string{"Quarterly report"}},
+		{"", []string{"Revenue: ", "12\tEUR"}},
+		{"", []string{"A & B"}},
+		{"", nil},
+	}
+	if len(body.Paragraphs) != len(expected) {
+		t.Fatalf("unexpected number of paragraphs, want=%d, have=%d", len(expected), len(body.Paragraphs))
+	}
+	for i, e := range expected {
+		paragraph := body.Paragraphs[i]
+		if paragraph.Style != e.style {
+			t.Errorf("unexpected style of paragraph %d, want=%q, have=%q", i, e.style, paragraph.Style)
+		}
+		var texts []string
+		for _, run := range paragraph.Runs {
+			texts = append(texts, run.Text())
+		}
+		if strings.Join(texts, "|") != strings.Join(e.runs, "|") {
+			t.Errorf("unexpected runs of paragraph %d, want=%q, have=%q", i, e.runs, texts)
+		}
+	}
+	if text := body.Paragraphs[1].Text(); text != "Revenue: 12\tEUR" {
+		t.Errorf("unexpected paragraph text %q", text)
+	}
+	if props := body.Paragraphs[1].Runs[1].Properties; props.Color != "FF0000" || props.Bold {
+		t.Errorf("unexpected run properties %+v", props)
+	}
+}
+
+func TestBody_Apply(t *testing.T) {
+	doc, err := Open("./test/body.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	body, err := doc.Body()
+	if err != nil {
+		t.Error("reading the body failed", err)
+		return
+	}
+	body.Paragraphs[1].Runs[1].SetText("15 EUR\n(estimated)")
+	body.Paragraphs[2].Runs[0].SetText("C < D")
+	if err = body.Apply(); err != nil {
+		t.Error("applying the body failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	expected := []string{
+		// the properties of the run and the surrounding bookmark and field are kept
+		`<w:bookmarkStart w:id="0" w:name="revenue"/><w:r><w:rPr><w:color w:val="FF0000"/></w:rPr>` +
+			`<w:t xml:space="preserve">15 EUR</w:t><w:br/><w:t xml:space="preserve">(estimated)</w:t></w:r><w:bookmarkEnd w:id="0"/>` +
+			`<w:r><w:fldChar w:fldCharType="begin"/></w:r>`,
+		`<w:hyperlink w:anchor="revenue"><w:r><w:t xml:space="preserve">C &lt; D</w:t></w:r></w:hyperlink>`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(documentXml, e) {
+			t.Errorf("expected %s inside the document", e)
+		}
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, applying failed", err)
+	}
+	if text := body.Paragraphs[1].Text(); text != "Revenue: 15 EUR\n(estimated)" {
+		t.Errorf("body was not read again after applying, have %q", text)
+	}
+
+	// the body is outdated once the document is modified otherwise
+	body.Paragraphs[0].Runs[0].SetText("Annual report")
+	if err = doc.SetFile(DocumentXml, []byte(strings.Replace(documentXml, "Quarterly", "Monthly", 1))); err != nil {
+		t.Fatal(err)
+	}
+	if err = body.Apply(); !errors.Is(err, ErrBodyOutdated) {
+		t.Errorf("expected ErrBodyOutdated, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package docx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// keyCasing is the casing convention of a placeholder key.
+type keyCasing int
+
+const (
+	noCasing    keyCasing = iota
+	upperCasing           // e.g. 'NAME'
+	titleCasing           // e.g. 'Name' or 'First_Name'
+)
+
+// SetMatchKeyCasing enables or disables casing the values like their placeholder keys.
+// If enabled, the value of an all-uppercase key (e.g. '{NAME}') is converted to upper case and the value of a
+// title-case key (e.g. '{Name}') is converted to title case, all other values are inserted as they are.
+// Keys are still matched exactly, '{NAME}' and '{Name}' require their own entries in the PlaceholderMap.
+// It is disabled by default.
+func (d *Document) SetMatchKeyCasing(enabled bool) {
+	d.matchKeyCasing = enabled
+}
+
+// applyKeyCasing converts the value to the casing of the given key.
+func applyKeyCasing(key string, value string) string {
+	switch casingOf(key) {
+	case upperCasing:
+		return strings.ToUpper(value)
+	case titleCasing:
+		return titleCase(value)
+	}
+	return value
+}
+
+// casingOf determines the casing convention of the given key.
+// The words of a key may be separated by spaces, dashes, underscores or dots.
+func casingOf(key string) keyCasing {
+	hasLetter, allUpper, title := false, true, true
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '.'
+	})
+	for _, word := range words {
+		for i, r := range word {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			hasLetter = true
+			if unicode.IsLower(r) {
+				allUpper = false
+			}
+			if (i == 0) != unicode.IsUpper(r) {
+				title = false
+			}
+		}
+	}
+	switch {
+	case !hasLetter:
+		return noCasing
+	case allUpper:
+		return upperCasing
+	case title:
+		return titleCasing
+	}
+	return noCasing
+}
+
+// titleCase converts the first letter of every word of the given text to upper case.
+func titleCase(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(runes)
+}
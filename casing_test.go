@@ -0,0 +1,50 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCasingOf(t *testing.T) {
+	tests := map[string]keyCasing{
+		"NAME":       upperCasing,
+		"FIRST_NAME": upperCasing,
+		"Name":       titleCasing,
+		"First Name": titleCasing,
+		"First_name": noCasing,
+		"name":       noCasing,
+		"nAME":       noCasing,
+		"42":         noCasing,
+	}
+	for key, expected := range tests {
+		if casing := casingOf(key); casing != expected {
+			t.Errorf("casingOf(%s), want=%d, have=%d", key, expected, casing)
+		}
+	}
+}
+
+func TestDocument_SetMatchKeyCasing(t *testing.T) {
+	doc, err := Open("./test/casing.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetMatchKeyCasing(true)
+	err = doc.ReplaceAll(PlaceholderMap{
+		"NAME": "john doe",
+		"Name": "john doe",
+		"name": "john doe",
+		"CITY": "Berlin",
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	expected := "JOHN DOE / John Doe / john doe / BERLIN"
+	if text := doc.Text(); !strings.Contains(text, expected) {
+		t.Errorf("unexpected text, want=%s, have=%s", expected, text)
+	}
+}
@@ -0,0 +1,66 @@
+package docx
+
+import (
+	"bytes"
+	"sort"
+)
+
+// ChartRelationshipType is the type of relationships which reference the parts of embedded charts.
+const ChartRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+
+// SetReplaceCharts enables or disables the replacement of placeholders inside embedded charts (word/charts/chartN.xml).
+// If enabled, the placeholders inside the rich text of the charts are replaced, e.g. of the chart title
+// 'Sales {year}' or of the axis titles. The chart data (e.g. the series names and values) is not touched.
+// The replaced text keeps the formatting of the run which contains the start of the placeholder,
+// the values cannot be styled. It is disabled by default.
+func (d *Document) SetReplaceCharts(enabled bool) {
+	d.replaceCharts = enabled
+}
+
+// findCharts returns the paths of all chart parts which are referenced by the main document, the headers or the footers.
+func (d *Document) findCharts() ([]string, error) {
+	found := make(map[string]bool)
+	for _, part := range d.readingOrder() {
+		rels, err := d.readRelationships(part)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range rels {
+			if rel.Type == ChartRelationshipType && rel.TargetMode != ExternalTargetMode {
+				found[relationshipTarget(part, rel.Target)] = true
+			}
+		}
+	}
+
+	parts := make([]string, 0, len(found))
+	for part := range found {
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	return parts, nil
+}
+
+// replaceChartParts replaces all placeholders of the placeholderMap inside the rich text (<a:t>) of the charts,
+// if enabled by SetReplaceCharts.
+func (d *Document) replaceChartParts(placeholderMap PlaceholderMap) error {
+	if !d.replaceCharts {
+		return nil
+	}
+	for _, part := range d.chartFiles {
+		data, err := d.readFile(part)
+		if err != nil {
+			return err
+		}
+		replaced := replaceDrawingText(data, func(key string) (string, bool) {
+			value, ok := placeholderMap[key]
+			if !ok {
+				return "", false
+			}
+			return d.plainValue(key, value), true
+		})
+		if !bytes.Equal(replaced, data) {
+			d.writePart(part, replaced)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetReplaceCharts(t *testing.T) {
+	doc, err := Open("./test/chart.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	placeholderMap := PlaceholderMap{"year": 2024, "currency": "EUR & USD", "series": "Q1"}
+
+	// charts are not replaced by default
+	if err = doc.ReplaceAll(placeholderMap); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	chart, err := doc.readFile("word/charts/chart1.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(chart), "{ye</a:t>") {
+		t.Error("chart was replaced although it is disabled")
+	}
+
+	doc.SetReplaceCharts(true)
+	if err = doc.ReplaceAll(placeholderMap); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	chart, err = doc.readFile("word/charts/chart1.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, expected := range []string{
+		"<a:t>Sales \u2014 2024</a:t></a:r><a:r><a:rPr lang=\"en-US\" b=\"1\"/><a:t></a:t>",
+		"<a:t>Revenue in EUR &amp; USD</a:t>",
+		// the chart data is out of scope
+		"<c:v>{series}</c:v>",
+	} {
+		if !strings.Contains(string(chart), expected) {
+			t.Errorf("expected %s inside the chart", expected)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package docx
+
+// Clone returns an independent copy of the document, including all replacements made so far and all options
+// (e.g. the ValueFormatter). Replacing on the copy does not modify the receiver and vice versa, so a template can be
+// opened once and cloned for every rendering. Cloning is safe while other clones of the same document are modified
+// concurrently, but the receiver itself must not be modified while it is cloned.
+//
+// The parts of the copy are parsed again, so values inserted before cloning are treated as text of the template.
+// The copy reads the unmodified files from the archive of the receiver, hence the receiver must not be closed
+// until the copy has been written. Closing the copy does not close the archive.
+func (d *Document) Clone() (*Document, error) {
+	clone := *d
+	clone.docxFile = nil
+
+	clone.files = d.files.clone()
+	clone.modifiedParts = d.modifiedParts.clone()
+	clone.removedParts = make(map[string]bool, len(d.removedParts))
+	for name, removed := range d.removedParts {
+		clone.removedParts[name] = removed
+	}
+	clone.declarations = make(map[string][]byte, len(d.declarations))
+	for name, declaration := range d.declarations {
+		clone.declarations[name] = declaration
+	}
+	clone.keyMaxValueRunes = make(map[string]int, len(d.keyMaxValueRunes))
+	for key, max := range d.keyMaxValueRunes {
+		clone.keyMaxValueRunes[key] = max
+	}
+
+	// the slices are copied, so appending to them does not modify the receiver
+	for _, files := range []*[]string{&clone.headerFiles, &clone.footerFiles, &clone.mediaFiles, &clone.altChunkFiles,
+		&clone.diagramFiles, &clone.chartFiles, &clone.additionalFiles} {
+		*files = append([]string(nil), *files...)
+	}
+
+	clone.runParsers = make(map[string]*RunParser, len(d.runParsers))
+	clone.filePlaceholders = make(map[string][]*Placeholder, len(d.filePlaceholders))
+	clone.fileReplacers = make(map[string]*Replacer, len(d.fileReplacers))
+	for name := range d.runParsers {
+		if err := clone.parseFile(name); err != nil {
+			return nil, err
+		}
+	}
+	return &clone, nil
+}
+
+// WithReplacements returns a copy of the document (see Clone) with all placeholders of the placeholderMap replaced
+// (see ReplaceAll). The receiver is left untouched, so one document can serve many concurrent renderings.
+func (d *Document) WithReplacements(placeholderMap PlaceholderMap) (*Document, error) {
+	clone, err := d.Clone()
+	if err != nil {
+		return nil, err
+	}
+	if err := clone.ReplaceAll(placeholderMap); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// clone returns a deep copy of the FileMap. The replacers modify the bytes in place, so they must not be shared.
+func (fm FileMap) clone() FileMap {
+	clone := make(FileMap, len(fm))
+	for name, data := range fm {
+		clone[name] = append([]byte(nil), data...)
+	}
+	return clone
+}
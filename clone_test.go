@@ -0,0 +1,99 @@
+package docx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDocument_WithReplacements(t *testing.T) {
+	base, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer base.Close()
+	base.SetValueFormatter(func(key string, value interface{}) interface{} {
+		return fmt.Sprintf("[%v]", value)
+	})
+
+	var wg sync.WaitGroup
+	texts := make([]string, 8)
+	errs := make([]error, len(texts))
+	for i := range texts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			doc, err := base.WithReplacements(PlaceholderMap{"key": fmt.Sprintf("variant-%d", i)})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			texts[i] = doc.Text()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, text := range texts {
+		if errs[i] != nil {
+			t.Errorf("variant %d failed: %s", i, errs[i])
+			continue
+		}
+		// the options of the base are applied to the copy
+		if !strings.Contains(text, fmt.Sprintf("[variant-%d]", i)) {
+			t.Errorf("variant %d was not replaced: %s", i, text)
+		}
+		for j := range texts {
+			if j != i && strings.Contains(text, fmt.Sprintf("[variant-%d]", j)) {
+				t.Errorf("variant %d contains the value of variant %d", i, j)
+			}
+		}
+	}
+
+	if !strings.Contains(base.Text(), "{key}") {
+		t.Error("the base document was modified")
+	}
+	if err = base.Replace("key", "base"); err != nil {
+		t.Error("replacing in the base document failed", err)
+	}
+}
+
+func TestDocument_Clone(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.Replace("key", "original"); err != nil {
+		t.Error(err)
+		return
+	}
+	clone, err := doc.Clone()
+	if err != nil {
+		t.Error("cloning failed", err)
+		return
+	}
+	if err = clone.Close(); err != nil {
+		t.Error("closing the clone failed", err)
+	}
+	if err = clone.ReplaceAll(PlaceholderMap{"key-with-dash": "cloned"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	if text := clone.Text(); !strings.Contains(text, "original") || !strings.Contains(text, "cloned") {
+		t.Errorf("clone does not contain the replacements of both documents: %s", text)
+	}
+	if strings.Contains(doc.Text(), "cloned") {
+		t.Error("replacing on the clone modified the original")
+	}
+
+	// the archive of the original is still open and can be written from the clone
+	var buf strings.Builder
+	if err = clone.Write(&buf); err != nil {
+		t.Error("writing the clone failed", err)
+	}
+}
@@ -0,0 +1,195 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CompiledTemplate is a template whose placeholders have been parsed once, see Compile.
+// It is immutable, so Render can be called concurrently, e.g. by the workers of a batch pipeline.
+type CompiledTemplate struct {
+	archive []byte
+	parts   map[string]*compiledPart
+}
+
+// compiledPart is a parsed part of a CompiledTemplate.
+type compiledPart struct {
+	data         []byte
+	placeholders []compiledPlaceholder // placeholders are ordered by their position
+}
+
+// compiledPlaceholder records the absolute byte spans of a placeholder. The value is inserted in place of the
+// first span, all other spans (the fragments in the following runs) are removed.
+type compiledPlaceholder struct {
+	key         string
+	spans       []Position
+	textOpenTag string // textOpenTag is the open tag of the text element of the first span
+
+	// preservingOpenTag is the open tag of the text element of the first span with 'xml:space="preserve"' added,
+	// it replaces the span of the open tag if a value starts or ends with whitespace. It is empty if the open tag
+	// preserves whitespace already.
+	preservingOpenTag string
+	textOpenTagSpan   Position // textOpenTagSpan is the span of the open tag of the text element of the first span
+}
+
+// compiledEdit replaces the bytes of the span with the value.
+type compiledEdit struct {
+	span  Position
+	value string
+}
+
+// Compile parses the placeholders of the given docx-archive once and records their byte spans.
+// The returned template renders documents by splicing the values into a copy of the parsed parts,
+// which is much cheaper than opening and parsing the template for every document.
+func Compile(b []byte) (*CompiledTemplate, error) {
+	doc, err := OpenBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	defer doc.Close()
+
+	template := &CompiledTemplate{
+		archive: append([]byte{}, b...),
+		parts:   make(map[string]*compiledPart),
+	}
+	for name, replacer := range doc.fileReplacers {
+		data := replacer.document
+		part := &compiledPart{data: data}
+		for _, placeholder := range replacer.Unreplaced() {
+			openTag := placeholder.Fragments[0].Run.Text.OpenTag
+			compiled := compiledPlaceholder{
+				key:             RemovePlaceholderDelimiter(placeholder.Text(data)),
+				textOpenTag:     string(data[openTag.Start:openTag.End]),
+				textOpenTagSpan: Position{Start: openTag.Start, End: openTag.End},
+			}
+			if !strings.Contains(compiled.textOpenTag, "xml:space") {
+				// just like Replacer.preserveSpace, the attribute is added before the closing '>'
+				closing := len(compiled.textOpenTag) - 1
+				compiled.preservingOpenTag = compiled.textOpenTag[:closing] + ` xml:space="preserve"` + compiled.textOpenTag[closing:]
+			}
+			for _, fragment := range placeholder.Fragments {
+				compiled.spans = append(compiled.spans, Position{Start: fragment.StartPos(), End: fragment.EndPos()})
+			}
+			part.placeholders = append(part.placeholders, compiled)
+		}
+		template.parts[name] = part
+	}
+	return template, nil
+}
+
+// Keys returns the distinct keys of all placeholders of the template, sorted alphabetically.
+func (t *CompiledTemplate) Keys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, part := range t.parts {
+		for _, placeholder := range part.placeholders {
+			if !seen[placeholder.key] {
+				seen[placeholder.key] = true
+				keys = append(keys, placeholder.key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Render returns the docx-archive of the template with all placeholders replaced by the values of the map.
+// Placeholders without an entry in the map or whose value is a nil pointer are left untouched.
+//
+// The values are inserted as plain text: strings and byte slices as they are, all other values are formatted
+// using fmt.Sprint. Newlines are converted into breaks. The elements of slice values can be accessed by their
+// index and pointers are dereferenced, just like with ReplaceAll. The options of a Document (e.g. the ValueFormatter) do not apply
+// and parts which are not parsed (e.g. charts and diagrams) are copied unchanged.
+func (t *CompiledTemplate) Render(m PlaceholderMap) ([]byte, error) {
+	m, _ = expandIndexedKeys(dereferenceValues(m))
+	m = withoutNilPointers(m)
+
+	reader, err := zip.NewReader(bytes.NewReader(t.archive), int64(len(t.archive)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open zip reader: %s", err)
+	}
+
+	out := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(out)
+	for _, zipFile := range reader.File {
+		fw, err := zipWriter.CreateHeader(copyFileHeader(zipFile.FileHeader))
+		if err != nil {
+			return nil, fmt.Errorf("unable to create writer: %s", err)
+		}
+
+		var fileBytes []byte
+		if part, ok := t.parts[zipFile.Name]; ok {
+			fileBytes = part.render(m)
+		} else if fileBytes, err = readZipFile(zipFile); err != nil {
+			return nil, err
+		}
+		if _, err = fw.Write(fileBytes); err != nil {
+			return nil, fmt.Errorf("unable to write %s: %s", zipFile.Name, err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("unable to close zip writer: %s", err)
+	}
+	return out.Bytes(), nil
+}
+
+// render returns a copy of the part with the placeholders of all keys of the map replaced.
+// Like ReplaceAll, the text elements of values which start or end with whitespace are ensured to preserve it.
+func (p *compiledPart) render(m PlaceholderMap) []byte {
+	// a text element may contain several placeholders, its open tag is replaced once
+	preserved := make(map[int64]compiledEdit)
+	for _, placeholder := range p.placeholders {
+		value, ok := m[placeholder.key]
+		if ok && placeholder.preservingOpenTag != "" && hasSurroundingSpace(plainTextValue(value)) {
+			preserved[placeholder.textOpenTagSpan.Start] = compiledEdit{span: placeholder.textOpenTagSpan, value: placeholder.preservingOpenTag}
+		}
+	}
+
+	var edits []compiledEdit
+	for _, edit := range preserved {
+		edits = append(edits, edit)
+	}
+	for _, placeholder := range p.placeholders {
+		value, ok := m[placeholder.key]
+		if !ok {
+			continue
+		}
+		textOpenTag := placeholder.textOpenTag
+		if edit, ok := preserved[placeholder.textOpenTagSpan.Start]; ok {
+			textOpenTag = edit.value
+		}
+		edits = append(edits, compiledEdit{span: placeholder.spans[0], value: escapeTextValue(plainTextValue(value), textOpenTag)})
+		for _, span := range placeholder.spans[1:] {
+			edits = append(edits, compiledEdit{span: span})
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].span.Start < edits[j].span.Start
+	})
+
+	var rendered bytes.Buffer
+	rendered.Grow(len(p.data))
+	last := int64(0)
+	for _, edit := range edits {
+		rendered.Write(p.data[last:edit.span.Start])
+		rendered.WriteString(edit.value)
+		last = edit.span.End
+	}
+	rendered.Write(p.data[last:])
+	return rendered.Bytes()
+}
+
+// plainTextValue returns the textual representation of a value of a CompiledTemplate.
+func plainTextValue(value interface{}) string {
+	switch v := dereferenceValue(value).(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
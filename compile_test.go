@@ -0,0 +1,119 @@
+package docx
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	template, err := Compile(readFile(t, "./test/template.docx"))
+	if err != nil {
+		t.Error("compiling failed", err)
+		return
+	}
+
+	keys := template.Keys()
+	if len(keys) == 0 || keys[0] > keys[len(keys)-1] {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	placeholderMap := PlaceholderMap{
+		"key":                 "REPLACE some more",
+		"key-with-dash":       "line\nbreak & more",
+		"key_with_underscore": 42,
+	}
+
+	// the result equals the one of ReplaceAll with plain values
+	expected, err := Open("./test/template.docx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer expected.Close()
+	if err = expected.ReplaceAll(placeholderMap); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]byte, 4)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = template.Render(placeholderMap)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if errs[i] != nil {
+			t.Error("rendering failed", errs[i])
+			continue
+		}
+		doc, err := OpenBytes(result)
+		if err != nil {
+			t.Error("rendered document cannot be opened", err)
+			continue
+		}
+		if text := doc.Text(); text != expected.Text() {
+			t.Errorf("rendered text differs from ReplaceAll\nwant=%s\nhave=%s", expected.Text(), text)
+		}
+	}
+
+	// rendering does not modify the template
+	result, err := template.Render(PlaceholderMap{})
+	if err != nil {
+		t.Error("rendering failed", err)
+		return
+	}
+	doc, err := OpenBytes(result)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "{key}") {
+		t.Error("template was modified by rendering")
+	}
+}
+
+func TestCompiledTemplate_Render_PreserveSpace(t *testing.T) {
+	template, err := Compile(readFile(t, "./test/template.docx"))
+	if err != nil {
+		t.Error("compiling failed", err)
+		return
+	}
+	placeholderMap := PlaceholderMap{"key": "  x  "}
+
+	expected, err := Open("./test/template.docx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer expected.Close()
+	if err = expected.ReplaceAll(placeholderMap); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	result, err := template.Render(placeholderMap)
+	if err != nil {
+		t.Error("rendering failed", err)
+		return
+	}
+	doc, err := OpenBytes(result)
+	if err != nil {
+		t.Error("rendered document cannot be opened", err)
+		return
+	}
+
+	// the whitespace of the value is preserved just like with ReplaceAll, even if a text element has several placeholders
+	for _, name := range expected.readingOrder() {
+		if rendered, replaced := string(doc.GetFile(name)), string(expected.GetFile(name)); rendered != replaced {
+			t.Errorf("rendered %s differs from ReplaceAll\nwant=%s\nhave=%s", name, replaced, rendered)
+		}
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), `<w:t xml:space="preserve">  x  -  x  -  x  </w:t>`) {
+		t.Error("text element does not preserve the whitespace of the value")
+	}
+}
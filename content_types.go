@@ -0,0 +1,95 @@
+package docx
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ContentTypesXml is the relative path of the content types inside the docx-archive.
+	ContentTypesXml = "[Content_Types].xml"
+)
+
+// ensureDefaultContentType ensures that the content types declare a default content type for the given
+// file extension (e.g. 'png'). Existing declarations are not modified.
+func (d *Document) ensureDefaultContentType(extension string, contentType string) error {
+	data, err := d.readFile(ContentTypesXml)
+	if err != nil {
+		return err
+	}
+	existing := regexp.MustCompile(fmt.Sprintf(`(?i)<Default\s[^>]*Extension="%s"`, regexp.QuoteMeta(extension)))
+	if existing.Match(data) {
+		return nil
+	}
+	closeTag := strings.LastIndex(string(data), "</Types>")
+	if closeTag < 0 {
+		return fmt.Errorf("unable to modify %s: missing root element", ContentTypesXml)
+	}
+	element := fmt.Sprintf(`<Default Extension="%s" ContentType="%s"/>`, extension, contentType)
+	d.writePart(ContentTypesXml, splice(data, closeTag, closeTag, element))
+	return nil
+}
+
+// ensureContentTypeOverride ensures that the content types declare the given content type for the given part.
+// An existing override of the part is not modified.
+func (d *Document) ensureContentTypeOverride(part string, contentType string) error {
+	data, err := d.readFile(ContentTypesXml)
+	if err != nil {
+		return err
+	}
+	existing := regexp.MustCompile(fmt.Sprintf(`<Override\s[^>]*PartName="/%s"`, regexp.QuoteMeta(part)))
+	if existing.Match(data) {
+		return nil
+	}
+	closeTag := strings.LastIndex(string(data), "</Types>")
+	if closeTag < 0 {
+		return fmt.Errorf("unable to modify %s: missing root element", ContentTypesXml)
+	}
+	element := fmt.Sprintf(`<Override PartName="/%s" ContentType="%s"/>`, part, contentType)
+	d.writePart(ContentTypesXml, splice(data, closeTag, closeTag, element))
+	return nil
+}
+
+// defaultContentType returns the default content type declared for the given file extension, or an empty
+// string if there is none.
+func (d *Document) defaultContentType(extension string) string {
+	data, err := d.readFile(ContentTypesXml)
+	if err != nil {
+		return ""
+	}
+	declaration := regexp.MustCompile(fmt.Sprintf(`(?i)<Default\s[^>]*Extension="%s"[^>]*>`, regexp.QuoteMeta(extension)))
+	contentType := regexp.MustCompile(`ContentType="([^"]*)"`).FindSubmatch(declaration.Find(data))
+	if contentType == nil {
+		return ""
+	}
+	return string(contentType[1])
+}
+
+// contentType returns the content type of the given part. An override declared for the part takes precedence
+// over the default content type of its extension. If neither is declared, an empty string is returned.
+func (d *Document) contentType(part string) string {
+	data, err := d.readFile(ContentTypesXml)
+	if err != nil {
+		return ""
+	}
+	declaration := regexp.MustCompile(fmt.Sprintf(`<Override\s[^>]*PartName="/%s"[^>]*>`, regexp.QuoteMeta(part)))
+	if contentType := regexp.MustCompile(`ContentType="([^"]*)"`).FindSubmatch(declaration.Find(data)); contentType != nil {
+		return string(contentType[1])
+	}
+	return d.defaultContentType(strings.TrimPrefix(path.Ext(part), "."))
+}
+
+// removeContentTypeOverride removes the content type override of the given part, if there is one.
+func (d *Document) removeContentTypeOverride(part string) error {
+	data, err := d.readFile(ContentTypesXml)
+	if err != nil {
+		return err
+	}
+	override := regexp.MustCompile(fmt.Sprintf(`<Override\s[^>]*PartName="/%s"[^>]*/>`, regexp.QuoteMeta(part)))
+	if loc := override.FindIndex(data); loc != nil {
+		d.writePart(ContentTypesXml, splice(data, loc[0], loc[1], ""))
+	}
+	return nil
+}
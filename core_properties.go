@@ -0,0 +1,69 @@
+package docx
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"time"
+)
+
+const (
+	// CorePropertiesXml is the relative path of the core properties (metadata) inside the docx-archive.
+	CorePropertiesXml = "docProps/core.xml"
+)
+
+// StampModification enables stamping the core properties of the document whenever it is written.
+// The modification date (dcterms:modified) is set to the time of writing and the last author
+// (cp:lastModifiedBy) is set to the given name, e.g. the name of the rendering service.
+// Unless enabled, the core properties are left unchanged.
+func (d *Document) StampModification(lastModifiedBy string) {
+	d.stampModification = true
+	d.lastModifiedBy = lastModifiedBy
+}
+
+// stampCoreProperties sets the modification date and the last author of the core properties.
+// If the document does not have core properties, nothing is changed.
+func (d *Document) stampCoreProperties(modified time.Time) error {
+	coreProperties, err := d.readFile(CorePropertiesXml)
+	if err != nil {
+		return nil
+	}
+
+	coreProperties, err = setCoreProperty(coreProperties, "cp:lastModifiedBy", "", html.EscapeString(d.lastModifiedBy))
+	if err != nil {
+		return err
+	}
+	typeAttribute := ""
+	if bytes.Contains(coreProperties, []byte("xmlns:xsi=")) {
+		typeAttribute = ` xsi:type="dcterms:W3CDTF"`
+	}
+	coreProperties, err = setCoreProperty(coreProperties, "dcterms:modified", typeAttribute, modified.UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	d.writePart(CorePropertiesXml, coreProperties)
+	return nil
+}
+
+// setCoreProperty sets the value of the core property with the given qualified name.
+// An existing element keeps its attributes, a missing element is appended using the given attributes.
+func setCoreProperty(data []byte, name string, attributes string, value string) ([]byte, error) {
+	existing := regexp.MustCompile(fmt.Sprintf(`(?s)<%[1]s(\s[^>]*)?/>|<%[1]s(\s[^>]*)?>.*?</%[1]s>`, regexp.QuoteMeta(name)))
+	if loc := existing.FindSubmatchIndex(data); loc != nil {
+		// keep the attributes of the existing element
+		for _, group := range [][]int{loc[2:4], loc[4:6]} {
+			if group[0] >= 0 {
+				attributes = string(data[group[0]:group[1]])
+			}
+		}
+		return splice(data, loc[0], loc[1], fmt.Sprintf("<%s%s>%s</%s>", name, attributes, value, name)), nil
+	}
+
+	rootEnd := bytes.LastIndex(data, []byte("</cp:coreProperties>"))
+	if rootEnd < 0 {
+		return nil, fmt.Errorf("invalid %s, missing root element", CorePropertiesXml)
+	}
+	return splice(data, rootEnd, rootEnd, fmt.Sprintf("<%s%s>%s</%s>", name, attributes, value, name)), nil
+}
@@ -0,0 +1,58 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDocument_StampModification(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.StampModification("render-service")
+
+	modified := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	if err = doc.stampCoreProperties(modified); err != nil {
+		t.Error("stamping failed", err)
+		return
+	}
+
+	coreProperties, err := doc.readFile(CorePropertiesXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []string{
+		`<cp:lastModifiedBy>render-service</cp:lastModifiedBy>`,
+		`<dcterms:modified xsi:type="dcterms:W3CDTF">2024-05-01T12:30:00Z</dcterms:modified>`,
+		`<dcterms:created xsi:type="dcterms:W3CDTF">2020-09-07T13:53:00Z</dcterms:created>`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(string(coreProperties), e) {
+			t.Errorf("core properties do not contain %s", e)
+		}
+	}
+}
+
+func TestDocument_StampModification_Disabled(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var buf strings.Builder
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	if _, modified := doc.modifiedParts[CorePropertiesXml]; modified {
+		t.Error("core properties were modified although stamping is disabled")
+	}
+}
@@ -0,0 +1,51 @@
+package docx
+
+import (
+	"archive/zip"
+	"path"
+	"regexp"
+)
+
+// xmlDeclarationRegex matches the XML declaration at the start of a part, including an optional byte order mark
+var xmlDeclarationRegex = regexp.MustCompile(`^(?:\x{FEFF})?<\?xml\s[^>]*\?>`)
+
+// isXmlPart returns true if the part of the docx-archive with the given path contains XML.
+func isXmlPart(name string) bool {
+	switch path.Ext(name) {
+	case ".xml", ".rels":
+		return true
+	}
+	return false
+}
+
+// preserveDeclaration returns the modified contents of a part with the exact XML declaration of the original contents,
+// since some validators are picky about the declaration (e.g. about standalone="yes").
+// If the original does not have a declaration, the modified contents are returned unchanged.
+func preserveDeclaration(original, modified []byte) []byte {
+	declaration := xmlDeclarationRegex.Find(original)
+	if declaration == nil {
+		return modified
+	}
+	loc := xmlDeclarationRegex.FindIndex(modified)
+	if loc == nil {
+		return splice(modified, 0, 0, string(declaration))
+	}
+	if string(modified[loc[0]:loc[1]]) == string(declaration) {
+		return modified
+	}
+	return splice(modified, loc[0], loc[1], string(declaration))
+}
+
+// copyFileHeader returns a header for writing a file of the original docx-archive, keeping its name,
+// compression method, modification time, comment and attributes. The sizes and checksums are computed on write.
+func copyFileHeader(original zip.FileHeader) *zip.FileHeader {
+	return &zip.FileHeader{
+		Name:          original.Name,
+		Comment:       original.Comment,
+		Method:        original.Method,
+		Modified:      original.Modified,
+		ModifiedTime:  original.ModifiedTime,
+		ModifiedDate:  original.ModifiedDate,
+		ExternalAttrs: original.ExternalAttrs,
+	}
+}
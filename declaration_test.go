@@ -0,0 +1,119 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDocument_Write_PreservesArchive(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceAll(PlaceholderMap{"key": "value"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	// the declaration of a part which was replaced entirely is restored
+	documentXml := string(doc.GetFile(DocumentXml))
+	documentXml = documentXml[strings.Index(documentXml, "?>")+2:]
+	if err = doc.SetFile(DocumentXml, []byte(`<?xml version="1.0"?>`+documentXml)); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = doc.SetDefaultTabStop(360); err != nil {
+		t.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("writing failed", err)
+		return
+	}
+	written, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	original := doc.zipFile.File
+	if len(written.File) != len(original) {
+		t.Errorf("unexpected number of parts, want=%d, have=%d", len(original), len(written.File))
+		return
+	}
+	for i, file := range written.File {
+		if file.Name != original[i].Name {
+			t.Errorf("unexpected part at %d, want=%s, have=%s", i, original[i].Name, file.Name)
+			continue
+		}
+		if file.Method != original[i].Method || !file.Modified.Equal(original[i].Modified) {
+			t.Errorf("header of %s was not preserved", file.Name)
+		}
+		if !isXmlPart(file.Name) {
+			continue
+		}
+		originalBytes, err := readZipFile(original[i])
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		writtenBytes, err := readZipFile(file)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		want := string(xmlDeclarationRegex.Find(originalBytes))
+		if have := string(xmlDeclarationRegex.Find(writtenBytes)); have != want {
+			t.Errorf("declaration of %s was not preserved, want=%s, have=%s", file.Name, want, have)
+		}
+	}
+}
+
+func TestPreserveDeclaration(t *testing.T) {
+	declaration := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`
+	tests := []struct {
+		original string
+		modified string
+		expected string
+	}{
+		{declaration + "<a/>", "<b/>", declaration + "<b/>"},
+		{declaration + "<a/>", `<?xml version="1.0"?><b/>`, declaration + "<b/>"},
+		{declaration + "<a/>", declaration + "<b/>", declaration + "<b/>"},
+		{"<a/>", `<?xml version="1.0"?><b/>`, `<?xml version="1.0"?><b/>`},
+	}
+	for _, tt := range tests {
+		if have := string(preserveDeclaration([]byte(tt.original), []byte(tt.modified))); have != tt.expected {
+			t.Errorf("unexpected result, want=%s, have=%s", tt.expected, have)
+		}
+	}
+}
+
+func TestDocument_declarations(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	// the declarations are recorded once the parts are read, Write does not read the modified parts again
+	if _, read := doc.declarations[SettingsXml]; read {
+		t.Errorf("declaration of %s recorded before it was read", SettingsXml)
+	}
+	if err = doc.SetDefaultTabStop(360); err != nil {
+		t.Error(err)
+		return
+	}
+	for _, name := range []string{DocumentXml, SettingsXml} {
+		declaration, read := doc.declarations[name]
+		if !read || !strings.HasPrefix(string(declaration), "<?xml ") {
+			t.Errorf("declaration of %s was not recorded, have=%q", name, declaration)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package docx
+
+import (
+	"sort"
+	"strings"
+)
+
+// DelimiterPair is a pair of open and close delimiters, e.g. '{{' and '}}'.
+type DelimiterPair struct {
+	Open  string
+	Close string
+}
+
+// DelimiterPairs defines multiple pairs of delimiters whose placeholders are parsed simultaneously,
+// e.g. to migrate a template from '{old}' to '{{new}}' placeholders gradually. Delimiters may consist of multiple
+// characters. If the open delimiters of multiple pairs match at the same position, the longest one wins,
+// so '{{new}}' is not mistaken for the nested placeholder '{new}' inside of braces.
+//
+// The placeholders are matched by their key regardless of the pair: their text is reported with the
+// OpenDelimiter and CloseDelimiter (see Placeholder.Text), e.g. both '{key}' and '{{key}}' are replaced by the key 'key'.
+// Note that parts which are not parsed (altChunk parts and diagrams) only support OpenDelimiter and CloseDelimiter.
+//
+// By default, no pairs are set and the placeholders are delimited by OpenDelimiter and CloseDelimiter only.
+// If set, the list must contain every pair, including '{' and '}' if they are still used.
+// It must be set before a document is opened.
+var DelimiterPairs []DelimiterPair
+
+// sortedDelimiterPairs returns the DelimiterPairs ordered by the length of their open delimiter, longest first.
+func sortedDelimiterPairs() []DelimiterPair {
+	pairs := append([]DelimiterPair{}, DelimiterPairs...)
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return len(pairs[i].Open) > len(pairs[j].Open)
+	})
+	return pairs
+}
+
+// canonicalPlaceholderText returns the placeholder text with the delimiters of the matching pair replaced
+// by OpenDelimiter and CloseDelimiter. If DelimiterPairs are not set or none matches, the text is returned unchanged.
+func canonicalPlaceholderText(text string) string {
+	for _, pair := range sortedDelimiterPairs() {
+		if len(text) >= len(pair.Open)+len(pair.Close) && strings.HasPrefix(text, pair.Open) && strings.HasSuffix(text, pair.Close) {
+			return string(OpenDelimiter) + text[len(pair.Open):len(text)-len(pair.Close)] + string(CloseDelimiter)
+		}
+	}
+	return text
+}
+
+// findDelimited returns the start and end positions of all placeholders delimited by one of the DelimiterPairs
+// inside the text, in order. Placeholders which contain an open delimiter are nested and skipped,
+// unless their key is quoted (see KeyQuote).
+func findDelimited(text string) [][2]int {
+	pairs := sortedDelimiterPairs()
+	containsOpenDelimiter := func(s string) bool {
+		for _, pair := range pairs {
+			if strings.Contains(s, pair.Open) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var found [][2]int
+	for i := 0; i < len(text); {
+		end := -1
+		for _, pair := range pairs {
+			if pair.Open == "" || pair.Close == "" || !strings.HasPrefix(text[i:], pair.Open) {
+				continue
+			}
+			// a quoted key ends at the closing quote which is directly followed by the close delimiter
+			if strings.HasPrefix(text[i+len(pair.Open):], string(KeyQuote)) {
+				quoteStart := i + len(pair.Open) + 1
+				if closePos := strings.Index(text[quoteStart:], string(KeyQuote)+pair.Close); closePos >= 0 {
+					end = quoteStart + closePos + 1 + len(pair.Close)
+					break
+				}
+			}
+			closePos := strings.Index(text[i+len(pair.Open):], pair.Close)
+			if closePos < 0 {
+				continue
+			}
+			if containsOpenDelimiter(text[i+len(pair.Open) : i+len(pair.Open)+closePos]) {
+				continue
+			}
+			end = i + len(pair.Open) + closePos + len(pair.Close)
+			break
+		}
+		if end < 0 {
+			i++
+			continue
+		}
+		found = append(found, [2]int{i, end})
+		i = end
+	}
+	return found
+}
+
+// parseDelimiterPairs parses the placeholders of all DelimiterPairs inside the text of the runs.
+// The text of all runs of a paragraph is scanned as a whole, so placeholders may span multiple runs.
+// If crossParagraph is set, the text of all runs is scanned as a whole.
+func parseDelimiterPairs(runs DocumentRuns, docBytes []byte, crossParagraph bool) []*Placeholder {
+	var placeholders []*Placeholder
+
+	parseGroup := func(group DocumentRuns) {
+		var text strings.Builder
+		offsets := make([]int, len(group))
+		for i, run := range group {
+			offsets[i] = text.Len()
+			text.WriteString(run.GetText(docBytes))
+		}
+
+		for _, match := range findDelimited(text.String()) {
+			placeholder := new(Placeholder)
+			for i, run := range group {
+				runStart, runEnd := offsets[i], offsets[i]+len(run.GetText(docBytes))
+				start, end := maxInt(match[0], runStart), minInt(match[1], runEnd)
+				if start >= end {
+					continue
+				}
+				position := Position{Start: int64(start - runStart), End: int64(end - runStart)}
+				placeholder.Fragments = append(placeholder.Fragments, NewPlaceholderFragment(0, position, run))
+			}
+			placeholders = append(placeholders, placeholder)
+		}
+	}
+
+	var group DocumentRuns
+	for _, run := range runs.WithText() {
+		if len(group) > 0 && !crossParagraph && group[len(group)-1].Paragraph != run.Paragraph {
+			parseGroup(group)
+			group = nil
+		}
+		group = append(group, run)
+	}
+	parseGroup(group)
+
+	return placeholders
+}
+
+// minInt returns the smaller of both integers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of both integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
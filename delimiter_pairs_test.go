@@ -0,0 +1,62 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindDelimited(t *testing.T) {
+	DelimiterPairs = []DelimiterPair{{Open: "{", Close: "}"}, {Open: "{{", Close: "}}"}, {Open: "[[", Close: "]]"}}
+	defer func() {
+		DelimiterPairs = nil
+	}()
+
+	text := "{a} {{b}} [[c]] {d {e} [[f"
+	expected := []string{"{a}", "{{b}}", "[[c]]", "{e}"}
+	var found []string
+	for _, match := range findDelimited(text) {
+		found = append(found, text[match[0]:match[1]])
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("unexpected placeholders, want=%v, have=%v", expected, found)
+	}
+
+	for text, expected := range map[string]string{"{{b}}": "{b}", "[[c]]": "{c}", "{a}": "{a}", "plain": "plain"} {
+		if canonical := canonicalPlaceholderText(text); canonical != expected {
+			t.Errorf("canonicalPlaceholderText(%s), want=%s, have=%s", text, expected, canonical)
+		}
+	}
+}
+
+func TestDelimiterPairs(t *testing.T) {
+	DelimiterPairs = []DelimiterPair{{Open: "{", Close: "}"}, {Open: "{{", Close: "}}"}}
+	defer func() {
+		DelimiterPairs = nil
+	}()
+
+	doc, err := Open("./test/delimiter_pairs.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var placeholders []string
+	for _, placeholder := range doc.filePlaceholders[DocumentXml] {
+		placeholders = append(placeholders, placeholder.Text(doc.GetFile(DocumentXml)))
+	}
+	expected := []string{"{old}", "{new}", "{new}", "{old}"}
+	if !reflect.DeepEqual(placeholders, expected) {
+		t.Errorf("unexpected placeholders, want=%v, have=%v", expected, placeholders)
+	}
+
+	if err = doc.ReplaceAll(PlaceholderMap{"old": "OLD", "new": "NEW"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	if !strings.Contains(text, "Old: OLD, new: NEW\nNEW and OLD") {
+		t.Errorf("placeholders of both delimiter pairs must be replaced: %s", text)
+	}
+}
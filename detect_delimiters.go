@@ -0,0 +1,111 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// detectableDelimiters are the delimiter pairs which are recognized by DetectDelimiters.
+var detectableDelimiters = []DelimiterPair{
+	{Open: "{", Close: "}"},
+	{Open: "{{", Close: "}}"},
+	{Open: "${", Close: "}"},
+	{Open: "%", Close: "%"},
+	{Open: "%%", Close: "%%"},
+	{Open: "[[", Close: "]]"},
+	{Open: "<<", Close: ">>"},
+	{Open: "\u00AB", Close: "\u00BB"},
+}
+
+// detectableKey is the pattern of the keys which are recognized by DetectDelimiters, e.g. 'customer.name' or ' name '.
+const detectableKey = `\s?[A-Za-z_][\w.\-]*\s?`
+
+// minDetectedPlaceholders is the number of placeholders from which on DetectDelimiters is fully confident.
+const minDetectedPlaceholders = 3
+
+// DetectDelimiters guesses the delimiters of the placeholders of the given docx-archive, e.g. to configure
+// ChangeOpenCloseDelimiter or DelimiterPairs for templates of unknown origin. The text of the main document,
+// the headers and the footers is searched for keys delimited by common delimiters: '{}', '{{}}', '${}', '%%', '%%%%',
+// '[[]]', '<<>>' and guillemets. Only keys starting with a letter or an underscore are recognized.
+//
+// The confidence (0 to 1) is the share of the placeholders of the returned delimiters among all recognized
+// placeholders, reduced if less than 3 placeholders have been found. Callers should ask for the delimiters
+// if the confidence is low. If no placeholders are found or the archive cannot be read, empty delimiters
+// and a confidence of 0 are returned.
+func DetectDelimiters(b []byte) (open, close string, confidence float64) {
+	text, err := archiveText(b)
+	if err != nil {
+		return "", "", 0
+	}
+
+	matches := make([][][]int, len(detectableDelimiters))
+	for i, pair := range detectableDelimiters {
+		pattern := regexp.MustCompile(regexp.QuoteMeta(pair.Open) + detectableKey + regexp.QuoteMeta(pair.Close))
+		matches[i] = pattern.FindAllStringIndex(text, -1)
+	}
+
+	// a match inside of the match of another pair is part of it, e.g. '{key}' inside of '{{key}}' or '${key}'
+	counts := make([]int, len(detectableDelimiters))
+	total := 0
+	for i := range matches {
+		for _, match := range matches[i] {
+			if !enclosedMatch(match, matches, i) {
+				counts[i]++
+				total++
+			}
+		}
+	}
+	if total == 0 {
+		return "", "", 0
+	}
+
+	best := 0
+	for i, count := range counts {
+		if count > counts[best] {
+			best = i
+		}
+	}
+	confidence = float64(counts[best]) / float64(total)
+	if counts[best] < minDetectedPlaceholders {
+		confidence *= float64(counts[best]) / minDetectedPlaceholders
+	}
+	return detectableDelimiters[best].Open, detectableDelimiters[best].Close, confidence
+}
+
+// enclosedMatch returns true if the match of the pair with the given index is enclosed by a longer match of another pair.
+func enclosedMatch(match []int, matches [][][]int, pair int) bool {
+	for i := range matches {
+		if i == pair {
+			continue
+		}
+		for _, other := range matches[i] {
+			if other[0] <= match[0] && match[1] <= other[1] && other[1]-other[0] > match[1]-match[0] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// archiveText returns the plain text of all text parts (the main document, headers, footers etc.) of the docx-archive.
+func archiveText(b []byte) (string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return "", err
+	}
+	var text strings.Builder
+	for _, file := range reader.File {
+		if path.Dir(file.Name) != "word" || path.Ext(file.Name) != ".xml" {
+			continue
+		}
+		data, err := readZipFile(file)
+		if err != nil {
+			return "", err
+		}
+		text.WriteString(plainText(data))
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,38 @@
+package docx
+
+import (
+	"testing"
+)
+
+func TestDetectDelimiters(t *testing.T) {
+	tests := []struct {
+		file          string
+		open, close   string
+		minConfidence float64
+		maxConfidence float64
+	}{
+		{"./test/template.docx", "{", "}", 1, 1},
+		// the header and footer of the fixture use '{key}', the percentages are no placeholders
+		{"./test/mustache.docx", "{{", "}}", 0.75, 0.75},
+		// only two placeholders, the confidence is reduced
+		{"./test/minimal.docx", "{", "}", 0.6, 0.7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			open, close, confidence := DetectDelimiters(readFile(t, tt.file))
+			if open != tt.open || close != tt.close {
+				t.Errorf("unexpected delimiters, want=%s%s, have=%s%s", tt.open, tt.close, open, close)
+			}
+			if confidence < tt.minConfidence || confidence > tt.maxConfidence {
+				t.Errorf("unexpected confidence, want=%.2f-%.2f, have=%.2f", tt.minConfidence, tt.maxConfidence, confidence)
+			}
+		})
+	}
+}
+
+func TestDetectDelimiters_Invalid(t *testing.T) {
+	open, close, confidence := DetectDelimiters([]byte("no archive"))
+	if open != "" || close != "" || confidence != 0 {
+		t.Errorf("expected no delimiters, have %s%s with confidence %.2f", open, close, confidence)
+	}
+}
@@ -0,0 +1,140 @@
+package docx
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// DiagramPathRegex matches the data and drawing parts of all diagrams (SmartArt) inside the docx-archive.
+	DiagramPathRegex = regexp.MustCompile(`^word/diagrams/(data|drawing)[0-9]*\.xml$`)
+
+	// drawingParagraphRegex matches the DrawingML paragraphs (<a:p>) including their content
+	drawingParagraphRegex = regexp.MustCompile(`(?s)<a:p(?:\s[^>]*)?>.*?</a:p>`)
+	// drawingTextRegex matches the DrawingML text elements (<a:t>) and captures their content
+	drawingTextRegex = regexp.MustCompile(`<a:t(?:\s[^>]*)?>([^<]*)</a:t>`)
+)
+
+// findDiagrams returns the paths of the data and drawing parts of all diagrams (SmartArt) inside the archive.
+func (d *Document) findDiagrams() []string {
+	var parts []string
+	for _, file := range d.zipFile.File {
+		if DiagramPathRegex.MatchString(file.Name) {
+			parts = append(parts, file.Name)
+		}
+	}
+	sort.Strings(parts)
+	return parts
+}
+
+// replaceDiagrams replaces all placeholders of the placeholderMap inside the text of the diagrams (SmartArt).
+//
+// The text of a diagram is stored twice: the data part (word/diagrams/dataN.xml) contains the text of the nodes,
+// the drawing part (word/diagrams/drawingN.xml) caches the rendered shapes. Word displays the cached shapes
+// until the diagram is laid out again, hence both parts are replaced. Other applications might only read one of them.
+// The replaced text keeps the formatting of the run which contains the start of the placeholder,
+// the values cannot be styled.
+func (d *Document) replaceDiagrams(placeholderMap PlaceholderMap) error {
+	for _, part := range d.diagramFiles {
+		data, err := d.readFile(part)
+		if err != nil {
+			return err
+		}
+		replaced := replaceDrawingText(data, func(key string) (string, bool) {
+			value, ok := placeholderMap[key]
+			if !ok {
+				return "", false
+			}
+			return d.plainValue(key, value), true
+		})
+		if !bytes.Equal(replaced, data) {
+			d.writePart(part, replaced)
+		}
+	}
+	return nil
+}
+
+// replaceDrawingText replaces the placeholders inside the text elements (<a:t>) of all DrawingML paragraphs.
+// Placeholders may be fragmented across the runs of a paragraph. The value is inserted into the text element
+// which contains the open delimiter, the rest of the placeholder is cut from the following text elements.
+// The valueFunc returns the value of a key, or false if the placeholder should be left as it is.
+func replaceDrawingText(data []byte, valueFunc func(key string) (string, bool)) []byte {
+	return drawingParagraphRegex.ReplaceAllFunc(data, func(paragraph []byte) []byte {
+		matches := drawingTextRegex.FindAllSubmatchIndex(paragraph, -1)
+		if len(matches) == 0 {
+			return paragraph
+		}
+		texts := make([]string, len(matches))
+		for i, match := range matches {
+			texts[i] = html.UnescapeString(string(paragraph[match[2]:match[3]]))
+		}
+
+		// offsets returns the start offset of every text inside the concatenated paragraph text
+		offsets := func() []int {
+			offs := make([]int, len(texts))
+			for i := 1; i < len(texts); i++ {
+				offs[i] = offs[i-1] + len(texts[i-1])
+			}
+			return offs
+		}
+		// textAt returns the index of the text which contains the given offset
+		textAt := func(offs []int, pos int) int {
+			return sort.Search(len(offs), func(i int) bool { return offs[i] > pos }) - 1
+		}
+
+		changed := false
+		searchFrom := 0
+		for {
+			text := strings.Join(texts, "")
+			start := strings.IndexRune(text[searchFrom:], OpenDelimiter)
+			if start < 0 {
+				break
+			}
+			start += searchFrom
+			end := strings.IndexRune(text[start:], CloseDelimiter)
+			if end < 0 {
+				break
+			}
+			end += start + len(string(CloseDelimiter))
+
+			// nested open delimiters, continue with the inner placeholder
+			if inner := strings.LastIndex(text[start:end-1], string(OpenDelimiter)); inner > 0 {
+				searchFrom = start + inner
+				continue
+			}
+
+			value, ok := valueFunc(RemovePlaceholderDelimiter(text[start:end]))
+			if !ok {
+				searchFrom = end
+				continue
+			}
+
+			offs := offsets()
+			first, last := textAt(offs, start), textAt(offs, end-1)
+			if first == last {
+				texts[first] = texts[first][:start-offs[first]] + value + texts[first][end-offs[first]:]
+			} else {
+				texts[first] = texts[first][:start-offs[first]] + value
+				for i := first + 1; i < last; i++ {
+					texts[i] = ""
+				}
+				texts[last] = texts[last][end-offs[last]:]
+			}
+			changed = true
+			searchFrom = start + len(value)
+		}
+		if !changed {
+			return paragraph
+		}
+
+		// write the texts back, starting from the end to keep the match positions valid
+		result := append([]byte{}, paragraph...)
+		for i := len(matches) - 1; i >= 0; i-- {
+			result = splice(result, matches[i][2], matches[i][3], html.EscapeString(texts[i]))
+		}
+		return result
+	})
+}
@@ -0,0 +1,45 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceAll_Diagram(t *testing.T) {
+	doc, err := Open("./test/diagram.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"company": "ACME", "ceo": "Jane Doe", "cto": "John <Doe>"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	data, err := doc.readFile("word/diagrams/data1.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, expected := range []string{
+		"<a:t>Jane Doe</a:t>",
+		"<a:t>John &lt;Doe&gt;</a:t>",
+		`<a:rPr lang="en-US" b="1"/><a:t> &amp; {unknown}</a:t>`,
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("expected %s inside the diagram data", expected)
+		}
+	}
+
+	drawing, err := doc.readFile("word/diagrams/drawing1.xml")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(drawing), "<a:t>Jane Doe</a:t>") {
+		t.Error("cached diagram drawing was not replaced")
+	}
+}
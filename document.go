@@ -3,22 +3,33 @@ package docx
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
-
-	"golang.org/x/net/html"
+	"time"
+	"unicode/utf8"
 )
 
 const (
-	// DocumentXml is the relative path where the actual document content resides inside the docx-archive.
+	// DocumentXml is the relative path where the actual document content usually resides inside the docx-archive.
+	// The path of the main document part of a Document is returned by MainPart.
 	DocumentXml = "word/document.xml"
+	// FootnotesXml is the relative path of the footnotes inside the docx-archive.
+	FootnotesXml = "word/footnotes.xml"
+	// EndnotesXml is the relative path of the endnotes inside the docx-archive.
+	EndnotesXml = "word/endnotes.xml"
+	// CommentsXml is the relative path of the comments inside the docx-archive.
+	CommentsXml = "word/comments.xml"
+	// GlossaryDocumentXml is the relative path of the glossary document (building blocks) inside the docx-archive.
+	GlossaryDocumentXml = "word/glossary/document.xml"
 )
 
 var (
@@ -46,12 +57,97 @@ type Document struct {
 	footerFiles []string
 	// paths to all media files inside the zip archive
 	mediaFiles []string
+	// paths to all parts embedded into the document using altChunk
+	altChunkFiles []string
+	// mainPart is the path of the main document part, usually DocumentXml
+	mainPart string
+	// paths to the data and drawing parts of all diagrams (SmartArt)
+	diagramFiles []string
+	// paths to the parts of all charts referenced by the main document, the headers or the footers
+	chartFiles []string
+	// paths to all additional text parts (e.g. footnotes) which were loaded by ReplaceEverywhere
+	additionalFiles []string
 	// The document contains multiple files which eventually need a parser each.
 	// The map key is the file path inside the document to which the parser belongs.
 	runParsers map[string]*RunParser
 
 	filePlaceholders map[string][]*Placeholder
 	fileReplacers    map[string]*Replacer
+
+	// files of the archive which are not subject to replacing, but were modified or added through the Document API
+	modifiedParts FileMap
+	// files of the archive which were removed through the Document API and are not written
+	removedParts map[string]bool
+	// XML declarations of the parts which were read from the archive, they are kept on write (see preserveDeclaration)
+	declarations map[string][]byte
+
+	// if set, the modification date and last author of the core properties are updated on write
+	stampModification bool
+	lastModifiedBy    string
+
+	// valueFormatter is applied to every value before it is inserted, may be nil
+	valueFormatter ValueFormatter
+
+	// htmlConverter converts HTML values, HTMLToRuns is used if nil
+	htmlConverter HTMLConverter
+
+	// values longer than maxValueRunes are truncated and suffixed with truncateSuffix, 0 disables truncation
+	maxValueRunes    int
+	keyMaxValueRunes map[string]int
+	truncateSuffix   string
+
+	// font name and size (in points) of inserted values if the placeholder run does not specify them
+	defaultFont     string
+	defaultFontSize int
+
+	// highlightColor is the highlight color of inserted values, empty if they are not highlighted
+	highlightColor string
+
+	// language is the proofing language of inserted values (e.g. 'fr-FR'), empty to keep the language of the placeholder
+	language string
+
+	// if set, values are cased like their keys, see SetMatchKeyCasing
+	matchKeyCasing bool
+
+	// mediaCounter is the number of the last media file added, see addMedia
+	mediaCounter int
+	// inserted images larger than maxMediaBytes are rejected, 0 disables the limit
+	maxMediaBytes int64
+	// media files of the images inserted by the current replacement call by the hash of their data, see shareMedia
+	sharedMedia map[[sha256.Size]byte]string
+
+	// qrEncoder generates the images of ReplaceQRCode, may be nil
+	qrEncoder QREncoder
+
+	// locale formats numbers and dates, may be nil
+	locale *Locale
+
+	// if set, quotes and dashes of string values are converted, see SetSmartTypography
+	smartTypography bool
+
+	// if set, ReplaceMultiple parses the files again after every pair, see SetRescanValues
+	rescanValues bool
+
+	// onMissingKey is called for placeholders without an entry in the PlaceholderMap, may be nil
+	onMissingKey MissingKeyFunc
+
+	// sliceSeparator joins the elements of slice values which are inserted as text
+	sliceSeparator string
+
+	// if set, blank lines of text values start new paragraphs, see SetExpandParagraphs
+	expandParagraphs bool
+
+	// if set, the placeholders inside of charts are replaced, see SetReplaceCharts
+	replaceCharts bool
+
+	// the spaces of the values of these keys are converted into non-breaking spaces, see SetNonBreakingKeys
+	nonBreakingKeys map[string]bool
+
+	// the style of the paragraphs which are inserted, see SetInsertParagraphStyle
+	insertParagraphStyle string
+
+	// if set, placeholders may span paragraphs, see SetAllowCrossParagraphPlaceholders
+	crossParagraphPlaceholders bool
 }
 
 // Open will open and parse the file pointed to by path.
@@ -62,12 +158,27 @@ func Open(path string) (*Document, error) {
 		return nil, fmt.Errorf("unable to open .docx docxFile: %s", err)
 	}
 
-	rc, err := zip.OpenReader(path)
+	info, err := fh.Stat()
+	if err != nil {
+		fh.Close()
+		return nil, fmt.Errorf("unable to stat .docx docxFile: %s", err)
+	}
+
+	rc, err := zip.NewReader(fh, info.Size())
 	if err != nil {
+		fh.Close()
 		return nil, fmt.Errorf("unable to open zip reader: %s", err)
 	}
 
-	return newDocument(&rc.Reader, path, fh)
+	doc, err := OpenZip(rc)
+	if err != nil {
+		fh.Close()
+		return nil, err
+	}
+	doc.path = path
+	doc.docxFile = fh
+
+	return doc, nil
 }
 
 // OpenBytes allows to create a Document from a byte slice.
@@ -80,77 +191,153 @@ func OpenBytes(b []byte) (*Document, error) {
 		return nil, fmt.Errorf("unable to open zip reader: %s", err)
 	}
 
-	return newDocument(rc, "", nil)
+	return OpenZip(rc)
+}
+
+// OpenZip allows to create a Document from an already opened zip archive.
+// This is useful if the archive is inspected by other means and should not be read twice.
+// The reader must stay usable until the document has been written, since all files which are
+// not modified are copied from it.
+func OpenZip(zipFile *zip.Reader) (*Document, error) {
+	return newDocument(zipFile)
 }
 
 // newDocument will create a new document struct given the zipFile.
-// The params 'path' and 'docxFile' may be empty/nil in case the document is created from a byte source directly.
 //
-// newDocument will parse the docx archive and ValidatePositions that at least a 'document.xml' exists.
-// If 'word/document.xml' is missing, an error is returned since the docx cannot be correct.
+// newDocument will parse the docx archive and ValidatePositions that at least the main document part exists.
+// The main document part is resolved through the package relationships, it is usually 'word/document.xml'.
+// If it is missing, an error is returned since the docx cannot be correct.
 // Then all files are parsed for their runs before returning the new document.
-func newDocument(zipFile *zip.Reader, path string, docxFile *os.File) (*Document, error) {
+func newDocument(zipFile *zip.Reader) (*Document, error) {
 	doc := &Document{
-		docxFile:         docxFile,
 		zipFile:          zipFile,
-		path:             path,
 		files:            make(FileMap),
+		modifiedParts:    make(FileMap),
+		removedParts:     make(map[string]bool),
+		declarations:     make(map[string][]byte),
 		runParsers:       make(map[string]*RunParser),
 		filePlaceholders: make(map[string][]*Placeholder),
 		fileReplacers:    make(map[string]*Replacer),
+		keyMaxValueRunes: make(map[string]int),
+		truncateSuffix:   DefaultTruncateSuffix,
+		sliceSeparator:   DefaultSliceSeparator,
 	}
 
 	ResetRunIdCounter()
 	ResetFragmentIdCounter()
 
+	mainPart, err := doc.resolveMainPart()
+	if err != nil {
+		return nil, fmt.Errorf("error resolving main document part: %s", err)
+	}
+	doc.mainPart = mainPart
+
 	if err := doc.parseArchive(); err != nil {
 		return nil, fmt.Errorf("error parsing document: %s", err)
 	}
 
 	// a valid docx document should really contain a document.xml :)
-	if _, exists := doc.files[DocumentXml]; !exists {
-		return nil, fmt.Errorf("invalid docx archive, %s is missing", DocumentXml)
+	if _, exists := doc.files[doc.mainPart]; !exists {
+		return nil, fmt.Errorf("invalid docx archive, %s is missing", doc.mainPart)
+	}
+	if err := doc.loadSectionParts(); err != nil {
+		return nil, fmt.Errorf("error loading headers and footers: %s", err)
 	}
 
 	// parse all files
-	for name, data := range doc.files {
-
-		// find all runs
-		doc.runParsers[name] = NewRunParser(data)
-		err := doc.runParsers[name].Execute()
-		if err != nil {
+	for name := range doc.files {
+		if err := doc.parseFile(name); err != nil {
 			return nil, err
 		}
+	}
 
-		// parse placeholders and initialize replacers
-		placeholder, err := ParsePlaceholders(doc.runParsers[name].Runs(), data)
-		if err != nil {
-			return nil, err
-		}
-		doc.filePlaceholders[name] = placeholder
-		doc.fileReplacers[name] = NewReplacer(data, placeholder)
+	altChunkFiles, err := doc.findAltChunks()
+	if err != nil {
+		return nil, err
+	}
+	doc.altChunkFiles = altChunkFiles
+	doc.diagramFiles = doc.findDiagrams()
+	chartFiles, err := doc.findCharts()
+	if err != nil {
+		return nil, err
 	}
+	doc.chartFiles = chartFiles
 
 	return doc, nil
 }
 
-// ReplaceAll will iterate over all files and perform the replacement according to the PlaceholderMap.
-func (d *Document) ReplaceAll(placeholderMap PlaceholderMap) error {
-	for name := range d.files {
-		changedBytes, err := d.replace(placeholderMap, name)
-		if err != nil {
+// SetAllowCrossParagraphPlaceholders defines whether a placeholder may span across paragraph boundaries.
+// If enabled, a placeholder opened in one paragraph and closed in a following one (e.g. '{foo' and 'bar}')
+// is assembled just like a placeholder which is fragmented inside a single paragraph. The value is inserted into the
+// first paragraph and the rest of the placeholder is removed from the following ones, which may be unexpected.
+// Hence it is disabled by default and such placeholders are discarded with a warning.
+// All files are parsed again, hence it should be set before replacing.
+func (d *Document) SetAllowCrossParagraphPlaceholders(allowed bool) error {
+	d.crossParagraphPlaceholders = allowed
+	for name := range d.fileReplacers {
+		if err := d.parseFile(name); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		err = d.SetFile(name, changedBytes)
-		if err != nil {
-			return err
-		}
+// parseFile will find all runs and placeholders of the given file and initialize the replacer of the file.
+// It must be called whenever the bytes of the file are modified without using its replacer, since all
+// positions would be invalid otherwise.
+func (d *Document) parseFile(name string) error {
+	data := d.files[name]
+
+	// find all runs
+	runParser := NewRunParser(data)
+	err := runParser.Execute()
+	if err != nil {
+		return err
+	}
+
+	// parse placeholders and initialize replacers
+	placeholder, err := parsePlaceholders(runParser.Runs(), data, d.crossParagraphPlaceholders)
+	if err != nil {
+		return err
 	}
+	d.runParsers[name] = runParser
+	d.filePlaceholders[name] = placeholder
+	// overlapping placeholders would corrupt each other, they are reported by OverlappingPlaceholders instead
+	d.fileReplacers[name] = NewReplacer(data, withoutOverlaps(placeholder, data))
 	return nil
 }
 
+// ReplaceAll will iterate over all files and perform the replacement according to the PlaceholderMap.
+// The elements of slice values can be accessed by their index, e.g. {tags[0]} is replaced by the first element of 'tags'.
+// Pointer values are replaced by the values they point to. The placeholders of nil pointers are treated like the
+// placeholders of missing keys, they are passed to the MissingKeyFunc (see SetOnMissingKey) or left untouched.
+// The files are replaced in reading order and the keys in alphabetical order, so inserted images get the same
+// media names and relationship ids on every run.
+func (d *Document) ReplaceAll(placeholderMap PlaceholderMap) error {
+	return d.ReplaceAllContext(context.Background(), placeholderMap)
+}
+
+// ErrInvalidUTF8 is returned by ReplaceAllBytes if a value is not valid UTF-8.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// ReplaceAllBytes works like ReplaceAll, but takes the values as bytes.
+// The bytes must be valid UTF-8, which is the encoding of the document parts. Otherwise, an error wrapping
+// ErrInvalidUTF8 is returned and nothing is replaced. The bytes are inserted verbatim, only the XML special characters
+// (&, <, >, ' and ") are escaped and newlines are converted into breaks, just like the values of ReplaceAll.
+// The values are not truncated (see SetMaxValueRunes).
+func (d *Document) ReplaceAllBytes(byteMap map[string][]byte) error {
+	placeholderMap := make(PlaceholderMap, len(byteMap))
+	for key, value := range byteMap {
+		if !utf8.Valid(value) {
+			return fmt.Errorf("value of %s: %w", key, ErrInvalidUTF8)
+		}
+		placeholderMap[key] = value
+	}
+	return d.ReplaceAll(placeholderMap)
+}
+
 // Replace will attempt to replace the given key with the value in every file.
+// Since the value is a string, there are no indexed keys (e.g. {tags[0]}), see ReplaceAll and ReplaceMultiple.
 func (d *Document) Replace(key, value string) error {
 	for name := range d.files {
 		changedBytes, err := d.replace(PlaceholderMap{key: value}, name)
@@ -162,7 +349,39 @@ func (d *Document) Replace(key, value string) error {
 			return err
 		}
 	}
-	return nil
+	return d.replaceUnparsedParts(PlaceholderMap{key: value})
+}
+
+// ReplaceFirst will replace only the first occurrence of the given key with the value, leaving all following
+// occurrences untouched.
+// The files are searched in reading order, that is the headers, the main document and the footers (see Text).
+// If the key does not exist in any file, ErrPlaceholderNotFound is returned.
+// Since the value is a string, there are no indexed keys (e.g. {tags[0]}), see ReplaceAll and ReplaceMultiple.
+func (d *Document) ReplaceFirst(key, value string) error {
+	for _, name := range d.readingOrder() {
+		replacer := d.fileReplacers[name]
+		err := d.replaceValue(replacer, key, value, 1)
+		if errors.Is(err, ErrPlaceholderNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return d.SetFile(name, replacer.Bytes())
+	}
+	return ErrPlaceholderNotFound
+}
+
+// replaceUnparsedParts replaces the placeholders of the placeholderMap inside the parts which are not
+// parsed for runs, that is the altChunk parts, the diagrams and the charts.
+func (d *Document) replaceUnparsedParts(placeholderMap PlaceholderMap) error {
+	if err := d.replaceAltChunks(placeholderMap); err != nil {
+		return err
+	}
+	if err := d.replaceDiagrams(placeholderMap); err != nil {
+		return err
+	}
+	return d.replaceChartParts(placeholderMap)
 }
 
 // Get placeholders in a human readable form
@@ -186,15 +405,25 @@ func (d *Document) GetPlaceHoldersList() ([]string, error) {
 // replace will create a parser on the given bytes, execute it and replace every placeholders found with the data
 // from the placeholderMap.
 func (d *Document) replace(placeholderMap PlaceholderMap, file string) ([]byte, error) {
+	return d.replaceContext(context.Background(), placeholderMap, file)
+}
+
+// replaceContext is like replace, but stops replacing once the context is done.
+// In this case, the bytes replaced so far are returned together with the error of the context.
+func (d *Document) replaceContext(ctx context.Context, placeholderMap PlaceholderMap, file string) ([]byte, error) {
 	if _, ok := d.runParsers[file]; !ok {
 		return nil, fmt.Errorf("no parser for file %s", file)
 	}
 	placeholderCount := d.countPlaceholders(file, placeholderMap)
 	placeholders := d.filePlaceholders[file]
 	replacer := d.fileReplacers[file]
+	replacedBefore := replacer.ReplaceCount
 
-	for key, value := range placeholderMap {
-		err := replacer.Replace(key, fmt.Sprint(value))
+	for _, key := range placeholderMap.sortedKeys() {
+		if err := ctx.Err(); err != nil {
+			return replacer.Bytes(), err
+		}
+		err := d.replaceValue(replacer, key, placeholderMap[key], -1)
 		if err != nil {
 			if errors.Is(err, ErrPlaceholderNotFound) {
 				continue
@@ -205,8 +434,9 @@ func (d *Document) replace(placeholderMap PlaceholderMap, file string) ([]byte,
 	}
 
 	// ensure that all placeholders have been replaced
-	if placeholderCount != replacer.ReplaceCount {
-		return nil, fmt.Errorf("not all placeholders were replaced, want=%d, have=%d", placeholderCount, replacer.ReplaceCount)
+	// the replacer is shared by all replacements of the file, only the replacements of this call are relevant
+	if replaced := replacer.ReplaceCount - replacedBefore; placeholderCount != replaced {
+		return nil, fmt.Errorf("not all placeholders were replaced, want=%d, have=%d", placeholderCount, replaced)
 	}
 
 	d.fileReplacers[file] = replacer
@@ -215,6 +445,120 @@ func (d *Document) replace(placeholderMap PlaceholderMap, file string) ([]byte,
 	return replacer.Bytes(), nil
 }
 
+// replaceValue will replace the first n occurrences of the key with the given value using the replacer.
+// If n < 0, all occurrences are replaced.
+// The value is prepared using formatValue and inserted by the ValueHandler of its type, see RegisterValueHandler.
+func (d *Document) replaceValue(replacer *Replacer, key string, value interface{}, n int) error {
+	ctx := ReplaceContext{Key: key, Part: d.partName(replacer), doc: d, replacer: replacer, n: n}
+	return ctx.ReplaceValue(d.formatValue(key, value))
+}
+
+// partName returns the name of the part which is replaced by the replacer.
+// If the replacer does not belong to a part (e.g. the replacer of a table row), an empty string is returned.
+func (d *Document) partName(replacer *Replacer) string {
+	for name, r := range d.fileReplacers {
+		if r == replacer {
+			return name
+		}
+	}
+	return ""
+}
+
+// plainValue returns the textual representation of the value prepared by formatValue.
+// It is used where values cannot be styled, e.g. inside of embedded parts.
+func (d *Document) plainValue(key string, value interface{}) string {
+	switch v := d.formatValue(key, value).(type) {
+	case FormattedText:
+		return v.Text
+	case []FormattedText:
+		var text strings.Builder
+		for _, segment := range v {
+			text.WriteString(segment.Text)
+		}
+		return text.String()
+	case Symbol:
+		return string(v.Char) + v.Text
+	case ImageData:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatValue prepares the value of the given key for insertion.
+// Pointers are dereferenced first (see dereferenceValue), then the ValueFormatter is applied, if set. Then, string values are cased like the key if
+// SetMatchKeyCasing is enabled. HTML values are converted into FormattedText segments.
+// Unless the result is a FormattedText, a []FormattedText, a Symbol or a value of another type with a ValueHandler
+// (see RegisterValueHandler), it is converted into a string, the elements of slices are joined using the slice
+// separator (see SetSliceSeparator). Nil pointers are converted into empty strings.
+// Afterwards, the text is truncated according to the maximum value length, except for []byte values.
+// Finally, the spaces of the values of non-breaking keys are converted (see SetNonBreakingKeys).
+func (d *Document) formatValue(key string, value interface{}) interface{} {
+	value = dereferenceValue(value)
+	if d.valueFormatter != nil {
+		value = d.valueFormatter(key, value)
+	}
+
+	if d.matchKeyCasing {
+		switch v := value.(type) {
+		case string:
+			value = applyKeyCasing(key, v)
+		case FormattedText:
+			v.Text = applyKeyCasing(key, v.Text)
+			value = v
+		}
+	}
+	if d.smartTypography {
+		value = applySmartTypography(value)
+	}
+	if v, ok := value.(HTML); ok {
+		value = d.convertHTML(v)
+	}
+
+	switch v := value.(type) {
+	case FormattedText:
+		value = d.truncateValue(key, d.defaultFormat(v))
+	case []FormattedText:
+		segments := make([]FormattedText, len(v))
+		for i, segment := range v {
+			segments[i] = d.defaultFormat(segment)
+		}
+		value = d.truncateValue(key, segments)
+	case Symbol:
+		return v
+	case []byte:
+		// byte values are inserted verbatim, truncating them could split multi-byte characters
+		return string(v)
+	default:
+		if _, isString := v.(string); !isString && lookupValueHandler(v) != nil {
+			// the value is inserted by the handler of its type
+			return v
+		}
+		if isNilPointer(v) {
+			// nil pointers of a PlaceholderMap are treated like missing keys, all others are empty
+			value = ""
+		} else if joined, ok := d.joinSlice(v); ok {
+			value = d.truncateValue(key, joined)
+		} else if localized, ok := d.localize(v); ok {
+			value = d.truncateValue(key, localized)
+		} else {
+			value = d.truncateValue(key, fmt.Sprint(v))
+		}
+	}
+	if d.nonBreakingKeys[key] {
+		value = applyNonBreaking(value)
+	}
+	return value
+}
+
+// SetValueFormatter sets the ValueFormatter which is applied to every value before it is inserted.
+// The formatter may return a FormattedText (or a []FormattedText) in order to style the value based on its content,
+// e.g. to render negative numbers in red.
+// Passing nil removes the formatter.
+func (d *Document) SetValueFormatter(formatter ValueFormatter) {
+	d.valueFormatter = formatter
+}
+
 // Runs returns all runs from all parsed files.
 func (d *Document) Runs() (runs []*Run) {
 	for _, parser := range d.runParsers {
@@ -231,48 +575,27 @@ func (d *Document) Placeholders() (placeholders []*Placeholder) {
 	return placeholders
 }
 
-// countPlaceholders will return the total count of placeholders from the placeholderMap in the given data.
+// countPlaceholders will return the total count of unreplaced placeholders from the placeholderMap in the given file.
 // Reoccurring placeholders are also counted multiple times.
 func (d *Document) countPlaceholders(file string, placeholderMap PlaceholderMap) int {
-	data := d.GetFile(file)
-	plaintext := d.stripXmlTags(string(data))
-	var placeholderCount int
+	keys := make(map[string]bool, len(placeholderMap))
 	for key := range placeholderMap {
 		placeholder := AddPlaceholderDelimiter(key)
-
-		count := strings.Count(plaintext, placeholder)
-		if count > 0 {
-			placeholderCount += count
+		if isEmptyPlaceholder(placeholder) {
+			continue // empty placeholders are never replaced
 		}
+		keys[placeholder] = true
 	}
-	return placeholderCount
-}
 
-// stripXmlTags is a stdlib way of stripping out all xml tags using the html.Tokenizer.
-// The returned string will be everything except the tags.
-func (d *Document) stripXmlTags(data string) string {
-	var output string
-	tokenizer := html.NewTokenizer(strings.NewReader(data))
-	prevToken := tokenizer.Token()
-loop:
-	for {
-		tok := tokenizer.Next()
-		switch {
-		case tok == html.ErrorToken:
-			break loop // End of the document,  done
-		case tok == html.StartTagToken:
-			prevToken = tokenizer.Token()
-		case tok == html.TextToken:
-			if prevToken.Data == "script" {
-				continue
-			}
-			TxtContent := strings.TrimSpace(html.UnescapeString(string(tokenizer.Text())))
-			if len(TxtContent) > 0 {
-				output += TxtContent
-			}
+	// overlapping placeholders are not part of the replacer, they are never replaced (see withoutOverlaps)
+	replacer := d.fileReplacers[file]
+	var placeholderCount int
+	for _, placeholder := range replacer.Unreplaced() {
+		if keys[placeholder.Text(replacer.Bytes())] {
+			placeholderCount++
 		}
 	}
-	return output
+	return placeholderCount
 }
 
 // GetFile returns the content of the given fileName if it exists.
@@ -296,44 +619,118 @@ func (d *Document) SetFile(fileName string, fileBytes []byte) error {
 // parseArchive will go through the docx zip archive and read them into the FileMap.
 // Files inside the FileMap are those which can be modified by the lib.
 // Currently not all files are read, only:
-//   - word/document.xml
+//   - the main document part, usually word/document.xml
 //   - word/header*.xml
 //   - word/footer*.xml
 //   - word/media/*
 func (d *Document) parseArchive() error {
-	readZipFile := func(file *zip.File) []byte {
-		readCloser, err := file.Open()
-		if err != nil {
-			return nil
+	for _, file := range d.zipFile.File {
+		isDocument := file.Name == d.mainPart
+		isHeader := HeaderPathRegex.MatchString(file.Name)
+		isFooter := FooterPathRegex.MatchString(file.Name)
+		isMedia := MediaPathRegex.MatchString(file.Name)
+		if !isDocument && !isHeader && !isFooter && !isMedia {
+			continue
 		}
-		defer readCloser.Close()
-		fileBytes, err := ioutil.ReadAll(readCloser)
+
+		fileBytes, err := d.readZipPart(file)
 		if err != nil {
-			return nil
+			return err
 		}
-		return fileBytes
-	}
-
-	for _, file := range d.zipFile.File {
-		if file.Name == DocumentXml {
-			d.files[DocumentXml] = readZipFile(file)
+		if !isMedia {
+			fileBytes = mergeHyphenElements(fileBytes)
 		}
-		if HeaderPathRegex.MatchString(file.Name) {
-			d.files[file.Name] = readZipFile(file)
+		d.files[file.Name] = fileBytes
+
+		switch {
+		case isHeader:
 			d.headerFiles = append(d.headerFiles, file.Name)
-		}
-		if FooterPathRegex.MatchString(file.Name) {
-			d.files[file.Name] = readZipFile(file)
+		case isFooter:
 			d.footerFiles = append(d.footerFiles, file.Name)
-		}
-		if MediaPathRegex.MatchString(file.Name) {
-			d.files[file.Name] = readZipFile(file)
+		case isMedia:
 			d.mediaFiles = append(d.mediaFiles, file.Name)
 		}
 	}
 	return nil
 }
 
+// readFile returns the contents of the given file inside the docx-archive.
+// If the file is one of the files which can be modified by the lib, the modified contents are returned.
+// An error is returned if the file does not exist.
+func (d *Document) readFile(fileName string) ([]byte, error) {
+	if d.removedParts[fileName] {
+		return nil, fmt.Errorf("file not found %s", fileName)
+	}
+	if f, exists := d.files[fileName]; exists {
+		return f, nil
+	}
+	if f, exists := d.modifiedParts[fileName]; exists {
+		return f, nil
+	}
+	for _, file := range d.zipFile.File {
+		if file.Name == fileName {
+			return d.readZipPart(file)
+		}
+	}
+	return nil, fmt.Errorf("file not found %s", fileName)
+}
+
+// readZipPart reads the given file of the archive like readZipFile and records the XML declaration of XML parts,
+// so the declaration can be kept on write without reading the part again.
+func (d *Document) readZipPart(file *zip.File) ([]byte, error) {
+	fileBytes, err := readZipFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if isXmlPart(file.Name) {
+		d.declarations[file.Name] = xmlDeclarationRegex.Find(fileBytes)
+	}
+	return fileBytes, nil
+}
+
+// writePart sets the contents of a file inside the docx-archive which is not subject to replacing (e.g. word/settings.xml).
+// If the file does not exist yet, it is added to the archive once the document is written.
+func (d *Document) writePart(fileName string, fileBytes []byte) {
+	d.modifiedParts[fileName] = fileBytes
+	delete(d.removedParts, fileName)
+}
+
+// removePart removes the given file from the docx-archive, it is not written anymore.
+// References to the file (e.g. relationships) are not touched.
+func (d *Document) removePart(fileName string) {
+	delete(d.files, fileName)
+	delete(d.modifiedParts, fileName)
+	delete(d.runParsers, fileName)
+	delete(d.filePlaceholders, fileName)
+	delete(d.fileReplacers, fileName)
+	for i, media := range d.mediaFiles {
+		if media == fileName {
+			d.mediaFiles = append(d.mediaFiles[:i], d.mediaFiles[i+1:]...)
+			break
+		}
+	}
+	if d.archiveContains(fileName) {
+		d.removedParts[fileName] = true
+	}
+}
+
+// readZipFile reads the given file from the zip archive.
+// The decompression is left to the archive/zip package, which supports the stored (uncompressed)
+// and the deflate method, the methods allowed by the Open Packaging Conventions.
+// An error is returned if the file uses any other method or if it cannot be read.
+func readZipFile(file *zip.File) ([]byte, error) {
+	readCloser, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %s", file.Name, err)
+	}
+	defer readCloser.Close()
+	fileBytes, err := ioutil.ReadAll(readCloser)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", file.Name, err)
+	}
+	return fileBytes, nil
+}
+
 // WriteToFile will write the document to a new file.
 // It is important to note that the target file cannot be the same as the path of this document.
 // If the path is not yet created, the function will attempt to MkdirAll() before creating the file.
@@ -360,25 +757,51 @@ func (d *Document) WriteToFile(file string) error {
 // Docx files are basically zip archives with many XMLs included.
 // Files which cannot be modified through this lib will just be read from the original docx and copied into the writer.
 func (d *Document) Write(writer io.Writer) error {
+	if d.stampModification {
+		if err := d.stampCoreProperties(time.Now()); err != nil {
+			return fmt.Errorf("unable to stamp modification: %s", err)
+		}
+	}
+
 	zipWriter := zip.NewWriter(writer)
 	defer zipWriter.Close()
 
 	// writeModifiedFile will check if the given zipFile is a file which was modified and writes it.
+	// The XML declaration of the original file is kept, even if the contents were replaced entirely.
 	// If the file is not one of the modified files, false is returned.
 	writeModifiedFile := func(writer io.Writer, zipFile *zip.File) (bool, error) {
-		isModified := d.isModifiedFile(zipFile.Name)
-		if !isModified {
-			return false, nil
+		files := d.files
+		if !d.isModifiedFile(zipFile.Name) {
+			if _, exists := d.modifiedParts[zipFile.Name]; !exists {
+				return false, nil
+			}
+			files = d.modifiedParts
+		}
+		fileBytes := files[zipFile.Name]
+		if isXmlPart(zipFile.Name) {
+			declaration, read := d.declarations[zipFile.Name]
+			if !read {
+				// the part was written without being read before
+				original, err := readZipFile(zipFile)
+				if err != nil {
+					return false, err
+				}
+				declaration = xmlDeclarationRegex.Find(original)
+			}
+			fileBytes = preserveDeclaration(declaration, fileBytes)
 		}
-		if err := d.files.Write(writer, zipFile.Name); err != nil {
+		if _, err := writer.Write(fileBytes); err != nil {
 			return false, fmt.Errorf("unable to writeFile %s: %s", zipFile.Name, err)
 		}
 		return true, nil
 	}
 
-	// write all files into the zip archive (docx-file)
+	// write all files into the zip archive (docx-file), keeping the order and the headers of the original archive
 	for _, zipFile := range d.zipFile.File {
-		fw, err := zipWriter.Create(zipFile.Name)
+		if d.removedParts[zipFile.Name] {
+			continue
+		}
+		fw, err := zipWriter.CreateHeader(copyFileHeader(zipFile.FileHeader))
 		if err != nil {
 			return fmt.Errorf("unable to create writer: %s", err)
 		}
@@ -406,14 +829,43 @@ func (d *Document) Write(writer io.Writer) error {
 			return fmt.Errorf("unable to close reader for %s: %s", zipFile.Name, err)
 		}
 	}
+
+	// parts which have been added through the Document API are appended in a stable order
+	var addedParts []string
+	for name := range d.modifiedParts {
+		if !d.archiveContains(name) {
+			addedParts = append(addedParts, name)
+		}
+	}
+	sort.Strings(addedParts)
+	for _, name := range addedParts {
+		fw, err := zipWriter.Create(name)
+		if err != nil {
+			return fmt.Errorf("unable to create writer: %s", err)
+		}
+		if err := d.modifiedParts.Write(fw, name); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// archiveContains returns true if the original docx-archive contains the given file.
+func (d *Document) archiveContains(fileName string) bool {
+	for _, file := range d.zipFile.File {
+		if file.Name == fileName {
+			return true
+		}
+	}
+	return false
+}
+
 // isModifiedFile will look through all modified files and check if the searchFileName exists
 func (d *Document) isModifiedFile(searchFileName string) bool {
 	allFiles := append(d.headerFiles, d.footerFiles...)
 	allFiles = append(allFiles, d.mediaFiles...)
-	allFiles = append(allFiles, DocumentXml)
+	allFiles = append(allFiles, d.additionalFiles...)
+	allFiles = append(allFiles, d.mainPart)
 
 	for _, file := range allFiles {
 		if searchFileName == file {
@@ -423,14 +875,19 @@ func (d *Document) isModifiedFile(searchFileName string) bool {
 	return false
 }
 
-// Close will close everything :)
-func (d *Document) Close() {
-	if d.docxFile != nil {
-		err := d.docxFile.Close()
-		if err != nil {
-			log.Println(err)
-		}
+// Close releases the file handle of a document opened with Open. Callers must close every document they opened,
+// usually right after it has been written. Documents created with OpenBytes or OpenZip do not hold a file handle,
+// closing them is a no-op. Closing a document multiple times is safe.
+func (d *Document) Close() error {
+	if d.docxFile == nil {
+		return nil
 	}
+	err := d.docxFile.Close()
+	d.docxFile = nil
+	if err != nil {
+		return fmt.Errorf("unable to close %s: %s", d.path, err)
+	}
+	return nil
 }
 
 // FileMap is just a convenience type for the map of fileName => fileBytes
@@ -1,6 +1,14 @@
 package docx
 
-import "testing"
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
 
 func BenchmarkDocument_ReplaceAll(b *testing.B) {
 	for n := 0; n < b.N; n++ {
@@ -33,3 +41,215 @@ func BenchmarkDocument_ReplaceAll(b *testing.B) {
 		}
 	}
 }
+
+func TestOpenZip(t *testing.T) {
+	zipFile, err := zip.OpenReader("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer zipFile.Close()
+
+	doc, err := OpenZip(&zipFile.Reader)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = doc.ReplaceAll(PlaceholderMap{"key": "value"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	if _, err = OpenBytes(buf.Bytes()); err != nil {
+		t.Error("unable to open written document", err)
+	}
+}
+
+func TestOpen_StoredArchive(t *testing.T) {
+	doc, err := Open("./test/stored.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"key": "value"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), "value") {
+		t.Error("placeholder of the stored archive was not replaced")
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	if _, err = OpenBytes(buf.Bytes()); err != nil {
+		t.Error("unable to open written document", err)
+	}
+}
+
+func TestOpenBytes_UnsupportedCompression(t *testing.T) {
+	const unsupportedMethod = 99
+
+	var buf bytes.Buffer
+	zipWriter := zip.NewWriter(&buf)
+	zipWriter.RegisterCompressor(unsupportedMethod, func(w io.Writer) (io.WriteCloser, error) {
+		return nopWriteCloser{w}, nil
+	})
+	fw, err := zipWriter.CreateHeader(&zip.FileHeader{Name: DocumentXml, Method: unsupportedMethod})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte("<w:document/>"))
+	if err = zipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = OpenBytes(buf.Bytes())
+	if err == nil {
+		t.Error("expected an error for an unsupported compression method")
+		return
+	}
+	if !strings.Contains(err.Error(), zip.ErrAlgorithm.Error()) {
+		t.Errorf("unexpected error, want=%s, have=%s", zip.ErrAlgorithm, err)
+	}
+}
+
+// nopWriteCloser wraps a writer which does not need to be closed.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func TestDocument_ReplaceFirst(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	// the template contains placeholders spanning paragraphs
+	if err = doc.SetAllowCrossParagraphPlaceholders(true); err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = doc.ReplaceFirst("key-with-dash", "FIRST")
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	text := doc.Text()
+	if count := strings.Count(text, "FIRST"); count != 1 {
+		t.Errorf("unexpected replacement count, want=%d, have=%d", 1, count)
+	}
+	remaining := strings.Index(text, "{key-with-dash}")
+	if remaining < 0 {
+		t.Error("following occurrence was replaced as well")
+		return
+	}
+	if strings.Index(text, "FIRST") > remaining {
+		t.Error("occurrence other than the first one was replaced")
+	}
+
+	// the remaining occurrence is replaced by the next call
+	if err = doc.ReplaceFirst("key-with-dash", "SECOND"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if err = doc.ReplaceFirst("key-with-dash", "THIRD"); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, got %v", err)
+	}
+}
+
+func TestDocument_ReplaceFirst_ThenReplaceAll(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceFirst("key", "FIRST"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	// the replacements of ReplaceFirst must not count towards the placeholders of ReplaceAll
+	if err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": "VALUE"}); err != nil {
+		t.Error("replacing failed", err)
+	}
+}
+
+func TestDocument_ReplaceAllBytes(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	// a latin-1 encoded '\u00e4' is not valid UTF-8 and would corrupt the document
+	err = doc.ReplaceAllBytes(map[string][]byte{"key.with.dots": []byte("a<b & \xe4")})
+	if !errors.Is(err, ErrInvalidUTF8) {
+		t.Errorf("expected ErrInvalidUTF8, have=%v", err)
+	}
+
+	err = doc.ReplaceAllBytes(map[string][]byte{"key.with.dots": []byte("a<b & \u00e4")})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !bytes.Contains(doc.GetFile(DocumentXml), []byte("a&lt;b &amp; \u00e4")) {
+		t.Error("bytes were not inserted verbatim")
+	}
+}
+
+func TestDocument_Close(t *testing.T) {
+	openFiles := func() int {
+		entries, err := ioutil.ReadDir("/proc/self/fd")
+		if err != nil {
+			return -1
+		}
+		return len(entries)
+	}
+	before := openFiles()
+
+	for i := 0; i < 50; i++ {
+		doc, err := Open("./test/template.docx")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err = doc.Close(); err != nil {
+			t.Errorf("closing failed: %s", err)
+		}
+		if err = doc.Close(); err != nil {
+			t.Errorf("closing twice must be safe: %s", err)
+		}
+	}
+	if before >= 0 && openFiles() > before {
+		t.Errorf("file handles are leaking, before=%d, after=%d", before, openFiles())
+	}
+
+	doc, err := OpenBytes(readFile(t, "./test/template.docx"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if err = doc.Close(); err != nil {
+		t.Errorf("closing a document without a file failed: %s", err)
+	}
+}
@@ -0,0 +1,42 @@
+package docx
+
+import "sort"
+
+// EachPlaceholder calls fn for every placeholder of the parsed parts, the same placeholders which are returned by
+// Placeholders, but without collecting them into a slice first. The parts are visited in reading order (see Text),
+// followed by the additional parts loaded by ReplaceEverywhere. The placeholders of a part are visited in the order
+// of their position. The text of a placeholder is read from the bytes of its part, e.g. p.Text(doc.GetFile(DocumentXml)).
+//
+// If fn returns an error, the iteration stops and the error is returned.
+func (d *Document) EachPlaceholder(fn func(p *Placeholder) error) error {
+	for _, name := range d.placeholderParts() {
+		placeholders := append([]*Placeholder{}, d.filePlaceholders[name]...)
+		sort.SliceStable(placeholders, func(i, j int) bool {
+			return placeholders[i].StartPos() < placeholders[j].StartPos()
+		})
+		for _, placeholder := range placeholders {
+			if err := fn(placeholder); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// placeholderParts returns the names of all parsed parts in reading order, followed by all other parsed parts
+// sorted by their name.
+func (d *Document) placeholderParts() []string {
+	parts := d.readingOrder()
+	visited := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		visited[part] = true
+	}
+	var others []string
+	for name := range d.filePlaceholders {
+		if !visited[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append(parts, others...)
+}
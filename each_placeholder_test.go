@@ -0,0 +1,56 @@
+package docx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDocument_EachPlaceholder(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	mainPlaceholders := make(map[*Placeholder]bool)
+	for _, p := range doc.filePlaceholders[DocumentXml] {
+		mainPlaceholders[p] = true
+	}
+
+	visited := 0
+	lastPos := int64(-1)
+	err = doc.EachPlaceholder(func(p *Placeholder) error {
+		visited++
+		// the placeholders of a part are visited in the order of their position
+		if mainPlaceholders[p] {
+			if p.StartPos() < lastPos {
+				t.Errorf("placeholder at %d visited after %d", p.StartPos(), lastPos)
+			}
+			lastPos = p.StartPos()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error("iterating failed", err)
+	}
+	if expected := len(doc.Placeholders()); visited != expected {
+		t.Errorf("unexpected number of placeholders, want=%d, have=%d", expected, visited)
+	}
+
+	stop := errors.New("stop")
+	visited = 0
+	err = doc.EachPlaceholder(func(p *Placeholder) error {
+		visited++
+		if visited == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("expected the error of the callback, got %v", err)
+	}
+	if visited != 2 {
+		t.Errorf("iteration did not stop, visited %d placeholders", visited)
+	}
+}
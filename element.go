@@ -0,0 +1,108 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// textRegex matches the text elements (<w:t>) including their content
+	textRegex = regexp.MustCompile(`<w:t(?:\s[^>]*)?>([^<]*)</w:t>`)
+	// paragraphPropertiesRegex matches the paragraph properties element, including all properties
+	paragraphPropertiesRegex = regexp.MustCompile(`(?s)<w:pPr>.*?</w:pPr>|<w:pPr/>`)
+)
+
+// findElements returns the positions of all elements with the given local name inside data, in document order.
+// A position starts at the '<' of the open tag and ends after the '>' of the close tag.
+// Singleton elements (e.g. <w:p/>) are returned as well. Nested elements are returned separately.
+func findElements(data []byte, localName string) ([]Position, error) {
+	// use a custom reader which saves the current byte position
+	docReader := NewReader(string(data))
+	decoder := xml.NewDecoder(docReader)
+
+	var (
+		elements []Position
+		open     []int64 // start positions of the currently open elements
+	)
+	for {
+		tok, err := decoder.Token()
+		if tok == nil || err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error getting token: %s", err)
+		}
+
+		switch elem := tok.(type) {
+		case xml.StartElement:
+			if elem.Name.Local == localName {
+				open = append(open, findOpenBracketPos(data, docReader.Pos()-1))
+			}
+		case xml.EndElement:
+			if elem.Name.Local == localName && len(open) > 0 {
+				elements = append(elements, Position{Start: open[len(open)-1], End: docReader.Pos()})
+				open = open[:len(open)-1]
+			}
+		}
+	}
+
+	// inner elements are closed first, restore the document order
+	sort.Slice(elements, func(i, j int) bool {
+		return elements[i].Start < elements[j].Start
+	})
+	return elements, nil
+}
+
+// findChildElements returns the positions of all elements with the given local name which are inside of parent,
+// but not inside of another element with the local name of the parent (e.g. rows of nested tables).
+// The returned positions are absolute.
+func findChildElements(data []byte, parent Position, localName string, parentLocalName string) ([]Position, error) {
+	content := data[parent.Start:parent.End]
+	elements, err := findElements(content, localName)
+	if err != nil {
+		return nil, err
+	}
+	nested, err := findElements(content, parentLocalName)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []Position
+	for _, element := range elements {
+		isNested := false
+		for _, n := range nested[1:] { // the first one is the parent itself
+			if n.Start < element.Start && element.End <= n.End {
+				isNested = true
+				break
+			}
+		}
+		if !isNested {
+			children = append(children, Position{Start: parent.Start + element.Start, End: parent.Start + element.End})
+		}
+	}
+	return children, nil
+}
+
+// findOpenBracketPos searches the matching '<' for a close bracket ('>') given it's position.
+func findOpenBracketPos(data []byte, endBracketPos int64) int64 {
+	for i := endBracketPos; i >= 0; i-- {
+		if data[i] == '<' {
+			return i
+		}
+	}
+	return 0
+}
+
+// elementText returns the unescaped content of all text elements (<w:t>) inside the given data.
+func elementText(data []byte) string {
+	var text strings.Builder
+	for _, match := range textRegex.FindAllSubmatch(data, -1) {
+		text.WriteString(html.UnescapeString(string(match[1])))
+	}
+	return text.String()
+}
@@ -0,0 +1,92 @@
+package docx
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// paragraphBreakRegex matches a blank line, which separates the paragraphs of a text value
+	paragraphBreakRegex = regexp.MustCompile(`\r?\n[ \t]*\r?\n`)
+	// sectionPropertiesRegex matches the section properties (<w:sectPr>) of a paragraph
+	sectionPropertiesRegex = regexp.MustCompile(`(?s)<w:sectPr(?:\s[^>]*)?>.*?</w:sectPr>|<w:sectPr(?:\s[^>]*)?/>`)
+	// tagRegex matches any open, close or singleton tag and captures the slash of close tags and the qualified name
+	tagRegex = regexp.MustCompile(`<(/?)([\w:]+)(?:\s[^>]*)?>`)
+)
+
+// SetExpandParagraphs enables or disables the expansion of text values into multiple paragraphs.
+// If enabled, the blank lines of a text value (e.g. 'first\n\nsecond') split the paragraph of the placeholder:
+// every chunk of the value ends up in a paragraph of its own, which carries over the paragraph properties and
+// the run properties of the placeholder. New paragraphs without style get the insert paragraph style, if set
+// (see SetInsertParagraphStyle). The content preceding and following the placeholder stays in
+// the first and last paragraph. Single newlines within a chunk are converted into breaks as usual.
+//
+// Values which are not inserted as plain text (e.g. FormattedText) are not expanded. It is disabled by default.
+func (d *Document) SetExpandParagraphs(enabled bool) {
+	d.expandParagraphs = enabled
+}
+
+// splitParagraph returns the value which splits the paragraph of the given run at every blank line of the text.
+// Elements which are open between the paragraph and the run (e.g. a hyperlink) are closed before and
+// reopened after every split, so the structure of the document stays intact.
+// The open tag and the properties of every new paragraph are passed to styleParagraph, e.g. to apply the insert
+// paragraph style. If the run is not inside a paragraph, the blank lines are converted into breaks.
+func (r *Replacer) splitParagraph(run *Run, text string, styleParagraph func(string) string) string {
+	paragraphs, err := findElements(r.document, ParagraphElementName)
+	if err != nil {
+		return r.textValue(run, text)
+	}
+	paragraph := innermostElement(paragraphs, run.OpenTag.Start)
+	if paragraph == nil {
+		return r.textValue(run, text)
+	}
+
+	contentStart := int64(strings.IndexByte(string(r.document[paragraph.Start:paragraph.End]), '>')) + paragraph.Start + 1
+	content := string(r.document[contentStart:run.OpenTag.Start])
+
+	// the paragraph properties are always the first child of the paragraph
+	properties := ""
+	if loc := paragraphPropertiesRegex.FindStringIndex(content); loc != nil && strings.TrimSpace(content[:loc[0]]) == "" {
+		properties = sectionPropertiesRegex.ReplaceAllString(content[loc[0]:loc[1]], "")
+	}
+
+	openTags := openElements(content)
+	var closeTags, reopenTags strings.Builder
+	for i := len(openTags) - 1; i >= 0; i-- {
+		closeTags.WriteString("</" + tagRegex.FindStringSubmatch(openTags[i])[2] + ">")
+	}
+	for _, tag := range openTags {
+		reopenTags.WriteString(tag)
+	}
+
+	r.preserveSpace(run)
+	textOpenTag := string(r.document[run.Text.OpenTag.Start:run.Text.OpenTag.End])
+	separator := "</w:t></w:r>" + closeTags.String() + "</w:p>" + styleParagraph("<w:p>"+properties) + reopenTags.String() +
+		"<w:r>" + runProperties(r.document, run) + textOpenTag
+
+	chunks := paragraphBreakRegex.Split(text, -1)
+	values := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		values[i] = escapeTextValue(chunk, textOpenTag)
+	}
+	return strings.Join(values, separator)
+}
+
+// openElements returns the open tags of all elements which are opened, but not closed inside the given content.
+// The outermost element is the first one.
+func openElements(content string) []string {
+	var open []string
+	for _, tag := range tagRegex.FindAllStringSubmatch(content, -1) {
+		switch {
+		case strings.HasSuffix(tag[0], "/>"):
+			continue
+		case tag[1] == "/":
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		default:
+			open = append(open, tag[0])
+		}
+	}
+	return open
+}
@@ -0,0 +1,41 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetExpandParagraphs(t *testing.T) {
+	doc, err := Open("./test/expand_paragraphs.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetExpandParagraphs(true)
+	err = doc.ReplaceAll(PlaceholderMap{
+		"note":    "first\n\nsecond line\nwith break",
+		"address": "Main Street 1\r\n\r\n12345 Springfield",
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	expected := `<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:t xml:space="preserve">Note: </w:t></w:r>` +
+		`<w:hyperlink w:anchor="notes"><w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">first</w:t></w:r></w:hyperlink></w:p>` +
+		`<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:hyperlink w:anchor="notes"><w:r><w:rPr><w:b/></w:rPr>` +
+		`<w:t xml:space="preserve">second line</w:t><w:br/><w:t xml:space="preserve">with break</w:t></w:r></w:hyperlink>` +
+		`<w:r><w:t xml:space="preserve"> (end)</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t xml:space="preserve">Main Street 1</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t xml:space="preserve">12345 Springfield</w:t></w:r></w:p>`
+	if !strings.Contains(documentXml, expected) {
+		t.Errorf("paragraphs were not expanded:\n%s", documentXml)
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
@@ -0,0 +1,147 @@
+package docx
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// fieldCharRegex matches the field characters (<w:fldChar>) which delimit complex fields and captures their type
+	fieldCharRegex = regexp.MustCompile(`<w:fldChar\s[^>]*w:fldCharType="(begin|separate|end)"`)
+	// fieldContentRegex matches the field characters, the instruction texts and the text elements of a run
+	fieldContentRegex = regexp.MustCompile(`(?s)<w:fldChar(?:\s[^>]*)?(?:/>|>.*?</w:fldChar>)|` +
+		`<w:instrText(?:\s[^>]*)?(?:/>|>[^<]*</w:instrText>)|<w:t(?:\s[^>]*)?(?:/>|>[^<]*</w:t>)`)
+	// adjacentTextRegex matches the close tag of a text element directly followed by the open tag of another one
+	adjacentTextRegex = regexp.MustCompile(`</w:t><w:t(?:\s[^>]*)?>`)
+	// runPropertiesElementRegex matches the run properties (<w:rPr>) of a run
+	runPropertiesElementRegex = regexp.MustCompile(`(?s)<w:rPr(?:\s[^>]*)?(?:/>|>.*?</w:rPr>)`)
+	// simpleFieldSingletonRegex matches simple fields (<w:fldSimple/>) without a result
+	simpleFieldSingletonRegex = regexp.MustCompile(`<w:fldSimple(?:\s[^>]*)?/>`)
+	// simpleFieldTagRegex matches the open and close tags of simple fields (<w:fldSimple>)
+	simpleFieldTagRegex = regexp.MustCompile(`<w:fldSimple(?:\s[^>]*)?>|</w:fldSimple>`)
+)
+
+// FreezeFields converts all fields of the main document, the headers, the footers and the loaded additional parts
+// into static text, so Word cannot overwrite replaced values by updating the fields (e.g. when the document is printed).
+// Only the current result of every field is kept:
+//   - simple fields (<w:fldSimple>) are replaced by their content
+//   - of complex fields, the field characters (<w:fldChar>) and the field instructions are removed, as well as
+//     the runs which are left without content
+//
+// Fields without a result (e.g. a complex field without a separator) are removed entirely.
+// Note that form fields are fields as well and lose their form data.
+func (d *Document) FreezeFields() error {
+	for _, name := range append(d.readingOrder(), d.additionalFiles...) {
+		frozen, err := freezeFields(d.files[name])
+		if err != nil {
+			return err
+		}
+		d.files[name] = frozen
+		if err := d.parseFile(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// freezeFields returns the data with all fields converted into static text, see FreezeFields.
+func freezeFields(data []byte) ([]byte, error) {
+	parser := NewRunParser(data)
+	if err := parser.Execute(); err != nil {
+		return nil, err
+	}
+	runs := append(DocumentRuns{}, parser.Runs()...)
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].OpenTag.Start < runs[j].OpenTag.Start
+	})
+
+	// instruction is the stack of the currently open complex fields, the innermost field is the last one.
+	// The value is true as long as the instructions of the field are read, and false once its result is read.
+	var instruction []bool
+	inInstruction := func() bool {
+		// the results of fields nested into the instructions of another field are part of the instructions
+		for _, in := range instruction {
+			if in {
+				return true
+			}
+		}
+		return false
+	}
+
+	type replacement struct {
+		Position
+		content string
+	}
+	var replacements []replacement
+	for _, run := range runs {
+		content := string(data[run.OpenTag.End:run.CloseTag.Start])
+
+		var frozen strings.Builder
+		last, modified := 0, false
+		for _, loc := range fieldContentRegex.FindAllStringIndex(content, -1) {
+			element := content[loc[0]:loc[1]]
+			remove := inInstruction()
+			if fieldChar := fieldCharRegex.FindStringSubmatch(element); fieldChar != nil {
+				remove = true
+				switch fieldChar[1] {
+				case "begin":
+					instruction = append(instruction, true)
+				case "separate":
+					if len(instruction) > 0 {
+						instruction[len(instruction)-1] = false
+					}
+				case "end":
+					if len(instruction) > 0 {
+						instruction = instruction[:len(instruction)-1]
+					}
+				}
+			} else if strings.HasPrefix(element, "<w:instrText") {
+				remove = true
+			}
+
+			if remove {
+				frozen.WriteString(content[last:loc[0]])
+				last, modified = loc[1], true
+			}
+		}
+		if !modified {
+			continue
+		}
+		frozen.WriteString(content[last:])
+
+		// runs which are left without content are removed entirely
+		position := Position{Start: run.OpenTag.End, End: run.CloseTag.Start}
+		runContent := mergeAdjacentTexts(frozen.String())
+		if strings.TrimSpace(runPropertiesElementRegex.ReplaceAllString(runContent, "")) == "" {
+			position = Position{Start: run.OpenTag.Start, End: run.CloseTag.End}
+			runContent = ""
+		}
+		replacements = append(replacements, replacement{Position: position, content: runContent})
+	}
+
+	// replace from the end, so the positions of the preceding runs stay valid
+	for i := len(replacements) - 1; i >= 0; i-- {
+		data = splice(data, int(replacements[i].Start), int(replacements[i].End), replacements[i].content)
+	}
+
+	data = simpleFieldSingletonRegex.ReplaceAll(data, nil)
+	return simpleFieldTagRegex.ReplaceAll(data, nil), nil
+}
+
+// mergeAdjacentTexts merges the text elements of the run content which directly follow each other, since the parser
+// only supports a single text element per run. The merged element preserves whitespace if either of them did.
+func mergeAdjacentTexts(content string) string {
+	for {
+		loc := adjacentTextRegex.FindStringIndex(content)
+		if loc == nil {
+			return content
+		}
+		preserve := strings.Contains(content[loc[0]:loc[1]], `xml:space="preserve"`)
+		head := content[:loc[0]]
+		if preserve {
+			head = string(preserveLastTextElement([]byte(head)))
+		}
+		content = head + content[loc[1]:]
+	}
+}
@@ -0,0 +1,100 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestDocument_FreezeFields(t *testing.T) {
+	doc, err := Open("./test/fields.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.Replace("total", "42.00"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if err = doc.FreezeFields(); err != nil {
+		t.Error("freezing fields failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, unexpected := range []string{"fldSimple", "fldChar", "instrText"} {
+		if strings.Contains(documentXml, unexpected) {
+			t.Errorf("unexpected %s inside the document", unexpected)
+		}
+	}
+	expected := []string{
+		`<w:t xml:space="preserve">Page </w:t></w:r><w:r><w:t>1</w:t></w:r></w:p>`,
+		`<w:t xml:space="preserve">Total: </w:t></w:r><w:r><w:t>42.00</w:t></w:r></w:p>`,
+		`<w:p><w:r><w:t>first</w:t></w:r></w:p>`,
+		`<w:p><w:r><w:t>done</w:t></w:r></w:p>`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(documentXml, e) {
+			t.Errorf("expected %s inside the document", e)
+		}
+	}
+}
+
+func TestDocument_ReplaceAll_SimpleField(t *testing.T) {
+	doc, err := Open("./test/fld_simple.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{
+		"name":    FormattedText{Text: "Ada", Italic: true},
+		"city":    "London",
+		"address": "Main St. 1\nLondon",
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	expected := []string{
+		`<w:fldSimple w:instr=" MERGEFIELD name \* Upper "><w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve"></w:t></w:r>` +
+			`<w:r><w:rPr><w:b/><w:i/></w:rPr><w:t xml:space="preserve">Ada</w:t></w:r>` +
+			`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve"></w:t></w:r></w:fldSimple>`,
+		`<w:fldSimple w:instr=" MERGEFIELD city "><w:r><w:t>London</w:t></w:r><w:r><w:t></w:t></w:r></w:fldSimple>`,
+		// the instruction is not text of the document, the placeholder inside of it is kept
+		`<w:fldSimple w:instr=" QUOTE &quot;{address}&quot; "><w:r><w:t>Main St. 1</w:t><w:br/><w:t>London</w:t></w:r></w:fldSimple>`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(documentXml, e) {
+			t.Errorf("expected %s inside the document", e)
+		}
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestFreezeFields_MixedRuns(t *testing.T) {
+	data := []byte(`<w:document><w:body><w:p>` +
+		`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">Page </w:t><w:fldChar w:fldCharType="begin"/></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> PAGE </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"/><w:t>1</w:t><w:fldChar w:fldCharType="end"/><w:t xml:space="preserve"> of 2</w:t></w:r>` +
+		`</w:p></w:body></w:document>`)
+
+	frozen, err := freezeFields(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `<w:document><w:body><w:p>` +
+		`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">Page </w:t></w:r>` +
+		`<w:r><w:t xml:space="preserve">1 of 2</w:t></w:r>` +
+		`</w:p></w:body></w:document>`
+	if string(frozen) != expected {
+		t.Errorf("unexpected result, want=%s, have=%s", expected, frozen)
+	}
+}
@@ -0,0 +1,95 @@
+package docx
+
+import (
+	"fmt"
+	"html"
+)
+
+// runPropertiesElementOrder is the order of the child elements of <w:rPr> as defined by the WordprocessingML schema.
+var runPropertiesElementOrder = []string{
+	"rStyle", "rFonts", "b", "bCs", "i", "iCs", "caps", "smallCaps", "strike", "dstrike", "outline", "shadow",
+	"emboss", "imprint", "noProof", "snapToGrid", "vanish", "webHidden", "color", "spacing", "w", "kern", "position",
+	"sz", "szCs", "highlight", "u", "effect", "bdr", "shd", "fitText", "vertAlign", "rtl", "cs", "em", "lang",
+	"eastAsianLayout", "specVanish", "oMath",
+}
+
+// SetDefaultFont sets the font name and size (in points) of inserted values.
+// They are only applied if the run of the placeholder does not specify a font (<w:rFonts>) or size (<w:sz>) itself,
+// in that case the value is inserted as a run of its own, carrying over the properties of the placeholder run.
+// The same applies to FormattedText values without a font or size.
+// An empty name or a size of 0 disables the respective default, which is the default.
+func (d *Document) SetDefaultFont(name string, size int) {
+	d.defaultFont = name
+	d.defaultFontSize = size
+}
+
+// applyDefaultFont returns the run properties with the default font and size added if they are not specified yet.
+// The run properties may be empty. If nothing needs to be added, the run properties are returned unchanged.
+func (d *Document) applyDefaultFont(runProperties string) string {
+	props := runProperties
+	if props == "" || props == "<w:rPr/>" {
+		props = "<w:rPr></w:rPr>"
+	}
+
+	changed := false
+	add := func(name, element string) {
+		if elementTagIndex([]byte(props), name) >= 0 {
+			return
+		}
+		result, err := setElement([]byte(props), "rPr", name, element, runPropertiesElementOrder)
+		if err != nil {
+			return
+		}
+		props = string(result)
+		changed = true
+	}
+	if d.defaultFont != "" {
+		add("rFonts", fontElement(d.defaultFont))
+	}
+	if d.defaultFontSize > 0 {
+		add("sz", sizeElement("sz", d.defaultFontSize))
+		add("szCs", sizeElement("szCs", d.defaultFontSize))
+	}
+
+	if !changed {
+		return runProperties
+	}
+	return props
+}
+
+// defaultFormat returns the FormattedText with the default highlight and language set, unless it specifies them itself.
+// The default font and size are applied once the properties are merged into those of the placeholder run,
+// since the placeholder run may specify them (see applyDefaultFont).
+func (d *Document) defaultFormat(text FormattedText) FormattedText {
+	if text.Highlight == "" {
+		text.Highlight = d.highlightColor
+	}
+	if text.Lang == "" {
+		text.Lang = d.language
+	}
+	return text
+}
+
+// fontElement returns the font element (<w:rFonts>) which uses the given font for all scripts.
+func fontElement(name string) string {
+	name = html.EscapeString(name)
+	return fmt.Sprintf(`<w:rFonts w:ascii="%[1]s" w:hAnsi="%[1]s" w:eastAsia="%[1]s" w:cs="%[1]s"/>`, name)
+}
+
+// sizeElement returns the size element with the given local name (e.g. <w:sz>).
+// The size is given in points, WordprocessingML uses half-points.
+func sizeElement(name string, size int) string {
+	return fmt.Sprintf(`<w:%s w:val="%d"/>`, name, size*2)
+}
+
+// defaultFontRun returns the run which contains the given text and the properties of the given run
+// with the default font, the highlight and the language applied. If the properties do not change, an empty string is returned.
+func (d *Document) defaultFontRun(docBytes []byte, run *Run, text string) string {
+	props := runProperties(docBytes, run)
+	merged := d.applyLanguage(d.applyHighlight(d.applyDefaultFont(props)))
+	if merged == props {
+		return ""
+	}
+	textOpenTag := `<w:t xml:space="preserve">`
+	return "<w:r>" + merged + textOpenTag + escapeTextValue(text, textOpenTag) + "</w:t></w:r>"
+}
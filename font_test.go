@@ -0,0 +1,75 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestDocument_applyDefaultFont(t *testing.T) {
+	doc := &Document{}
+	doc.SetDefaultFont("Arial", 12)
+
+	tests := []struct {
+		runProperties string
+		expected      string
+	}{
+		{
+			"",
+			`<w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:eastAsia="Arial" w:cs="Arial"/><w:sz w:val="24"/><w:szCs w:val="24"/></w:rPr>`,
+		},
+		{
+			`<w:rPr><w:b/><w:lang w:val="de-DE"/></w:rPr>`,
+			`<w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:eastAsia="Arial" w:cs="Arial"/><w:b/><w:sz w:val="24"/><w:szCs w:val="24"/><w:lang w:val="de-DE"/></w:rPr>`,
+		},
+		{
+			`<w:rPr><w:rFonts w:ascii="Calibri"/><w:sz w:val="20"/><w:szCs w:val="20"/></w:rPr>`,
+			`<w:rPr><w:rFonts w:ascii="Calibri"/><w:sz w:val="20"/><w:szCs w:val="20"/></w:rPr>`,
+		},
+	}
+	for _, tt := range tests {
+		if result := doc.applyDefaultFont(tt.runProperties); result != tt.expected {
+			t.Errorf("applyDefaultFont(%s), want=%s, have=%s", tt.runProperties, tt.expected, result)
+		}
+	}
+}
+
+func BenchmarkDocument_applyDefaultFont(b *testing.B) {
+	doc := &Document{}
+	doc.SetDefaultFont("Arial", 12)
+	runProperties := `<w:rPr><w:b/><w:color w:val="FF0000"/><w:lang w:val="de-DE"/></w:rPr>`
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		doc.applyDefaultFont(runProperties)
+	}
+}
+
+func TestDocument_SetDefaultFont(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetDefaultFont("Arial", 11)
+	err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": "plain", "key_with_underscore": FormattedText{Text: "bold", Bold: true}})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	font := `<w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:eastAsia="Arial" w:cs="Arial"/>`
+	formatted := font + `<w:b/><w:bCs/><w:sz w:val="22"/><w:szCs w:val="22"/><w:highlight w:val="yellow"/></w:rPr>`
+	if !strings.Contains(documentXml, formatted+`<w:t xml:space="preserve">bold</w:t>`) {
+		t.Error("default font not applied to formatted value")
+	}
+	if !strings.Contains(documentXml, `<w:t xml:space="preserve">plain</w:t>`) || strings.Count(documentXml, font) < 2 {
+		t.Error("default font not applied to plain value")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
@@ -0,0 +1,99 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// FootnotesRelationshipType is the type of the relationship which references the footnotes part.
+	FootnotesRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes"
+	// footnotesContentType is the content type of the footnotes part.
+	footnotesContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.footnotes+xml"
+)
+
+// footnoteIdRegex matches the ids of all footnotes (<w:footnote>) and captures them
+var footnoteIdRegex = regexp.MustCompile(`<w:footnote\s[^>]*w:id="(-?\d+)"`)
+
+// ReplaceWithFootnote replaces the given key inside the main document with a reference to a new footnote
+// containing noteText, e.g. to turn '{cite:5}' into a citation. Every occurrence of the key gets a footnote of its own.
+//
+// The footnotes are appended to word/footnotes.xml using the next free ids. If the document does not have
+// footnotes yet, the part is created together with its relationship and content type.
+// The reference and the footnote use the built-in styles 'FootnoteReference' and 'FootnoteText',
+// the reference is superscripted even if the document does not define them.
+// If the main document does not contain the key, ErrPlaceholderNotFound is returned.
+func (d *Document) ReplaceWithFootnote(key string, noteText string) error {
+	replacer := d.fileReplacers[d.mainPart]
+	if !replacer.contains(key) {
+		return ErrPlaceholderNotFound
+	}
+
+	footnotes, err := d.readFootnotes()
+	if err != nil {
+		return err
+	}
+	id := nextId(footnotes, footnoteIdRegex)
+
+	var notes strings.Builder
+	err = replacer.replace(key, -1, func(placeholder *Placeholder) string {
+		notes.WriteString(footnote(id, noteText))
+		reference := footnoteReferenceRun(id)
+		id++
+		return replacer.splitRun(placeholder.Fragments[0].Run, reference)
+	})
+	if err != nil {
+		return err
+	}
+	if err := d.SetFile(d.mainPart, replacer.Bytes()); err != nil {
+		return err
+	}
+
+	closeTag := strings.LastIndex(string(footnotes), "</w:footnotes>")
+	if closeTag < 0 {
+		return fmt.Errorf("unable to modify %s: missing root element", FootnotesXml)
+	}
+	footnotes = splice(footnotes, closeTag, closeTag, notes.String())
+
+	// once loaded by ReplaceEverywhere, the footnotes are subject to replacing and must be parsed again
+	if _, loaded := d.files[FootnotesXml]; loaded {
+		d.files[FootnotesXml] = footnotes
+		return d.parseFile(FootnotesXml)
+	}
+	d.writePart(FootnotesXml, footnotes)
+	return nil
+}
+
+// readFootnotes returns the contents of the footnotes part.
+// If the document does not have footnotes yet, an empty footnotes part is returned and its relationship
+// and content type are added.
+func (d *Document) readFootnotes() ([]byte, error) {
+	if footnotes, err := d.readFile(FootnotesXml); err == nil {
+		return footnotes, nil
+	}
+
+	if err := d.ensureMainPartReference(FootnotesXml, FootnotesRelationshipType, footnotesContentType); err != nil {
+		return nil, err
+	}
+
+	// the separators are referenced by the footnote properties of Word, they are always present
+	return []byte(xml.Header + `<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:type="continuationSeparator" w:id="0"><w:p><w:r><w:continuationSeparator/></w:r></w:p></w:footnote>` +
+		`</w:footnotes>`), nil
+}
+
+// footnoteReferenceRun returns the run (<w:r>) which references the footnote with the given id.
+func footnoteReferenceRun(id int) string {
+	return fmt.Sprintf(`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/><w:vertAlign w:val="superscript"/></w:rPr>`+
+		`<w:footnoteReference w:id="%d"/></w:r>`, id)
+}
+
+// footnote returns the footnote (<w:footnote>) with the given id and text.
+func footnote(id int, text string) string {
+	return fmt.Sprintf(`<w:footnote w:id="%d"><w:p><w:pPr><w:pStyle w:val="FootnoteText"/></w:pPr>`+
+		`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/><w:vertAlign w:val="superscript"/></w:rPr><w:footnoteRef/></w:r>`+
+		`<w:r><w:t xml:space="preserve"> %s</w:t></w:r></w:p></w:footnote>`, id, escapeTextValue(text, `<w:t xml:space="preserve">`))
+}
@@ -0,0 +1,95 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceWithFootnote(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceWithFootnote("key", "See page 5 & 6"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `<w:footnoteReference w:id="1"/>`) {
+		t.Error("footnote reference was not inserted")
+	}
+	footnotes, err := doc.readFile(FootnotesXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(footnotes), `<w:footnote w:id="1">`) || !strings.Contains(string(footnotes), "See page 5 &amp; 6") {
+		t.Error("footnote was not added")
+	}
+
+	// ids are allocated after the existing footnotes
+	if err = doc.ReplaceWithFootnote("key-with-dash", "Second"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), `<w:footnoteReference w:id="2"/>`) {
+		t.Error("footnote id collides with an existing footnote")
+	}
+
+	if err = doc.ReplaceWithFootnote("does-not-exist", "Note"); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, have=%v", err)
+	}
+}
+
+func TestDocument_ReplaceWithFootnote_MissingPart(t *testing.T) {
+	doc, err := Open("./test/no-footnotes.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceWithFootnote("key", "Note"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("writing failed", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("reopening failed", err)
+		return
+	}
+	footnotes, err := written.readFile(FootnotesXml)
+	if err != nil {
+		t.Error("footnotes part was not created", err)
+		return
+	}
+	if !strings.Contains(string(footnotes), `<w:footnote w:id="1">`) {
+		t.Error("footnote was not added")
+	}
+	if written.contentType(FootnotesXml) != footnotesContentType {
+		t.Error("content type of the footnotes part was not added")
+	}
+	rels, err := written.readRelationships(DocumentXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	found := false
+	for _, rel := range rels {
+		found = found || (rel.Type == FootnotesRelationshipType && rel.Target == "footnotes.xml")
+	}
+	if !found {
+		t.Error("relationship of the footnotes part was not added")
+	}
+}
@@ -0,0 +1,212 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// FormattedText is a replacement value which carries its own run properties.
+// Instead of inserting the text into the run of the placeholder, the run is split at the placeholder
+// and the text is inserted as a run of its own, styled according to the set properties.
+//
+// The properties which are set override those of the run of the placeholder, everything else is inherited from it,
+// e.g. a bold FormattedText inserted into a run using the font 'Georgia' is bold and uses 'Georgia'.
+//
+// A value can also be a slice of FormattedText segments (e.g. to render 'H₂O' as 'H', '2' as subscript and 'O'),
+// each segment is inserted as a run of its own.
+type FormattedText struct {
+	Text      string
+	Bold      bool
+	Italic    bool
+	Color     string // Color is the hex RGB value of the text color, e.g. 'FF0000'
+	VertAlign string // VertAlign is the vertical alignment of the text, one of 'superscript', 'subscript' or 'baseline'
+	Font      string // Font is the name of the font, e.g. 'Arial'
+	Size      int    // Size is the font size in points
+	Highlight string // Highlight is the highlight color of the text, e.g. 'yellow'
+	Underline string // Underline is the underline style of the text, one of UnderlineStyles (or 'wavy'), e.g. 'single'
+	Strike    bool   // Strike draws a single line through the text
+	Lang      string // Lang is the proofing language of the text, e.g. 'fr-FR'
+
+	// ThemeColor is the theme color of the text, one of ThemeColorNames, e.g. 'accent1' (see Document.ThemeColors).
+	// It takes precedence over Color, which is only used by applications that do not support themes.
+	ThemeColor string
+}
+
+// runPropertiesChangeRegex matches tracked changes of run properties (<w:rPrChange>)
+var runPropertiesChangeRegex = regexp.MustCompile(`(?s)<w:rPrChange(?:\s[^>]*)?/>|<w:rPrChange(?:\s[^>]*)?>.*?</w:rPrChange>`)
+
+// UnderlineStyles are the underline styles of WordprocessingML which are accepted by FormattedText.Underline.
+var UnderlineStyles = []string{
+	"single", "words", "double", "thick", "dotted", "dottedHeavy", "dash", "dashedHeavy", "dashLong", "dashLongHeavy",
+	"dotDash", "dashDotHeavy", "dotDotDash", "dashDotDotHeavy", "wave", "wavyHeavy", "wavyDouble", "none",
+}
+
+// underlineAliases maps common names of underline styles which are not part of UnderlineStyles to their style.
+var underlineAliases = map[string]string{
+	"wavy": "wave",
+}
+
+// underlineStyle returns the underline style of the given name, resolving aliases (e.g. 'wavy' to 'wave').
+func underlineStyle(name string) string {
+	if style, ok := underlineAliases[name]; ok {
+		return style
+	}
+	return name
+}
+
+// ErrInvalidUnderline is returned if the underline style of a FormattedText is not one of UnderlineStyles.
+var ErrInvalidUnderline = errors.New("invalid underline style")
+
+// Validate returns an error wrapping ErrInvalidUnderline if the underline style is set, but not one of UnderlineStyles,
+// or an error wrapping ErrInvalidThemeColor if the theme color is set, but not one of ThemeColorNames.
+// Note that the names are case-sensitive. The wavy line is called 'wave', 'wavy' is accepted as an alias.
+func (f FormattedText) Validate() error {
+	if f.Underline != "" && !containsString(UnderlineStyles, underlineStyle(f.Underline)) {
+		return fmt.Errorf("%w: %s", ErrInvalidUnderline, f.Underline)
+	}
+	if f.ThemeColor != "" && !containsString(ThemeColorNames, f.ThemeColor) {
+		return fmt.Errorf("%w: %s", ErrInvalidThemeColor, f.ThemeColor)
+	}
+	return nil
+}
+
+// containsString returns true if the values contain the given value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateFormattedTexts validates all given segments, see FormattedText.Validate.
+func validateFormattedTexts(texts []FormattedText) error {
+	for _, text := range texts {
+		if err := text.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runPropertyElement is a child element of the run properties (<w:rPr>) together with its local name.
+type runPropertyElement struct {
+	name    string
+	element string
+}
+
+// propertyElements returns the run property elements of all set properties,
+// in the order defined by the WordprocessingML schema.
+func (f FormattedText) propertyElements() []runPropertyElement {
+	var elements []runPropertyElement
+	add := func(name, element string) {
+		elements = append(elements, runPropertyElement{name: name, element: element})
+	}
+	if f.Font != "" {
+		add("rFonts", fontElement(f.Font))
+	}
+	if f.Bold {
+		add("b", "<w:b/>")
+	}
+	if f.Italic {
+		add("i", "<w:i/>")
+	}
+	if f.Strike {
+		add("strike", "<w:strike/>")
+	}
+	if f.Color != "" || f.ThemeColor != "" {
+		add("color", colorElement(f.Color, f.ThemeColor))
+	}
+	if f.Size > 0 {
+		add("sz", sizeElement("sz", f.Size))
+		add("szCs", sizeElement("szCs", f.Size))
+	}
+	if f.Highlight != "" {
+		add("highlight", highlightElement(f.Highlight))
+	}
+	if f.Underline != "" {
+		add("u", fmt.Sprintf(`<w:u w:val="%s"/>`, html.EscapeString(underlineStyle(f.Underline))))
+	}
+	if f.VertAlign != "" {
+		add("vertAlign", fmt.Sprintf(`<w:vertAlign w:val="%s"/>`, html.EscapeString(f.VertAlign)))
+	}
+	if f.Lang != "" {
+		add("lang", languageElement(f.Lang))
+	}
+	return elements
+}
+
+// runProperties assembles the <w:rPr> element of the FormattedText, merged into the given run properties
+// of the placeholder run (which may be empty): the properties which are set override those of the placeholder run,
+// all other properties are inherited. Properties cannot be unset, e.g. a bold placeholder run stays bold.
+// Tracked changes of the formatting (<w:rPrChange>) are not inherited.
+// If no property is set at all, an empty string is returned.
+func (f FormattedText) runProperties(base string) string {
+	elements := f.propertyElements()
+	base = runPropertiesChangeRegex.ReplaceAllString(base, "")
+	if base != "" && base != "<w:rPr/>" && base != "<w:rPr></w:rPr>" {
+		props := []byte(base)
+		for _, element := range elements {
+			merged, err := setElement(props, "rPr", element.name, element.element, runPropertiesElementOrder)
+			if err != nil {
+				return f.runProperties("")
+			}
+			props = merged
+		}
+		return string(props)
+	}
+
+	if len(elements) == 0 {
+		return ""
+	}
+	var props strings.Builder
+	props.WriteString("<w:rPr>")
+	for _, element := range elements {
+		props.WriteString(element.element)
+	}
+	props.WriteString("</w:rPr>")
+	return props.String()
+}
+
+// colorElement returns the color element (<w:color>) of the given hex RGB value and theme color, either may be empty.
+// The value is required, it is 'auto' if only the theme color is set.
+func colorElement(color string, themeColor string) string {
+	if color == "" {
+		color = "auto"
+	}
+	if themeColor == "" {
+		return fmt.Sprintf(`<w:color w:val="%s"/>`, html.EscapeString(color))
+	}
+	return fmt.Sprintf(`<w:color w:val="%s" w:themeColor="%s"/>`, html.EscapeString(color), html.EscapeString(themeColor))
+}
+
+// run returns the complete run (<w:r>) of the FormattedText, its properties are merged into the given
+// run properties of the placeholder run (see runProperties).
+func (f FormattedText) run(base string) string {
+	return f.runWith(f.runProperties(base))
+}
+
+// runWith returns the complete run (<w:r>) of the text of the FormattedText with the given run properties.
+func (f FormattedText) runWith(runProperties string) string {
+	return "<w:r>" + runProperties + `<w:t xml:space="preserve">` + escapeTextValue(f.Text, `<w:t xml:space="preserve">`) + "</w:t></w:r>"
+}
+
+// formattedRuns returns the runs of all given FormattedText segments, merged into the given run properties.
+func formattedRuns(texts []FormattedText, base string) string {
+	var runs strings.Builder
+	for _, text := range texts {
+		runs.WriteString(text.run(base))
+	}
+	return runs.String()
+}
+
+// ValueFormatter is called for every value before it is inserted into the document.
+// It can be used to convert values into their textual representation or to style
+// them based on their content by returning a FormattedText. Pointer values are dereferenced before they are passed.
+// Any returned value which is neither a string, a []byte, a FormattedText, a []FormattedText, HTML nor a Symbol
+// is formatted using fmt.Sprint.
+type ValueFormatter func(key string, value interface{}) interface{}
@@ -0,0 +1,201 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReplacer_ReplaceFormatted(t *testing.T) {
+	docBytes := readFile(t, "./test/placeholder.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	err := replacer.ReplaceFormatted("single", FormattedText{Text: "-42", Bold: true, Color: "FF0000"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	result := string(replacer.Bytes())
+	expected := `<w:r><w:rPr><w:b/><w:color w:val="FF0000"/></w:rPr><w:t xml:space="preserve">-42</w:t></w:r>`
+	if count := strings.Count(result, expected); count != 2 {
+		t.Errorf("formatted run not inserted, want=%d, have=%d", 2, count)
+	}
+	if strings.Contains(result, "{single}") {
+		t.Error("placeholder {single} was not replaced")
+	}
+
+	// the remaining placeholders must still be replaceable after the runs have been split
+	err = replacer.Replace("foo_bar", "BAR BAZ")
+	if err != nil {
+		t.Error("replacing after split failed", err)
+		return
+	}
+	if err = xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestDocument_SetValueFormatter(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetValueFormatter(func(key string, value interface{}) interface{} {
+		if f, ok := value.(float64); ok && f < 0 {
+			return FormattedText{Text: "-1.50", Color: "FF0000"}
+		}
+		return value
+	})
+
+	err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": -1.5, "key_with_underscore": 1.5})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `<w:rPr><w:i/><w:iCs/><w:color w:val="FF0000"/></w:rPr><w:t xml:space="preserve">-1.50</w:t>`) {
+		t.Error("negative value was not formatted")
+	}
+	if !strings.Contains(documentXml, ">1.5</w:t>") {
+		t.Error("positive value was not replaced as plain text")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+// newTestReplacer parses the given bytes and returns a Replacer for them.
+// Placeholders spanning paragraphs are allowed.
+func newTestReplacer(t testing.TB, docBytes []byte) *Replacer {
+	parser := NewRunParser(docBytes)
+	if err := parser.Execute(); err != nil {
+		t.Fatalf("parser.Execute failed: %s", err)
+	}
+	placeholders, err := parsePlaceholders(parser.Runs(), docBytes, true)
+	if err != nil {
+		t.Fatalf("parsePlaceholders failed: %s", err)
+	}
+	return NewReplacer(docBytes, placeholders)
+}
+
+func TestReplacer_ReplaceFormatted_VertAlign(t *testing.T) {
+	docBytes := readFile(t, "./test/placeholder.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	err := replacer.ReplaceFormatted("yet-another-placeholder",
+		FormattedText{Text: "H"},
+		FormattedText{Text: "2", VertAlign: "subscript"},
+		FormattedText{Text: "O"},
+	)
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	expected := `<w:r><w:t xml:space="preserve">H</w:t></w:r>` +
+		`<w:r><w:rPr><w:vertAlign w:val="subscript"/></w:rPr><w:t xml:space="preserve">2</w:t></w:r>` +
+		`<w:r><w:t xml:space="preserve">O</w:t></w:r>`
+	if !strings.Contains(string(replacer.Bytes()), expected) {
+		t.Error("formatted segments were not inserted")
+	}
+	if err = xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestFormattedText_UnderlineStrike(t *testing.T) {
+	docBytes := readFile(t, "./test/placeholder.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	err := replacer.ReplaceFormatted("yet-another-placeholder", FormattedText{Text: "old", Underline: "double", Strike: true})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	expected := `<w:r><w:rPr><w:strike/><w:u w:val="double"/></w:rPr><w:t xml:space="preserve">old</w:t></w:r>`
+	if !strings.Contains(string(replacer.Bytes()), expected) {
+		t.Error("underlined and struck through run was not inserted")
+	}
+
+	// 'wavy' is an alias of the schema value 'wave'
+	err = replacer.ReplaceFormatted("single", FormattedText{Text: "wavy", Underline: "wavy"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(string(replacer.Bytes()), `<w:u w:val="wave"/>`) {
+		t.Error("alias of the underline style was not resolved")
+	}
+
+	err = replacer.ReplaceFormatted("some_placeholder", FormattedText{Text: "zigzag", Underline: "zigzag"})
+	if !errors.Is(err, ErrInvalidUnderline) {
+		t.Errorf("expected ErrInvalidUnderline, got %v", err)
+	}
+	if !strings.Contains(string(replacer.Bytes()), "_placeholder}") {
+		t.Error("placeholder must not be replaced by an invalid value")
+	}
+
+	for _, style := range UnderlineStyles {
+		if err := (FormattedText{Underline: style}).Validate(); err != nil {
+			t.Errorf("underline style %s must be valid: %s", style, err)
+		}
+	}
+}
+
+func TestDocument_ReplaceAll_InvalidUnderline(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"key": []FormattedText{{Text: "a"}, {Text: "b", Underline: "Single"}}})
+	if !errors.Is(err, ErrInvalidUnderline) {
+		t.Errorf("expected ErrInvalidUnderline, got %v", err)
+	}
+}
+
+func TestReplacer_ReplaceFormatted_MergeRunProperties(t *testing.T) {
+	docBytes := readFile(t, "./test/run_props_merge.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	if err := replacer.ReplaceFormatted("name", FormattedText{Text: "Jane", Bold: true}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if err := replacer.ReplaceFormatted("amount", FormattedText{Text: "-1.50", Color: "FF0000", Size: 10}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if err := replacer.ReplaceFormatted("plain", FormattedText{Text: "plain", Italic: true}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	result := string(replacer.Bytes())
+	for _, expected := range []string{
+		// the set properties are added, the others are inherited from the placeholder run
+		`<w:r><w:rPr><w:rStyle w:val="Emphasis"/><w:rFonts w:ascii="Georgia" w:hAnsi="Georgia"/><w:b/><w:i/><w:color w:val="00FF00"/><w:sz w:val="28"/></w:rPr><w:t xml:space="preserve">Jane</w:t></w:r>`,
+		// the set properties override those of the placeholder run
+		`<w:r><w:rPr><w:rStyle w:val="Emphasis"/><w:rFonts w:ascii="Georgia" w:hAnsi="Georgia"/><w:i/><w:color w:val="FF0000"/><w:sz w:val="20"/><w:szCs w:val="20"/></w:rPr><w:t xml:space="preserve">-1.50</w:t></w:r>`,
+		`<w:r><w:rPr><w:i/></w:rPr><w:t xml:space="preserve">plain</w:t></w:r>`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s in the replaced document", expected)
+		}
+	}
+	// the text around the placeholders keeps the original properties, including the tracked change
+	if strings.Count(result, "<w:rPrChange") != 3 {
+		t.Errorf("tracked changes of the placeholder run must only be kept for the original text, have %d", strings.Count(result, "<w:rPrChange"))
+	}
+	if err := xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
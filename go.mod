@@ -2,4 +2,7 @@ module github.com/lukasjarosch/go-docx
 
 go 1.14
 
-require golang.org/x/net v0.0.0-20200925080053-05aa5d4ee321
+require (
+	golang.org/x/net v0.0.0-20200925080053-05aa5d4ee321
+	golang.org/x/text v0.3.0
+)
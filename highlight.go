@@ -0,0 +1,77 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+)
+
+// HighlightColors are the highlight colors of WordprocessingML which are accepted by SetHighlightReplacements.
+var HighlightColors = []string{
+	"black", "blue", "cyan", "green", "magenta", "red", "yellow", "white", "darkBlue", "darkCyan",
+	"darkGreen", "darkMagenta", "darkRed", "darkYellow", "darkGray", "lightGray",
+}
+
+// ErrInvalidHighlightColor is returned if the highlight color is not one of HighlightColors.
+var ErrInvalidHighlightColor = errors.New("invalid highlight color")
+
+// SetHighlightReplacements sets the highlight color of all inserted values, e.g. 'yellow', so reviewers can see
+// which text was filled in automatically. The color must be one of HighlightColors (e.g. 'yellow', 'green', 'cyan'
+// or 'lightGray'), otherwise an error wrapping ErrInvalidHighlightColor is returned and the highlight color is not changed.
+// Note that the names are case-sensitive. An empty color disables highlighting, which is the default.
+//
+// Like the default font (see SetDefaultFont), the value is inserted as a run of its own to be highlighted.
+// Once reviewed, the highlights can be removed with RemoveHighlights.
+func (d *Document) SetHighlightReplacements(color string) error {
+	if color != "" && !containsString(HighlightColors, color) {
+		return fmt.Errorf("%w: %s", ErrInvalidHighlightColor, color)
+	}
+	d.highlightColor = color
+	return nil
+}
+
+// RemoveHighlights removes all highlights of the given color from the main document, the headers, the footers and
+// the loaded additional parts, e.g. to finalize a document after reviewing the values highlighted by
+// SetHighlightReplacements. Note that highlights of the template itself are removed as well if they have the same color.
+// An empty color removes all highlights.
+func (d *Document) RemoveHighlights(color string) error {
+	value := `[^"]*`
+	if color != "" {
+		value = regexp.QuoteMeta(html.EscapeString(color))
+	}
+	highlight := regexp.MustCompile(fmt.Sprintf(`<w:highlight\s+w:val="%s"\s*/>`, value))
+
+	for _, name := range append(d.readingOrder(), d.additionalFiles...) {
+		if !highlight.Match(d.files[name]) {
+			continue
+		}
+		d.files[name] = highlight.ReplaceAll(d.files[name], nil)
+		if err := d.parseFile(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyHighlight returns the run properties with the highlight color of the document set, replacing the
+// highlight of the run. The run properties may be empty. If highlighting is disabled, they are returned unchanged.
+func (d *Document) applyHighlight(runProperties string) string {
+	if d.highlightColor == "" {
+		return runProperties
+	}
+	props := runProperties
+	if props == "" || props == "<w:rPr/>" {
+		props = "<w:rPr></w:rPr>"
+	}
+	result, err := setElement([]byte(props), "rPr", "highlight", highlightElement(d.highlightColor), runPropertiesElementOrder)
+	if err != nil {
+		return runProperties
+	}
+	return string(result)
+}
+
+// highlightElement returns the highlight element (<w:highlight>) of the given color.
+func highlightElement(color string) string {
+	return fmt.Sprintf(`<w:highlight w:val="%s"/>`, html.EscapeString(color))
+}
@@ -0,0 +1,102 @@
+package docx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetHighlightReplacements(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.SetHighlightReplacements("yellow"); err != nil {
+		t.Error("setting the highlight color failed", err)
+		return
+	}
+	err = doc.ReplaceAll(PlaceholderMap{
+		"key":           "plain",
+		"key-with-dash": FormattedText{Text: "formatted", Bold: true},
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{
+		`<w:highlight w:val="yellow"/></w:rPr><w:t xml:space="preserve">plain</w:t>`,
+		`<w:rPr><w:b/><w:highlight w:val="yellow"/></w:rPr><w:t xml:space="preserve">formatted</w:t>`,
+	} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+
+	// the highlighted values must still be replaceable after the runs have been split
+	if err = doc.Replace("key_with_underscore", "after"); err != nil {
+		t.Error("replacing after highlighting failed", err)
+		return
+	}
+
+	if err = doc.RemoveHighlights("yellow"); err != nil {
+		t.Error("removing highlights failed", err)
+		return
+	}
+	for _, name := range doc.readingOrder() {
+		if strings.Contains(string(doc.GetFile(name)), `<w:highlight w:val="yellow"/>`) {
+			t.Errorf("highlights were not removed from %s", name)
+		}
+	}
+	if !strings.Contains(doc.Text(), "plain") {
+		t.Error("removing highlights must keep the text")
+	}
+}
+
+func TestDocument_applyHighlight(t *testing.T) {
+	doc := &Document{}
+	if result := doc.applyHighlight("<w:rPr><w:b/></w:rPr>"); result != "<w:rPr><w:b/></w:rPr>" {
+		t.Errorf("highlight must not be applied if disabled, have=%s", result)
+	}
+
+	if err := doc.SetHighlightReplacements("green"); err != nil {
+		t.Error("setting the highlight color failed", err)
+		return
+	}
+	tests := []struct {
+		runProperties string
+		expected      string
+	}{
+		{"", `<w:rPr><w:highlight w:val="green"/></w:rPr>`},
+		{`<w:rPr><w:sz w:val="24"/><w:u w:val="single"/></w:rPr>`, `<w:rPr><w:sz w:val="24"/><w:highlight w:val="green"/><w:u w:val="single"/></w:rPr>`},
+		{`<w:rPr><w:highlight w:val="yellow"/></w:rPr>`, `<w:rPr><w:highlight w:val="green"/></w:rPr>`},
+	}
+	for _, tt := range tests {
+		if result := doc.applyHighlight(tt.runProperties); result != tt.expected {
+			t.Errorf("applyHighlight(%s), want=%s, have=%s", tt.runProperties, tt.expected, result)
+		}
+	}
+}
+
+func TestDocument_SetHighlightReplacements_InvalidColor(t *testing.T) {
+	doc := &Document{}
+	if err := doc.SetHighlightReplacements("yellow"); err != nil {
+		t.Error("setting a valid highlight color failed", err)
+		return
+	}
+	for _, color := range []string{"orange", "Yellow", "#FFFF00"} {
+		if err := doc.SetHighlightReplacements(color); !errors.Is(err, ErrInvalidHighlightColor) {
+			t.Errorf("expected ErrInvalidHighlightColor for %s, got %v", color, err)
+		}
+	}
+	if doc.highlightColor != "yellow" {
+		t.Errorf("an invalid color must not change the highlight color, have=%s", doc.highlightColor)
+	}
+	if err := doc.SetHighlightReplacements(""); err != nil || doc.highlightColor != "" {
+		t.Error("an empty color must disable highlighting", err)
+	}
+}
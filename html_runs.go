@@ -0,0 +1,136 @@
+package docx
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HTML is a replacement value of lightweight inline markup, e.g. '<b>bold</b> and <i>italic</i>'.
+// It is converted into FormattedText segments by the HTMLConverter of the document, see SetHTMLConverter.
+type HTML string
+
+// HTMLConverter converts an HTML value into the FormattedText segments which are inserted into the document.
+type HTMLConverter func(html string) ([]FormattedText, error)
+
+// htmlColorRegex matches the color declaration of a style attribute and captures the hex RGB value
+var htmlColorRegex = regexp.MustCompile(`(?i)(?:^|;)\s*color\s*:\s*#([0-9a-f]{6}|[0-9a-f]{3})\b`)
+
+// SetHTMLConverter sets the converter of HTML values. By default, HTMLToRuns is used.
+// Passing nil restores the default.
+func (d *Document) SetHTMLConverter(converter HTMLConverter) {
+	d.htmlConverter = converter
+}
+
+// convertHTML converts the HTML value into FormattedText segments using the HTMLConverter of the document.
+// If the conversion fails, the markup is inserted as plain text.
+func (d *Document) convertHTML(value HTML) interface{} {
+	converter := d.htmlConverter
+	if converter == nil {
+		converter = HTMLToRuns
+	}
+	segments, err := converter(string(value))
+	if err != nil {
+		log.Printf("unable to convert HTML value, inserting it as text: %s\n", err)
+		return string(value)
+	}
+	return segments
+}
+
+// HTMLToRuns converts a safe subset of inline HTML into FormattedText segments:
+//   - <b> and <strong> are bold, <i> and <em> are italic, <u> is underlined
+//   - <br> is a line break
+//   - <span style="color: #FF0000"> sets the text color
+//
+// Unsupported tags are stripped, their text is kept. Entities are unescaped.
+// Adjacent text with the same formatting is merged into one segment.
+func HTMLToRuns(markup string) ([]FormattedText, error) {
+	tokenizer := html.NewTokenizer(strings.NewReader(markup))
+
+	var segments []FormattedText
+	appendText := func(format FormattedText, text string) {
+		if last := len(segments) - 1; last >= 0 {
+			merged := segments[last]
+			merged.Text = format.Text
+			if merged == format {
+				segments[last].Text += text
+				return
+			}
+		}
+		format.Text = text
+		segments = append(segments, format)
+	}
+
+	// every open tag pushes the formatting of its content, the closing tag pops it again
+	type openTag struct {
+		name   string
+		format FormattedText
+	}
+	stack := []openTag{{}}
+	current := func() FormattedText {
+		return stack[len(stack)-1].format
+	}
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, fmt.Errorf("unable to parse HTML: %s", err)
+			}
+			return segments, nil
+
+		case html.TextToken:
+			appendText(current(), string(tokenizer.Text()))
+
+		case html.SelfClosingTagToken:
+			if token := tokenizer.Token(); token.Data == "br" {
+				appendText(current(), "\n")
+			}
+
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			format := current()
+			switch token.Data {
+			case "br":
+				appendText(format, "\n")
+				continue // <br> does not have any content
+			case "b", "strong":
+				format.Bold = true
+			case "i", "em":
+				format.Italic = true
+			case "u":
+				format.Underline = "single"
+			case "span":
+				for _, attr := range token.Attr {
+					if match := htmlColorRegex.FindStringSubmatch(attr.Val); attr.Key == "style" && match != nil {
+						format.Color = expandHexColor(match[1])
+					}
+				}
+			}
+			stack = append(stack, openTag{name: token.Data, format: format})
+
+		case html.EndTagToken:
+			name := tokenizer.Token().Data
+			// unbalanced tags close all tags opened after them, unmatched closing tags are ignored
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+}
+
+// expandHexColor returns the six digit, uppercase form of a hex RGB color, e.g. 'F00' becomes 'FF0000'.
+func expandHexColor(color string) string {
+	color = strings.ToUpper(color)
+	if len(color) == 3 {
+		return string([]byte{color[0], color[0], color[1], color[1], color[2], color[2]})
+	}
+	return color
+}
@@ -0,0 +1,70 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHTMLToRuns(t *testing.T) {
+	tests := []struct {
+		markup   string
+		expected []FormattedText
+	}{
+		{"plain", []FormattedText{{Text: "plain"}}},
+		{"<b>bold</b> and <i>italic</i>", []FormattedText{{Text: "bold", Bold: true}, {Text: " and "}, {Text: "italic", Italic: true}}},
+		{"<strong><em>both</em></strong>", []FormattedText{{Text: "both", Bold: true, Italic: true}}},
+		{"<u>under</u>line", []FormattedText{{Text: "under", Underline: "single"}, {Text: "line"}}},
+		{"one<br>two<br/>three", []FormattedText{{Text: "one\ntwo\nthree"}}},
+		{`<span style="font-weight: bold; color: #f00">red</span>`, []FormattedText{{Text: "red", Color: "FF0000"}}},
+		{`<span style="color:#00FF00">green</span>`, []FormattedText{{Text: "green", Color: "00FF00"}}},
+		{`<a href="https://example.com">link</a> &amp; <script>text</script>`, []FormattedText{{Text: "link & text"}}},
+		{"<b>unclosed <i>nested</b> after", []FormattedText{{Text: "unclosed ", Bold: true}, {Text: "nested", Bold: true, Italic: true}, {Text: " after"}}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		segments, err := HTMLToRuns(tt.markup)
+		if err != nil {
+			t.Errorf("HTMLToRuns(%s) failed: %s", tt.markup, err)
+			continue
+		}
+		if !reflect.DeepEqual(segments, tt.expected) {
+			t.Errorf("HTMLToRuns(%s), want=%v, have=%v", tt.markup, tt.expected, segments)
+		}
+	}
+}
+
+func TestDocument_ReplaceAll_HTML(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"key": HTML("<b>bold</b> and <u>underlined</u>")})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{
+		`<w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">bold</w:t></w:r><w:r><w:t xml:space="preserve"> and </w:t></w:r>`,
+		`<w:r><w:rPr><w:u w:val="single"/></w:rPr><w:t xml:space="preserve">underlined</w:t></w:r>`,
+	} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+
+	doc.SetHTMLConverter(func(html string) ([]FormattedText, error) {
+		return []FormattedText{{Text: strings.ToUpper(html)}}, nil
+	})
+	if err = doc.ReplaceAll(PlaceholderMap{"key-with-dash": HTML("custom")}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "CUSTOM") {
+		t.Error("custom HTML converter was not used")
+	}
+}
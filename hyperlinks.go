@@ -0,0 +1,61 @@
+package docx
+
+import (
+	"html"
+	"regexp"
+)
+
+var (
+	// hyperlinkRegex matches all hyperlinks (<w:hyperlink>), capturing their attributes and their content
+	hyperlinkRegex = regexp.MustCompile(`(?s)<w:hyperlink(\s[^>]*?)?(?:/>|>(.*?)</w:hyperlink>)`)
+	// hyperlinkIdRegex matches the relationship id attribute of a hyperlink and captures the id
+	hyperlinkIdRegex = regexp.MustCompile(`\sr:id="([^"]*)"`)
+	// hyperlinkAnchorRegex matches the anchor attribute of a hyperlink and captures the name of the bookmark
+	hyperlinkAnchorRegex = regexp.MustCompile(`\sw:anchor="([^"]*)"`)
+)
+
+// Hyperlink is a hyperlink (<w:hyperlink>) of the document as returned by Hyperlinks.
+type Hyperlink struct {
+	Part     string // Part is the part which contains the hyperlink, e.g. 'word/document.xml'
+	Text     string // Text is the plain text displayed by the hyperlink
+	URL      string // URL is the target of the relationship of the hyperlink, empty for internal links
+	Anchor   string // Anchor is the name of the bookmark of internal links, or the location inside the target of others
+	External bool   // External is true if the target is outside of the document, e.g. a website
+}
+
+// Hyperlinks returns all hyperlinks of the headers, the main document and the footers in reading order,
+// e.g. to check that the links of a generated document are not broken.
+// The URL of a hyperlink is resolved via the relationships of its part. Internal links to a bookmark of the document
+// (see ReplaceAnchorLink) do not have a relationship, they are reported with the name of the bookmark as Anchor.
+// A hyperlink whose relationship does not exist is reported without URL.
+func (d *Document) Hyperlinks() []Hyperlink {
+	var links []Hyperlink
+	for _, part := range d.readingOrder() {
+		rels, _ := d.readRelationships(part)
+		links = append(links, partHyperlinks(part, d.files[part], rels)...)
+	}
+	return links
+}
+
+// partHyperlinks returns the hyperlinks of the given part, resolving their targets using the relationships of the part.
+func partHyperlinks(part string, data []byte, rels []relationship) []Hyperlink {
+	var links []Hyperlink
+	for _, match := range hyperlinkRegex.FindAllSubmatch(data, -1) {
+		link := Hyperlink{Part: part, Text: plainText(match[2])}
+		if anchor := hyperlinkAnchorRegex.FindSubmatch(match[1]); anchor != nil {
+			link.Anchor = html.UnescapeString(string(anchor[1]))
+		}
+		if id := hyperlinkIdRegex.FindSubmatch(match[1]); id != nil {
+			rId := html.UnescapeString(string(id[1]))
+			for _, rel := range rels {
+				if rel.ID == rId && rel.Type == HyperlinkRelationshipType {
+					link.URL = rel.Target
+					link.External = rel.TargetMode == ExternalTargetMode
+					break
+				}
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
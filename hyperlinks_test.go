@@ -0,0 +1,64 @@
+package docx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_Hyperlinks(t *testing.T) {
+	doc, err := Open("./test/hyperlink_a.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	expected := []Hyperlink{{Part: DocumentXml, Text: "Link a", URL: "https://a.example.com/", External: true}}
+	if links := doc.Hyperlinks(); !reflect.DeepEqual(links, expected) {
+		t.Errorf("unexpected hyperlinks, want=%+v, have=%+v", expected, links)
+	}
+
+	doc, err = Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	if links := doc.Hyperlinks(); len(links) != 0 {
+		t.Errorf("expected no hyperlinks, have %+v", links)
+	}
+
+	doc, err = Open("./test/anchor_link.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	if err = doc.ReplaceAnchorLink("results", "the results", "results"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	expected = []Hyperlink{{Part: DocumentXml, Text: "the results", Anchor: "results"}}
+	if links := doc.Hyperlinks(); len(links) == 0 || !reflect.DeepEqual(links[0], expected[0]) {
+		t.Errorf("unexpected anchor link, want=%+v, have=%+v", expected, links)
+	}
+}
+
+func TestPartHyperlinks(t *testing.T) {
+	data := []byte(`<w:p><w:hyperlink r:id="rId1" w:anchor="top" w:history="1"><w:r><w:t>Top &amp; </w:t></w:r>` +
+		`<w:r><w:t>bottom</w:t></w:r></w:hyperlink><w:hyperlink r:id="rId2"><w:r><w:t>Manual</w:t></w:r></w:hyperlink>` +
+		`<w:hyperlink r:id="rId9"><w:r><w:t>Broken</w:t></w:r></w:hyperlink><w:hyperlink w:anchor="empty"/></w:p>`)
+	rels := []relationship{
+		{ID: "rId1", Type: HyperlinkRelationshipType, Target: "https://example.com/", TargetMode: ExternalTargetMode},
+		{ID: "rId2", Type: HyperlinkRelationshipType, Target: "manual.docx"},
+	}
+	expected := []Hyperlink{
+		{Part: DocumentXml, Text: "Top & bottom", URL: "https://example.com/", Anchor: "top", External: true},
+		{Part: DocumentXml, Text: "Manual", URL: "manual.docx"},
+		{Part: DocumentXml, Text: "Broken"},
+		{Part: DocumentXml, Anchor: "empty"},
+	}
+	if links := partHyperlinks(DocumentXml, data, rels); !reflect.DeepEqual(links, expected) {
+		t.Errorf("unexpected hyperlinks, want=%+v, have=%+v", expected, links)
+	}
+}
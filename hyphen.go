@@ -0,0 +1,134 @@
+package docx
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// SoftHyphen is the character of an optional hyphen, which is only displayed if the word is broken at its position
+	SoftHyphen = '\u00AD'
+	// NonBreakingHyphen is the character of a hyphen at which the line must not be broken
+	NonBreakingHyphen = '\u2011'
+)
+
+var (
+	// hyphenElementsRegex matches a sequence of hyphen elements (<w:softHyphen/> and <w:noBreakHyphen/>)
+	// including the adjacent close tag of the preceding and the open tag of the following text element of the run
+	hyphenElementsRegex = regexp.MustCompile(`((?:</w:t>)?)((?:<w:(?:softHyphen|noBreakHyphen)\s*/>)+)((?:<w:t(?:\s[^>]*)?>)?)`)
+	// hyphenElementRegex matches a single hyphen element
+	hyphenElementRegex = regexp.MustCompile(`<w:(softHyphen|noBreakHyphen)\s*/>`)
+	// textOpenTagRegex matches the open tag of a text element (<w:t>)
+	textOpenTagRegex = regexp.MustCompile(`<w:t(?:\s[^>]*)?>`)
+	// hyphenReplacer normalizes the hyphen characters of placeholder keys
+	hyphenReplacer = strings.NewReplacer(string(SoftHyphen), "", string(NonBreakingHyphen), "-")
+)
+
+// mergeHyphenElements replaces the hyphen elements (<w:softHyphen/> and <w:noBreakHyphen/>) which are adjacent to
+// a text element of their run by the equivalent characters inside the text.
+// The parser only supports a single text element per run, the text around the hyphen elements
+// would be lost otherwise and placeholders containing them would not be found.
+// Only paragraphs which contain a delimiter are modified, all others keep their hyphen elements.
+// Hyphen elements of runs without text are left as they are.
+func mergeHyphenElements(data []byte) []byte {
+	if !hyphenElementRegex.Match(data) {
+		return data
+	}
+	paragraphs, err := findElements(data, ParagraphElementName)
+	if err != nil {
+		return data
+	}
+
+	// nested paragraphs (e.g. inside text boxes) are merged together with their outermost paragraph
+	sort.Slice(paragraphs, func(i, j int) bool {
+		return paragraphs[i].Start < paragraphs[j].Start
+	})
+	var outermost []Position
+	for _, paragraph := range paragraphs {
+		if len(outermost) > 0 && paragraph.End <= outermost[len(outermost)-1].End {
+			continue
+		}
+		outermost = append(outermost, paragraph)
+	}
+
+	// merge from the end, so the positions of the preceding paragraphs stay valid
+	for i := len(outermost) - 1; i >= 0; i-- {
+		paragraph := data[outermost[i].Start:outermost[i].End]
+		if !containsDelimiter(string(paragraph)) {
+			continue
+		}
+		data = splice(data, int(outermost[i].Start), int(outermost[i].End), string(mergeParagraphHyphens(paragraph)))
+	}
+	return data
+}
+
+// mergeParagraphHyphens merges the hyphen elements of the given paragraph, see mergeHyphenElements.
+// If two text elements are merged, the merged element preserves whitespace if either of them did.
+func mergeParagraphHyphens(paragraph []byte) []byte {
+	var result []byte
+	last := 0
+	for _, loc := range hyphenElementsRegex.FindAllSubmatchIndex(paragraph, -1) {
+		result = append(result, paragraph[last:loc[0]]...)
+		last = loc[1]
+		closeTag, elements, openTag := paragraph[loc[2]:loc[3]], paragraph[loc[4]:loc[5]], paragraph[loc[6]:loc[7]]
+		if len(closeTag) == 0 && len(openTag) == 0 {
+			result = append(result, paragraph[loc[0]:loc[1]]...)
+			continue
+		}
+
+		var hyphens strings.Builder
+		for _, element := range hyphenElementRegex.FindAllSubmatch(elements, -1) {
+			if string(element[1]) == "softHyphen" {
+				hyphens.WriteRune(SoftHyphen)
+			} else {
+				hyphens.WriteRune(NonBreakingHyphen)
+			}
+		}
+
+		switch {
+		case len(closeTag) > 0 && len(openTag) > 0:
+			result = append(result, hyphens.String()...)
+			if strings.Contains(string(openTag), `xml:space="preserve"`) {
+				result = preserveLastTextElement(result)
+			}
+		case len(closeTag) > 0:
+			result = append(result, hyphens.String()+string(closeTag)...)
+		default:
+			result = append(result, string(openTag)+hyphens.String()...)
+		}
+	}
+	return append(result, paragraph[last:]...)
+}
+
+// preserveLastTextElement sets the xml:space attribute of the last text element (<w:t>) of the data to 'preserve'.
+func preserveLastTextElement(data []byte) []byte {
+	tags := textOpenTagRegex.FindAllIndex(data, -1)
+	if len(tags) == 0 {
+		return data
+	}
+	loc := tags[len(tags)-1]
+	tag := setAttribute(string(data[loc[0]:loc[1]]), "xml:space", "preserve")
+	return splice(data, loc[0], loc[1], tag)
+}
+
+// containsDelimiter reports whether the text contains the OpenDelimiter or CloseDelimiter or the delimiters of one
+// of the DelimiterPairs, either literally or escaped.
+func containsDelimiter(text string) bool {
+	delimiters := []string{string(OpenDelimiter), string(CloseDelimiter)}
+	for _, pair := range DelimiterPairs {
+		delimiters = append(delimiters, pair.Open, pair.Close)
+	}
+	for _, delimiter := range delimiters {
+		if strings.Contains(text, delimiter) || strings.Contains(text, html.EscapeString(delimiter)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHyphens removes all soft hyphens of the text and replaces non-breaking hyphens by '-'.
+func normalizeHyphens(text string) string {
+	return hyphenReplacer.Replace(text)
+}
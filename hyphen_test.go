@@ -0,0 +1,69 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeHyphenElements(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "between text elements",
+			data:     `<w:p><w:r><w:t>{cus</w:t><w:softHyphen/><w:t>tomer}</w:t></w:r></w:p>`,
+			expected: "<w:p><w:r><w:t>{cus\u00ADtomer}</w:t></w:r></w:p>",
+		},
+		{
+			name:     "multiple elements",
+			data:     `<w:p><w:r><w:t>{a</w:t><w:noBreakHyphen/><w:softHyphen/><w:t xml:space="preserve">b} </w:t></w:r></w:p>`,
+			expected: "<w:p><w:r><w:t xml:space=\"preserve\">{a\u2011\u00ADb} </w:t></w:r></w:p>",
+		},
+		{
+			name:     "leading and trailing elements",
+			data:     `<w:p><w:r><w:noBreakHyphen/><w:t>{a</w:t></w:r><w:r><w:t>b}</w:t><w:softHyphen/></w:r></w:p>`,
+			expected: "<w:p><w:r><w:t>\u2011{a</w:t></w:r><w:r><w:t>b}\u00AD</w:t></w:r></w:p>",
+		},
+		{
+			name:     "run without text",
+			data:     `<w:p><w:r><w:t>{a}</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:noBreakHyphen/></w:r></w:p>`,
+			expected: `<w:p><w:r><w:t>{a}</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:noBreakHyphen/></w:r></w:p>`,
+		},
+		{
+			name:     "paragraph without delimiters",
+			data:     `<w:p><w:r><w:t>co</w:t><w:softHyphen/><w:t>operate</w:t></w:r></w:p><w:p><w:r><w:t>{a</w:t><w:softHyphen/><w:t>b}</w:t></w:r></w:p>`,
+			expected: "<w:p><w:r><w:t>co</w:t><w:softHyphen/><w:t>operate</w:t></w:r></w:p><w:p><w:r><w:t>{a\u00ADb}</w:t></w:r></w:p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := string(mergeHyphenElements([]byte(tt.data))); result != tt.expected {
+				t.Errorf("unexpected result, want=%s, have=%s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDocument_HyphenatedPlaceholders(t *testing.T) {
+	doc, err := Open("./test/hyphen.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"customer": "ACME", "contract-id": "42"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{"Dear ACME,", "<w:t>ACME</w:t>", "<w:t>42</w:t>", "<w:r><w:noBreakHyphen/></w:r>"} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package docx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"html"
+	"image"
+	_ "image/gif"  // register the decoder to determine the size of gif images
+	_ "image/jpeg" // register the decoder to determine the size of jpeg images
+	_ "image/png"  // register the decoder to determine the size of png images
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MediaDirectory is the directory inside the docx-archive which contains the media files.
+	MediaDirectory = "word/media"
+	// emuPerPixel is the number of English Metric Units per pixel at 96 DPI, the unit of sizes in DrawingML.
+	emuPerPixel = 9525
+)
+
+var (
+	// ErrUnsupportedImage is returned if the format of an image cannot be inserted into a document.
+	ErrUnsupportedImage = errors.New("unsupported image format, only png, jpeg and gif are supported")
+	// ErrMediaTooLarge is returned if an image is larger than the limit set with SetMaxMediaBytes.
+	ErrMediaTooLarge = errors.New("media exceeds the size limit")
+	// ErrInvalidPosition is returned if an image cannot be inserted at a position, since it is not inside the text of a run.
+	ErrInvalidPosition = errors.New("position is not inside the text of a run")
+
+	// imageExtensions maps the supported image content types to the extension of their media files.
+	imageExtensions = map[string]string{
+		"image/png":  "png",
+		"image/jpeg": "jpeg",
+		"image/gif":  "gif",
+	}
+	// mediaNameRegex matches the names of numbered media files and captures their number
+	mediaNameRegex = regexp.MustCompile(`^word/media/image(\d+)\.[^/]+$`)
+	// docPrIdRegex matches the ids of all drawing objects
+	docPrIdRegex = regexp.MustCompile(`<wp:docPr\s[^>]*id="(\d+)"`)
+)
+
+// ImageData is an image which can be inserted into the document.
+// The format (png, jpeg or gif) is detected from the data.
+// If Width and Height are not set, the size of the image is used.
+type ImageData struct {
+	Data   []byte
+	Width  int // Width is the displayed width in pixels (at 96 DPI)
+	Height int // Height is the displayed height in pixels (at 96 DPI)
+}
+
+// ReplaceImage will replace the given key with the image in every file.
+// The image is added to the media files once and referenced by every file which contains the key,
+// the files are processed in reading order (see addMedia for the naming of the media files).
+// The run of the placeholder is split and the image is inserted as an inline drawing in a run of its own.
+// If no file contains the key, ErrPlaceholderNotFound is returned.
+func (d *Document) ReplaceImage(key string, img ImageData) error {
+	if _, supported := imageExtensions[http.DetectContentType(img.Data)]; !supported {
+		return ErrUnsupportedImage
+	}
+	if _, _, err := img.size(); err != nil {
+		return err
+	}
+	if err := d.checkMediaSize(key, img); err != nil {
+		return err
+	}
+
+	media := ""
+	for _, name := range d.readingOrder() {
+		replacer := d.fileReplacers[name]
+		if !replacer.contains(key) {
+			continue
+		}
+		var err error
+		if media, err = d.insertImage(name, replacer, key, -1, img, media); err != nil {
+			return err
+		}
+		if err := d.SetFile(name, replacer.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if media == "" {
+		return ErrPlaceholderNotFound
+	}
+	return nil
+}
+
+// SetMaxMediaBytes sets the maximum size of inserted images in bytes, e.g. to protect a document store from
+// user-supplied images of excessive size. Inserting a larger image (e.g. using ReplaceImage or an ImageData value)
+// fails with an error wrapping ErrMediaTooLarge, before anything is added to the document.
+// A maximum of 0 disables the limit, which is the default.
+func (d *Document) SetMaxMediaBytes(maxBytes int64) {
+	d.maxMediaBytes = maxBytes
+}
+
+// checkMediaSize returns an error wrapping ErrMediaTooLarge if the image exceeds the maximum size of inserted images.
+// The subject (e.g. the key of the image) is included in the error.
+func (d *Document) checkMediaSize(subject string, img ImageData) error {
+	if size := int64(len(img.Data)); d.maxMediaBytes > 0 && size > d.maxMediaBytes {
+		return fmt.Errorf("%w: image of %s has %d bytes, at most %d bytes are allowed", ErrMediaTooLarge, subject, size, d.maxMediaBytes)
+	}
+	return nil
+}
+
+// insertImage replaces the first n occurrences of the key in the given part by the image.
+// If n < 0, all occurrences are replaced. The image is added to the archive, unless the name of
+// an already added media file is given. The name of the media file is returned.
+func (d *Document) insertImage(part string, replacer *Replacer, key string, n int, img ImageData, media string) (string, error) {
+	if err := d.checkMediaSize(key, img); err != nil {
+		return "", err
+	}
+	ref, err := d.referenceImage(part, img, media)
+	if err != nil {
+		return "", err
+	}
+	err = replacer.replace(key, n, func(placeholder *Placeholder) string {
+		run := placeholder.Fragments[0].Run
+		return replacer.splitRun(run, d.drawingRun(runProperties(replacer.document, run), ref))
+	})
+	return ref.media, err
+}
+
+// InsertImageAt inserts the image as an inline drawing at the given byte position of the main document,
+// e.g. after locating a text in the bytes returned by GetFile. The bytes between the start and the end of the position
+// are replaced by the image, if both are equal the image is inserted at the start.
+// The position must be inside the text of a single run, which is split at the position. The image is inserted in a
+// run of its own, carrying over the properties of the split run. Otherwise ErrInvalidPosition is returned.
+//
+// The image is added to the archive and referenced by the main document like the images of ReplaceImage.
+func (d *Document) InsertImageAt(pos Position, img ImageData) error {
+	if err := d.checkMediaSize(fmt.Sprintf("position [%d:%d]", pos.Start, pos.End), img); err != nil {
+		return err
+	}
+	data := d.files[d.mainPart]
+	run, err := textRunAt(data, pos)
+	if err != nil {
+		return err
+	}
+	ref, err := d.referenceImage(d.mainPart, img, "")
+	if err != nil {
+		return err
+	}
+
+	// the text before the position now ends a text element, hence it must preserve its whitespace
+	textOpenTag := string(data[run.Text.OpenTag.Start:run.Text.OpenTag.End])
+	if !strings.Contains(textOpenTag, "xml:space") {
+		textOpenTag = strings.TrimSuffix(textOpenTag, ">") + ` xml:space="preserve">`
+	}
+	value := textOpenTag + string(data[run.Text.OpenTag.End:pos.Start]) +
+		"</w:t></w:r>" + d.drawingRun(runProperties(data, run), ref) + "<w:r>" + runProperties(data, run) + textOpenTag
+	d.files[d.mainPart] = splice(data, int(run.Text.OpenTag.Start), int(pos.End), value)
+	return d.parseFile(d.mainPart)
+}
+
+// textRunAt returns the run whose text contains the given position. Start and end must be inside the text of the same
+// run and must not split a character reference (e.g. '&amp;'), otherwise ErrInvalidPosition is returned.
+func textRunAt(data []byte, pos Position) (*Run, error) {
+	if pos.Start < 0 || pos.End < pos.Start || pos.End > int64(len(data)) {
+		return nil, fmt.Errorf("%w: [%d:%d]", ErrInvalidPosition, pos.Start, pos.End)
+	}
+	runParser := NewRunParser(data)
+	if err := runParser.Execute(); err != nil {
+		return nil, err
+	}
+	for _, run := range runParser.Runs() {
+		if !run.HasText || pos.Start < run.Text.OpenTag.End || pos.End > run.Text.CloseTag.Start {
+			continue
+		}
+		for _, offset := range []int64{pos.Start, pos.End} {
+			text := data[run.Text.OpenTag.End:offset]
+			if bytes.LastIndexByte(text, '&') > bytes.LastIndexByte(text, ';') {
+				return nil, fmt.Errorf("%w: [%d:%d] splits a character reference", ErrInvalidPosition, pos.Start, pos.End)
+			}
+		}
+		return run, nil
+	}
+	return nil, fmt.Errorf("%w: [%d:%d]", ErrInvalidPosition, pos.Start, pos.End)
+}
+
+// imageReference is an image which has been added to the archive and is referenced by a part.
+type imageReference struct {
+	rId    string
+	media  string
+	width  int
+	height int
+}
+
+// shareMedia makes the images inserted until the returned function is called share their media files,
+// so an image which is inserted into several parts by a single replacement call is added to the archive only once.
+// Nested calls keep sharing the media files of the outermost call.
+func (d *Document) shareMedia() func() {
+	if d.sharedMedia != nil {
+		return func() {}
+	}
+	d.sharedMedia = make(map[[sha256.Size]byte]string)
+	return func() {
+		d.sharedMedia = nil
+	}
+}
+
+// sharedMediaName returns the name of the media file which was added for the image by the current replacement call,
+// or an empty string if there is none, see shareMedia.
+func (d *Document) sharedMediaName(img ImageData) string {
+	if d.sharedMedia == nil {
+		return ""
+	}
+	return d.sharedMedia[sha256.Sum256(img.Data)]
+}
+
+// referenceImage adds the image to the archive, unless the name of an already added media file is given,
+// and adds a relationship to it to the given part.
+func (d *Document) referenceImage(part string, img ImageData, media string) (imageReference, error) {
+	contentType := http.DetectContentType(img.Data)
+	extension, supported := imageExtensions[contentType]
+	if !supported {
+		return imageReference{}, ErrUnsupportedImage
+	}
+	width, height, err := img.size()
+	if err != nil {
+		return imageReference{}, err
+	}
+
+	if media == "" {
+		media = d.addMedia(extension, img.Data)
+		if err := d.ensureDefaultContentType(extension, contentType); err != nil {
+			return imageReference{}, err
+		}
+	}
+	rId, err := d.addRelationship(part, relationship{Type: ImageRelationshipType, Target: relativeTarget(part, media)})
+	if err != nil {
+		return imageReference{}, err
+	}
+	return imageReference{rId: rId, media: media, width: width, height: height}, nil
+}
+
+// drawingRun returns a run with the drawing of the referenced image and a new drawing id.
+func (d *Document) drawingRun(runProperties string, ref imageReference) string {
+	return drawingRun(runProperties, ref.rId, d.nextDrawingId(), path.Base(ref.media), ref.width, ref.height)
+}
+
+// size returns the displayed size of the image in pixels.
+// If the size is not set, it is read from the image data.
+func (img ImageData) size() (width int, height int, err error) {
+	if img.Width > 0 && img.Height > 0 {
+		return img.Width, img.Height, nil
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(img.Data))
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to determine image size: %s", err)
+	}
+	return config.Width, config.Height, nil
+}
+
+// addMedia adds a new media file with the given extension and data and returns its path.
+//
+// Media files are named 'word/media/imageN.ext'. The number N is taken from a counter which starts one past
+// the highest number used by the media files of the archive (regardless of their extension) and counts up
+// with every added file. Hence the same document and the same sequence of insertions always yield the same names,
+// which keeps the output reproducible.
+func (d *Document) addMedia(extension string, data []byte) string {
+	if d.mediaCounter == 0 {
+		for _, file := range d.zipFile.File {
+			if match := mediaNameRegex.FindStringSubmatch(file.Name); match != nil {
+				if n, err := strconv.Atoi(match[1]); err == nil && n > d.mediaCounter {
+					d.mediaCounter = n
+				}
+			}
+		}
+	}
+	d.mediaCounter++
+
+	name := fmt.Sprintf("%s/image%d.%s", MediaDirectory, d.mediaCounter, extension)
+	d.writePart(name, data)
+	return name
+}
+
+// nextDrawingId returns an id for a new drawing object which is unique inside the document.
+func (d *Document) nextDrawingId() int {
+	maxId := 0
+	for name := range d.fileReplacers {
+		for _, match := range docPrIdRegex.FindAllSubmatch(d.fileReplacers[name].document, -1) {
+			if id, err := strconv.Atoi(string(match[1])); err == nil && id > maxId {
+				maxId = id
+			}
+		}
+	}
+	return maxId + 1
+}
+
+// drawingRun returns a run (<w:r>) containing the inline drawing of the image referenced by rId.
+// All namespaces are declared inline, since the root element of the file might not declare them.
+func drawingRun(runProperties string, rId string, id int, name string, width int, height int) string {
+	cx, cy := width*emuPerPixel, height*emuPerPixel
+	name = html.EscapeString(name)
+	return fmt.Sprintf(`<w:r>%s<w:drawing>`+
+		`<wp:inline distT="0" distB="0" distL="0" distR="0" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing">`+
+		`<wp:extent cx="%d" cy="%d"/><wp:docPr id="%d" name="Picture %d"/>`+
+		`<wp:cNvGraphicFramePr><a:graphicFrameLocks xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" noChangeAspect="1"/></wp:cNvGraphicFramePr>`+
+		`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:nvPicPr><pic:cNvPr id="0" name="%s"/><pic:cNvPicPr/></pic:nvPicPr>`+
+		`<pic:blipFill><a:blip r:embed="%s" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+		`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr>`+
+		`</pic:pic></a:graphicData></a:graphic></wp:inline></w:drawing></w:r>`,
+		runProperties, cx, cy, id, id, name, rId, cx, cy)
+}
@@ -0,0 +1,291 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// testImage returns a png image of the given size.
+func testImage(t testing.TB, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("unable to encode image: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDocument_ReplaceImage(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceImage("key.with.dots", ImageData{Data: testImage(t, 20, 10)})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `<wp:extent cx="190500" cy="95250"/>`) {
+		t.Error("drawing was not inserted with the size of the image")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open written document", err)
+		return
+	}
+	rels, err := written.readRelationships(DocumentXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var target string
+	for _, rel := range rels {
+		if rel.Type == ImageRelationshipType && strings.HasSuffix(rel.Target, ".png") {
+			target = relationshipTarget(DocumentXml, rel.Target)
+		}
+	}
+	if target == "" {
+		t.Error("image relationship was not added")
+		return
+	}
+	if _, err = written.readFile(target); err != nil {
+		t.Errorf("media file %s was not written", target)
+	}
+	contentTypes, _ := written.readFile(ContentTypesXml)
+	if !strings.Contains(string(contentTypes), `Extension="png"`) {
+		t.Error("png content type was not added")
+	}
+
+	if err = doc.ReplaceImage("key.with.dots", ImageData{Data: []byte("not an image")}); !errors.Is(err, ErrUnsupportedImage) {
+		t.Errorf("expected ErrUnsupportedImage, got %v", err)
+	}
+	if err = doc.ReplaceImage("missing", ImageData{Data: testImage(t, 1, 1)}); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, got %v", err)
+	}
+}
+
+// staticEncoder is a QREncoder which always returns the same image.
+type staticEncoder struct {
+	image []byte
+	data  string
+}
+
+func (e *staticEncoder) Encode(data string, size int) ([]byte, error) {
+	e.data = data
+	return e.image, nil
+}
+
+func TestDocument_ReplaceQRCode(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceQRCode("key.with.dots", "ORDER-42", 100); !errors.Is(err, ErrNoQREncoder) {
+		t.Errorf("expected ErrNoQREncoder, got %v", err)
+	}
+
+	encoder := &staticEncoder{image: testImage(t, 25, 25)}
+	doc.SetQREncoder(encoder)
+	if err = doc.ReplaceQRCode("key.with.dots", "ORDER-42", 100); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if encoder.data != "ORDER-42" {
+		t.Errorf("unexpected encoded data: %s", encoder.data)
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), `<wp:extent cx="952500" cy="952500"/>`) {
+		t.Error("qr code was not inserted with the given size")
+	}
+}
+
+func TestDocument_ReplaceImage_Reproducible(t *testing.T) {
+	render := func() []byte {
+		doc, err := Open("./test/template.docx")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer doc.Close()
+
+		for _, key := range []string{"key.with.dots", "key_with_underscore"} {
+			if err = doc.ReplaceImage(key, ImageData{Data: testImage(t, 2, 2)}); err != nil {
+				t.Fatal("replacing failed", err)
+			}
+		}
+		for _, name := range []string{"word/media/image2.png", "word/media/image3.png"} {
+			if _, err = doc.readFile(name); err != nil {
+				t.Errorf("expected media file %s", name)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err = doc.Write(&buf); err != nil {
+			t.Fatal("unable to write", err)
+		}
+		return buf.Bytes()
+	}
+
+	if !bytes.Equal(render(), render()) {
+		t.Error("rendering the same document twice yields different results")
+	}
+}
+
+func TestDocument_ReplaceAll_ImagesReproducible(t *testing.T) {
+	render := func() []byte {
+		doc, err := Open("./test/template.docx")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer doc.Close()
+
+		err = doc.ReplaceAll(PlaceholderMap{
+			"key.with.dots":       ImageData{Data: testImage(t, 2, 2)},
+			"key_with_underscore": ImageData{Data: testImage(t, 3, 3)},
+		})
+		if err != nil {
+			t.Fatal("replacing failed", err)
+		}
+
+		var buf bytes.Buffer
+		if err = doc.Write(&buf); err != nil {
+			t.Fatal("unable to write", err)
+		}
+		return buf.Bytes()
+	}
+
+	// the media names and relationship ids must not depend on the iteration order of the map
+	expected := render()
+	for i := 0; i < 10; i++ {
+		if !bytes.Equal(render(), expected) {
+			t.Error("replacing the same images twice yields different results")
+			return
+		}
+	}
+}
+
+func TestDocument_InsertImageAt(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	text := []byte("<w:t>{key}-{key}-{key}</w:t>")
+	offset := int64(bytes.Index(doc.GetFile(DocumentXml), text))
+	if offset < 0 {
+		t.Fatal("text not found in template")
+	}
+
+	// replace the second '-' by the image
+	start := offset + int64(len("<w:t>{key}"))
+	err = doc.InsertImageAt(Position{Start: start, End: start + 1}, ImageData{Data: testImage(t, 20, 10)})
+	if err != nil {
+		t.Error("inserting failed", err)
+		return
+	}
+	// the image carries over the properties of the split run
+	properties := `<w:rPr><w:rFonts w:ascii="Lato" w:hAnsi="Lato"/><w:color w:val="FF0000"/></w:rPr>`
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `<w:t xml:space="preserve">{key}</w:t></w:r><w:r>`+properties+`<w:drawing>`) {
+		t.Error("run was not split in front of the image")
+	}
+	if !strings.Contains(documentXml, `</w:drawing></w:r><w:r>`+properties+`<w:t xml:space="preserve">{key}-{key}</w:t>`) {
+		t.Error("text following the image was not put into a run of its own")
+	}
+	if !strings.Contains(documentXml, `<wp:extent cx="190500" cy="95250"/>`) {
+		t.Error("drawing was not inserted with the size of the image")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, inserting failed", err)
+	}
+
+	// the placeholders of the split run are still replaced
+	if err = doc.Replace("key", "value"); err != nil {
+		t.Error("replacing after inserting failed", err)
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), `<w:t xml:space="preserve">value-value</w:t>`) {
+		t.Error("placeholders following the image were not replaced")
+	}
+
+	invalid := []Position{
+		{Start: offset, End: offset},                  // inside of the text open tag
+		{Start: start, End: start + int64(len(text))}, // beyond the end of the text
+		{Start: start + 1, End: start},                // end before start
+		{Start: -1, End: 0},                           // outside of the document
+	}
+	for _, pos := range invalid {
+		if err = doc.InsertImageAt(pos, ImageData{Data: testImage(t, 1, 1)}); !errors.Is(err, ErrInvalidPosition) {
+			t.Errorf("expected ErrInvalidPosition for %v, got %v", pos, err)
+		}
+	}
+}
+
+func TestTextRunAt_CharacterReference(t *testing.T) {
+	data := []byte(`<w:p><w:r><w:t>A &amp; B</w:t></w:r></w:p>`)
+	start := int64(bytes.Index(data, []byte("&amp;")))
+	if _, err := textRunAt(data, Position{Start: start + 1, End: start + 1}); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("expected ErrInvalidPosition inside of a character reference, got %v", err)
+	}
+	if _, err := textRunAt(data, Position{Start: start, End: start + int64(len("&amp;"))}); err != nil {
+		t.Errorf("unexpected error around a character reference: %s", err)
+	}
+}
+
+func TestDocument_SetMaxMediaBytes(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	img := ImageData{Data: testImage(t, 20, 10)}
+	doc.SetMaxMediaBytes(int64(len(img.Data)) - 1)
+	before := string(doc.GetFile(DocumentXml))
+
+	err = doc.ReplaceImage("key.with.dots", img)
+	if !errors.Is(err, ErrMediaTooLarge) {
+		t.Errorf("expected ErrMediaTooLarge, got %v", err)
+	} else if expected := fmt.Sprintf("image of key.with.dots has %d bytes", len(img.Data)); !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected the key and the size in the error, have %q", err)
+	}
+	if err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": img}); !errors.Is(err, ErrMediaTooLarge) {
+		t.Errorf("expected ErrMediaTooLarge for an ImageData value, got %v", err)
+	}
+	if err = doc.InsertImageAt(Position{Start: 0, End: 0}, img); !errors.Is(err, ErrMediaTooLarge) {
+		t.Errorf("expected ErrMediaTooLarge for an inserted image, got %v", err)
+	}
+	if string(doc.GetFile(DocumentXml)) != before {
+		t.Error("image exceeding the limit was inserted")
+	}
+	if _, err = doc.readFile("word/media/image2.png"); err == nil {
+		t.Error("image exceeding the limit was added to the media files")
+	}
+
+	doc.SetMaxMediaBytes(int64(len(img.Data)))
+	if err = doc.ReplaceImage("key.with.dots", img); err != nil {
+		t.Error("image within the limit was rejected", err)
+	}
+}
@@ -0,0 +1,70 @@
+package docx
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// expandIndexedKeys returns a copy of the placeholderMap which additionally contains an entry 'key[n]'
+// for every element n of every slice or array value, e.g. {tags[0]} is replaced by the first element of 'tags'.
+// Explicit entries of the map take precedence over the generated ones. Indexes which are out of range do not
+// have an entry and are treated like any other missing key.
+// Byte slices and []FormattedText are values of their own and are not indexed.
+//
+// The second return value maps every generated key to the key of its slice. If there are no slices,
+// the placeholderMap itself is returned.
+func expandIndexedKeys(placeholderMap PlaceholderMap) (PlaceholderMap, map[string]string) {
+	var (
+		expanded PlaceholderMap
+		indexed  map[string]string
+	)
+	for key, value := range placeholderMap {
+		switch value.(type) {
+		case []byte, []FormattedText:
+			continue
+		}
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			continue
+		}
+
+		if expanded == nil {
+			expanded = make(PlaceholderMap, len(placeholderMap))
+			for k, val := range placeholderMap {
+				expanded[k] = val
+			}
+			indexed = make(map[string]string)
+		}
+		for i := 0; i < v.Len(); i++ {
+			indexedKey := fmt.Sprintf("%s[%d]", key, i)
+			if _, exists := placeholderMap[indexedKey]; exists {
+				continue
+			}
+			expanded[indexedKey] = v.Index(i).Interface()
+			indexed[indexedKey] = key
+		}
+	}
+	if expanded == nil {
+		return placeholderMap, nil
+	}
+	return expanded, indexed
+}
+
+// indexedPairs returns the given pairs, each followed by a pair 'key[n]' for every element n of its value
+// if the value is a slice or array (see expandIndexedKeys). Pointer values are dereferenced first.
+func indexedPairs(pairs []KV) []KV {
+	var expanded []KV
+	for _, pair := range pairs {
+		values, _ := expandIndexedKeys(dereferenceValues(PlaceholderMap{pair.Key: pair.Value}))
+		expanded = append(expanded, KV{Key: pair.Key, Value: values[pair.Key]})
+		for i := 0; ; i++ {
+			indexedKey := fmt.Sprintf("%s[%d]", pair.Key, i)
+			value, ok := values[indexedKey]
+			if !ok {
+				break
+			}
+			expanded = append(expanded, KV{Key: indexedKey, Value: value})
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,55 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandIndexedKeys(t *testing.T) {
+	placeholderMap := PlaceholderMap{
+		"tags":    []string{"a", "b"},
+		"tags[1]": "explicit",
+		"bytes":   []byte("raw"),
+		"name":    "John",
+	}
+	expanded, indexed := expandIndexedKeys(placeholderMap)
+
+	expected := PlaceholderMap{
+		"tags":    []string{"a", "b"},
+		"tags[0]": "a",
+		"tags[1]": "explicit",
+		"bytes":   []byte("raw"),
+		"name":    "John",
+	}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Errorf("unexpected expanded map, want=%v, have=%v", expected, expanded)
+	}
+	if !reflect.DeepEqual(indexed, map[string]string{"tags[0]": "tags"}) {
+		t.Errorf("unexpected generated keys %v", indexed)
+	}
+	if _, exists := placeholderMap["tags[0]"]; exists {
+		t.Error("the given map must not be modified")
+	}
+}
+
+func TestDocument_ReplaceAll_IndexedKeys(t *testing.T) {
+	doc, err := Open("./test/indexed.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"tags": []string{"red", "green"}, "amounts": [2]float64{1.5, 2.5}})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	for _, expected := range []string{"Tags: red, green and {tags[5]}", "Amount: 2.5"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q inside the document", expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package docx
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+)
+
+// languageRegex matches the language element (<w:lang>) of run properties
+var languageRegex = regexp.MustCompile(`<w:lang(?:\s[^>]*)?/>`)
+
+// SetLanguage sets the proofing language of all inserted values as a language tag, e.g. 'fr-FR', so the spell checker
+// of Word checks them in the right language. The language replaces the language of the placeholder run
+// for the value only. The same applies to FormattedText values without a language (see FormattedText.Lang).
+//
+// Like the default font (see SetDefaultFont), the value is inserted as a run of its own.
+// An empty tag keeps the language of the placeholder, which is the default.
+func (d *Document) SetLanguage(tag string) {
+	d.language = tag
+}
+
+// applyLanguage returns the run properties with the language of the document set. The other attributes of an existing
+// language element (e.g. the language of east asian text) are kept. The run properties may be empty.
+// If no language is set, they are returned unchanged.
+func (d *Document) applyLanguage(runProperties string) string {
+	if d.language == "" {
+		return runProperties
+	}
+	if loc := languageRegex.FindStringIndex(runProperties); loc != nil {
+		element := setAttribute(runProperties[loc[0]:loc[1]], "w:val", html.EscapeString(d.language))
+		return runProperties[:loc[0]] + element + runProperties[loc[1]:]
+	}
+
+	props := runProperties
+	if props == "" || props == "<w:rPr/>" {
+		props = "<w:rPr></w:rPr>"
+	}
+	result, err := setElement([]byte(props), "rPr", "lang", languageElement(d.language), runPropertiesElementOrder)
+	if err != nil {
+		return runProperties
+	}
+	return string(result)
+}
+
+// languageElement returns the language element (<w:lang>) of the given language tag.
+func languageElement(tag string) string {
+	return fmt.Sprintf(`<w:lang w:val="%s"/>`, html.EscapeString(tag))
+}
@@ -0,0 +1,59 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetLanguage(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetLanguage("fr-FR")
+	err = doc.ReplaceAll(PlaceholderMap{
+		"key":           "bonjour",
+		"key-with-dash": FormattedText{Text: "merci", Bold: true},
+		"key.with.dots": FormattedText{Text: "hello", Lang: "en-GB"},
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{
+		`<w:lang w:val="fr-FR"/></w:rPr><w:t xml:space="preserve">bonjour</w:t>`,
+		`<w:rPr><w:b/><w:lang w:val="fr-FR"/></w:rPr><w:t xml:space="preserve">merci</w:t>`,
+		// the properties of the placeholder run are kept
+		`<w:rPr><w:i/><w:iCs/><w:lang w:val="en-GB"/></w:rPr><w:t xml:space="preserve">hello</w:t>`,
+	} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestDocument_applyLanguage(t *testing.T) {
+	doc := &Document{language: "de-DE"}
+	tests := []struct {
+		props    string
+		expected string
+	}{
+		{"", `<w:rPr><w:lang w:val="de-DE"/></w:rPr>`},
+		{`<w:rPr><w:b/><w:vertAlign w:val="superscript"/></w:rPr>`, `<w:rPr><w:b/><w:vertAlign w:val="superscript"/><w:lang w:val="de-DE"/></w:rPr>`},
+		{`<w:rPr><w:lang w:val="en-US" w:eastAsia="ja-JP"/></w:rPr>`, `<w:rPr><w:lang w:val="de-DE" w:eastAsia="ja-JP"/></w:rPr>`},
+	}
+	for _, tt := range tests {
+		if have := doc.applyLanguage(tt.props); have != tt.expected {
+			t.Errorf("unexpected run properties, want=%s, have=%s", tt.expected, have)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package docx
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale defines how numbers and dates are formatted if they are inserted without a ValueFormatter
+// converting them, e.g. '1.234,56' instead of '1234.56' for German documents.
+type Locale struct {
+	DecimalSeparator string // DecimalSeparator separates the integer part from the fraction, e.g. ','
+	GroupSeparator   string // GroupSeparator separates the groups of thousands, e.g. '.'; empty disables grouping
+	Decimals         int    // Decimals is the fixed number of decimals of floats, -1 uses the shortest representation
+	DateLayout       string // DateLayout is the layout of time.Time values (see time.Format), e.g. '02.01.2006'
+}
+
+var (
+	// LocaleEnglish formats numbers like '1,234.56' and dates like '01/02/2006'.
+	LocaleEnglish = Locale{DecimalSeparator: ".", GroupSeparator: ",", Decimals: -1, DateLayout: "01/02/2006"}
+	// LocaleGerman formats numbers like '1.234,56' and dates like '02.01.2006'.
+	LocaleGerman = Locale{DecimalSeparator: ",", GroupSeparator: ".", Decimals: -1, DateLayout: "02.01.2006"}
+	// LocaleFrench formats numbers like '1 234,56' (with a narrow no-break space) and dates like '02/01/2006'.
+	LocaleFrench = Locale{DecimalSeparator: ",", GroupSeparator: "\u202F", Decimals: -1, DateLayout: "02/01/2006"}
+)
+
+// SetLocale sets the locale which formats all integer, float and time.Time values.
+// Values which are converted by the ValueFormatter are not affected, it can still format single keys differently.
+// By default, no locale is set and values are formatted using fmt.Sprint.
+func (d *Document) SetLocale(locale Locale) {
+	d.locale = &locale
+}
+
+// localize formats the value according to the locale of the document.
+// If no locale is set or the value is neither a number nor a time.Time, false is returned.
+func (d *Document) localize(value interface{}) (string, bool) {
+	if d.locale == nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case int:
+		return d.locale.formatInteger(strconv.FormatInt(int64(v), 10)), true
+	case int8:
+		return d.locale.formatInteger(strconv.FormatInt(int64(v), 10)), true
+	case int16:
+		return d.locale.formatInteger(strconv.FormatInt(int64(v), 10)), true
+	case int32:
+		return d.locale.formatInteger(strconv.FormatInt(int64(v), 10)), true
+	case int64:
+		return d.locale.formatInteger(strconv.FormatInt(v, 10)), true
+	case uint:
+		return d.locale.formatInteger(strconv.FormatUint(uint64(v), 10)), true
+	case uint8:
+		return d.locale.formatInteger(strconv.FormatUint(uint64(v), 10)), true
+	case uint16:
+		return d.locale.formatInteger(strconv.FormatUint(uint64(v), 10)), true
+	case uint32:
+		return d.locale.formatInteger(strconv.FormatUint(uint64(v), 10)), true
+	case uint64:
+		return d.locale.formatInteger(strconv.FormatUint(v, 10)), true
+	case float32:
+		return d.locale.formatFloat(float64(v), 32), true
+	case float64:
+		return d.locale.formatFloat(v, 64), true
+	case time.Time:
+		if d.locale.DateLayout == "" {
+			return "", false
+		}
+		return v.Format(d.locale.DateLayout), true
+	}
+	return "", false
+}
+
+// formatFloat formats the float with the decimals and separators of the locale.
+func (l Locale) formatFloat(f float64, bitSize int) string {
+	text := strconv.FormatFloat(f, 'f', l.Decimals, bitSize)
+	integer, fraction := text, ""
+	if pos := strings.IndexByte(text, '.'); pos >= 0 {
+		integer, fraction = text[:pos], text[pos+1:]
+	}
+	integer = l.formatInteger(integer)
+	if fraction == "" {
+		return integer
+	}
+	return integer + l.DecimalSeparator + fraction
+}
+
+// formatInteger inserts the group separator of the locale into the formatted integer, e.g. '-1234' becomes '-1,234'.
+func (l Locale) formatInteger(integer string) string {
+	sign := ""
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+	if l.GroupSeparator == "" || len(integer) <= 3 {
+		return sign + integer
+	}
+
+	var grouped strings.Builder
+	first := len(integer) % 3
+	if first > 0 {
+		grouped.WriteString(integer[:first])
+	}
+	for i := first; i < len(integer); i += 3 {
+		if grouped.Len() > 0 {
+			grouped.WriteString(l.GroupSeparator)
+		}
+		grouped.WriteString(integer[i : i+3])
+	}
+	return sign + grouped.String()
+}
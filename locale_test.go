@@ -0,0 +1,66 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDocument_localize(t *testing.T) {
+	doc := &Document{}
+	if _, ok := doc.localize(1234.5); ok {
+		t.Error("values must not be localized without a locale")
+	}
+
+	doc.SetLocale(LocaleGerman)
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: 1234.56, expected: "1.234,56"},
+		{value: -1234567, expected: "-1.234.567"},
+		{value: uint8(255), expected: "255"},
+		{value: float32(0.5), expected: "0,5"},
+		{value: time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), expected: "31.01.2023"},
+	}
+	for _, tt := range tests {
+		localized, ok := doc.localize(tt.value)
+		if !ok || localized != tt.expected {
+			t.Errorf("unexpected localized value of %v, want=%s, have=%s", tt.value, tt.expected, localized)
+		}
+	}
+	if _, ok := doc.localize("1234.5"); ok {
+		t.Error("strings must not be localized")
+	}
+
+	doc.SetLocale(Locale{DecimalSeparator: ".", Decimals: 2})
+	if localized, _ := doc.localize(1234.5); localized != "1234.50" {
+		t.Errorf("unexpected localized value, want=%s, have=%s", "1234.50", localized)
+	}
+}
+
+func TestDocument_SetLocale(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetLocale(LocaleGerman)
+	doc.SetValueFormatter(func(key string, value interface{}) interface{} {
+		if key == "key-with-dash" {
+			return "formatted"
+		}
+		return value
+	})
+	err = doc.ReplaceAll(PlaceholderMap{"key": 1234.56, "key-with-dash": 1234.56})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	if !strings.Contains(text, "1.234,56") || !strings.Contains(text, "formatted") {
+		t.Error("values were not localized")
+	}
+}
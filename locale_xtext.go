@@ -0,0 +1,44 @@
+//go:build xtext
+// +build xtext
+
+package docx
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// LocaleFromTag returns the Locale of the language tag, with the separators of numbers as defined by golang.org/x/text,
+// e.g. docx.LocaleFromTag(language.German). The date layout is not defined by golang.org/x/text and left empty,
+// time.Time values are therefore formatted using fmt.Sprint unless a layout is set.
+//
+// It is only available if the lib is built with the 'xtext' build tag (go build -tags xtext), golang.org/x/text is
+// only compiled into programs which are built with the tag. If the separators cannot be determined (e.g. because the
+// locale does not use latin digits), LocaleEnglish is returned.
+//
+// There is no SetLocale taking a language tag: SetLocale takes a Locale, so it is available without the build tag,
+// and Go does not allow a second SetLocale for tags. Use doc.SetLocale(docx.LocaleFromTag(tag)) instead, which also
+// allows adjusting the locale (e.g. its DateLayout) before it is set.
+func LocaleFromTag(tag language.Tag) Locale {
+	formatted := message.NewPrinter(tag).Sprintf("%.2f", 1234567.89)
+
+	// the formatted number consists of the digit groups '1', '234', '567' and '89' and the separators in between
+	groupStart := strings.Index(formatted, "234")
+	groupEnd := strings.Index(formatted, "567")
+	fraction := strings.LastIndex(formatted, "89")
+	if !strings.HasPrefix(formatted, "1") || groupStart < 0 || groupEnd < groupStart || fraction < groupEnd {
+		return LocaleEnglish
+	}
+
+	locale := Locale{
+		DecimalSeparator: formatted[groupEnd+3 : fraction],
+		GroupSeparator:   formatted[groupStart+3 : groupEnd],
+		Decimals:         -1,
+	}
+	if locale.DecimalSeparator == "" {
+		return LocaleEnglish
+	}
+	return locale
+}
@@ -0,0 +1,39 @@
+//go:build xtext
+// +build xtext
+
+package docx
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestLocaleFromTag(t *testing.T) {
+	tests := []struct {
+		tag      language.Tag
+		decimal  string
+		grouping string
+	}{
+		{language.German, ",", "."},
+		{language.English, ".", ","},
+	}
+	for _, tt := range tests {
+		locale := LocaleFromTag(tt.tag)
+		if locale.DecimalSeparator != tt.decimal || locale.GroupSeparator != tt.grouping {
+			t.Errorf("LocaleFromTag(%s), want=%q/%q, have=%q/%q", tt.tag, tt.decimal, tt.grouping,
+				locale.DecimalSeparator, locale.GroupSeparator)
+		}
+		if locale.Decimals != -1 {
+			t.Errorf("LocaleFromTag(%s) must use the shortest representation of floats, have %d decimals", tt.tag, locale.Decimals)
+		}
+	}
+}
+
+func TestDocument_SetLocale_FromTag(t *testing.T) {
+	doc := &Document{}
+	doc.SetLocale(LocaleFromTag(language.German))
+	if localized, ok := doc.localize(1234.56); !ok || localized != "1.234,56" {
+		t.Errorf("unexpected localized value, want=%s, have=%s", "1.234,56", localized)
+	}
+}
@@ -0,0 +1,91 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// PackageRelationshipsPath is the relative path of the package relationships inside the docx-archive.
+const PackageRelationshipsPath = "_rels/.rels"
+
+// MainPart returns the path of the main document part inside the docx-archive.
+// It is usually DocumentXml, but some generators name it differently (e.g. 'word/document2.xml').
+func (d *Document) MainPart() string {
+	return d.mainPart
+}
+
+// resolveMainPart returns the path of the main document part which is referenced by the officeDocument
+// relationship of the package. If the package does not have such a relationship, DocumentXml is assumed.
+func (d *Document) resolveMainPart() (string, error) {
+	data, err := d.readFile(PackageRelationshipsPath)
+	if err != nil {
+		return DocumentXml, nil
+	}
+	rels := new(relationships)
+	if err := xml.Unmarshal(data, rels); err != nil {
+		return "", fmt.Errorf("unable to parse %s: %s", PackageRelationshipsPath, err)
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type == OfficeDocumentRelationshipType && rel.TargetMode != ExternalTargetMode {
+			// the targets of package relationships are relative to the root of the package
+			return relationshipTarget("", rel.Target), nil
+		}
+	}
+	return DocumentXml, nil
+}
+
+// ensureMainPartReference ensures that the main document part has a relationship of the given type to the given part
+// and that the content types declare the content type of the part. It is used when a part is added to the archive.
+func (d *Document) ensureMainPartReference(part string, relType string, contentType string) error {
+	rels, err := d.readRelationships(d.mainPart)
+	if err != nil {
+		return err
+	}
+	hasRelationship := false
+	for _, rel := range rels {
+		if rel.Type == relType {
+			hasRelationship = true
+			break
+		}
+	}
+	if !hasRelationship {
+		rel := relationship{Type: relType, Target: relativeTarget(d.mainPart, part)}
+		if _, err := d.addRelationship(d.mainPart, rel); err != nil {
+			return err
+		}
+	}
+	return d.ensureContentTypeOverride(part, contentType)
+}
+
+// referencedPart returns the path of the part which is referenced by the main document with the given relationship type
+// (e.g. the style definitions). If the main document does not have such a relationship, the default path is returned.
+func (d *Document) referencedPart(relType string, defaultPath string) (string, error) {
+	rels, err := d.readRelationships(d.mainPart)
+	if err != nil {
+		return "", err
+	}
+	for _, rel := range rels {
+		if rel.Type == relType && rel.TargetMode != ExternalTargetMode {
+			return relationshipTarget(d.mainPart, rel.Target), nil
+		}
+	}
+	return defaultPath, nil
+}
+
+// readOrCreatePart returns the path and the contents of the part which is referenced by the main document with
+// the given relationship type. Minimal documents might not have all parts (e.g. the style definitions),
+// in that case the given empty contents are returned for the default path and the relationship and content type
+// of the part are added. The part itself is added once the caller writes it.
+func (d *Document) readOrCreatePart(relType string, defaultPath string, contentType string, empty string) (string, []byte, error) {
+	part, err := d.referencedPart(relType, defaultPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if data, err := d.readFile(part); err == nil {
+		return part, data, nil
+	}
+	if err := d.ensureMainPartReference(part, relType, contentType); err != nil {
+		return "", nil, err
+	}
+	return part, []byte(xml.Header + empty), nil
+}
@@ -0,0 +1,110 @@
+package docx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDocument_MainPart(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if doc.MainPart() != DocumentXml {
+		t.Errorf("unexpected main part, want=%s, have=%s", DocumentXml, doc.MainPart())
+	}
+}
+
+func TestDocument_MainPart_Renamed(t *testing.T) {
+	doc, err := Open("./test/renamed.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if doc.MainPart() != "word/document2.xml" {
+		t.Errorf("unexpected main part, want=%s, have=%s", "word/document2.xml", doc.MainPart())
+	}
+	if err = doc.ReplaceAll(PlaceholderMap{"key": "REPLACED", "key-with-dash": "DASH"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("writing failed", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("reopening failed", err)
+		return
+	}
+	if written.GetFile(DocumentXml) != nil {
+		t.Errorf("%s must not be added to the archive", DocumentXml)
+	}
+	text := string(written.GetFile("word/document2.xml"))
+	if !strings.Contains(text, "REPLACED") || !strings.Contains(text, "DASH") {
+		t.Error("placeholders of the main part were not replaced")
+	}
+	if !strings.Contains(written.Text(), "Header REPLACED") {
+		t.Error("placeholders of the header were not replaced")
+	}
+}
+
+func TestDocument_MinimalDocument(t *testing.T) {
+	doc, err := Open("./test/minimal.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	// the document neither has numbering definitions, style definitions, settings nor relationships of the main part
+	if err = doc.ReplaceOutline("agenda", []ListNode{{Text: "Welcome"}}, ListBullet); err != nil {
+		t.Error("replacing the outline failed", err)
+	}
+	if err = doc.SetDefaultParagraphSpacing(0, 120); err != nil {
+		t.Error("setting the paragraph spacing failed", err)
+	}
+	if err = doc.SetDefaultTabStop(360); err != nil {
+		t.Error("setting the default tab stop failed", err)
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("writing failed", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open the written document", err)
+		return
+	}
+	defer written.Close()
+
+	for part, relType := range map[string]string{
+		NumberingXml: NumberingRelationshipType,
+		StylesXml:    StylesRelationshipType,
+		SettingsXml:  SettingsRelationshipType,
+	} {
+		if _, err := written.readFile(part); err != nil {
+			t.Errorf("part %s was not created", part)
+		}
+		if referenced, _ := written.referencedPart(relType, ""); referenced != part {
+			t.Errorf("part %s is not referenced by the main document", part)
+		}
+		contentTypes, _ := written.readFile(ContentTypesXml)
+		if !strings.Contains(string(contentTypes), `PartName="/`+part+`"`) {
+			t.Errorf("content type of %s was not declared", part)
+		}
+	}
+	if before, after, _ := written.DefaultParagraphSpacing(); before != 0 || after != 120 {
+		t.Errorf("unexpected paragraph spacing, want=0/120, have=%d/%d", before, after)
+	}
+}
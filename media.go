@@ -0,0 +1,125 @@
+package docx
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// MediaInfo describes a media file (e.g. an image) of the docx-archive.
+type MediaInfo struct {
+	Name        string // Name is the path of the media file inside the docx-archive, e.g. 'word/media/image1.png'
+	ContentType string // ContentType is the content type declared for the media file, empty if there is none
+	Size        int    // Size is the uncompressed size of the media file in bytes
+	Referenced  bool   // Referenced is true if at least one relationship of any part targets the media file
+}
+
+// Media returns all media files of the document, including those added through the Document API, sorted by name.
+// A media file which is not referenced by any relationship is not displayed anywhere and only bloats the document.
+func (d *Document) Media() ([]MediaInfo, error) {
+	referenced, err := d.referencedParts()
+	if err != nil {
+		return nil, err
+	}
+
+	var media []MediaInfo
+	for _, name := range d.partNames() {
+		if !strings.HasPrefix(name, MediaDirectory+"/") {
+			continue
+		}
+		data, err := d.readFile(name)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, MediaInfo{
+			Name:        name,
+			ContentType: d.contentType(name),
+			Size:        len(data),
+			Referenced:  referenced[name],
+		})
+	}
+	return media, nil
+}
+
+// referencedParts returns the paths of all parts which are the target of at least one internal relationship.
+// The relationships of all parts are considered, including those added through the Document API.
+func (d *Document) referencedParts() (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	for _, name := range d.partNames() {
+		part, isRelationships := relationshipsSource(name)
+		if !isRelationships {
+			continue
+		}
+		rels, err := d.readRelationships(part)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range rels {
+			if rel.TargetMode == ExternalTargetMode {
+				continue
+			}
+			referenced[relationshipTarget(part, rel.Target)] = true
+		}
+	}
+	return referenced, nil
+}
+
+// relationshipsSource returns the path of the part to which the relationships part with the given path belongs.
+// It is the inverse of relationshipsPath, the relationships of the package ('_rels/.rels') belong to the empty path.
+// If the path is not a relationships part, false is returned.
+func relationshipsSource(name string) (string, bool) {
+	dir, file := path.Split(name)
+	if path.Base(dir) != "_rels" || !strings.HasSuffix(file, ".rels") {
+		return "", false
+	}
+	part := path.Join(path.Dir(strings.TrimSuffix(dir, "/")), strings.TrimSuffix(file, ".rels"))
+	if part == "." {
+		return "", true
+	}
+	return part, true
+}
+
+// partNames returns the paths of all parts of the document, that is the files of the archive and
+// all parts added through the Document API, sorted by name.
+func (d *Document) partNames() []string {
+	names := make(map[string]bool)
+	for _, file := range d.zipFile.File {
+		if !d.removedParts[file.Name] {
+			names[file.Name] = true
+		}
+	}
+	for name := range d.modifiedParts {
+		names[name] = true
+	}
+
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// RemoveUnusedMedia removes all media files which are not referenced by any relationship and returns their paths.
+// Media files are left unreferenced e.g. by replacing or removing the content which displayed them.
+// Only files inside MediaDirectory are removed, together with their content type overrides.
+// The default content types of the extensions are kept.
+func (d *Document) RemoveUnusedMedia() ([]string, error) {
+	media, err := d.Media()
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, m := range media {
+		if m.Referenced {
+			continue
+		}
+		if err := d.removeContentTypeOverride(m.Name); err != nil {
+			return nil, err
+		}
+		d.removePart(m.Name)
+		removed = append(removed, m.Name)
+	}
+	return removed, nil
+}
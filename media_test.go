@@ -0,0 +1,114 @@
+package docx
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocument_Media(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	media, err := doc.Media()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(media) != 1 {
+		t.Errorf("unexpected media count, want=%d, have=%d", 1, len(media))
+		return
+	}
+	expected := MediaInfo{Name: "word/media/image1.jpg", ContentType: "image/jpeg", Size: 160012, Referenced: true}
+	if media[0] != expected {
+		t.Errorf("unexpected media, want=%+v, have=%+v", expected, media[0])
+	}
+
+	// added media files are listed as well, they are unreferenced until a relationship targets them
+	name := doc.addMedia("png", testImage(t, 1, 1))
+	media, err = doc.Media()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(media) != 2 || media[1].Name != name || media[1].Referenced {
+		t.Errorf("unexpected added media %+v", media)
+	}
+}
+
+func TestRelationshipsSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{name: "word/_rels/document.xml.rels", expected: "word/document.xml", ok: true},
+		{name: "_rels/.rels", expected: "", ok: true},
+		{name: "word/document.xml", ok: false},
+	}
+	for _, tt := range tests {
+		part, ok := relationshipsSource(tt.name)
+		if part != tt.expected || ok != tt.ok {
+			t.Errorf("unexpected source of %s, want=%s (%v), have=%s (%v)", tt.name, tt.expected, tt.ok, part, ok)
+		}
+	}
+}
+
+func TestDocument_RemoveUnusedMedia(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	// orphan the image of the template by removing its relationship
+	relsPath := relationshipsPath(DocumentXml)
+	rels, err := doc.readFile(relsPath)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	rels = []byte(strings.Replace(string(rels), `<Relationship Id="rId6" Type="`+ImageRelationshipType+`" Target="media/image1.jpg"/>`, "", 1))
+	doc.writePart(relsPath, rels)
+
+	unused := doc.addMedia("png", testImage(t, 1, 1))
+	if err = doc.ReplaceImage("key.with.dots", ImageData{Data: testImage(t, 2, 2)}); err != nil {
+		t.Error("replacing image failed", err)
+		return
+	}
+
+	removed, err := doc.RemoveUnusedMedia()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []string{"word/media/image1.jpg", unused}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Errorf("unexpected removed media, want=%v, have=%v", expected, removed)
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open written document", err)
+		return
+	}
+	media, err := written.Media()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(media) != 1 || !media[0].Referenced {
+		t.Errorf("only the referenced image must be left, have=%+v", media)
+	}
+}
@@ -0,0 +1,307 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	// BodyElementName is the local name of the XML tag of the document body (<w:body>)
+	BodyElementName = "body"
+	// SectionPropertiesElementName is the local name of the XML tag of section properties (<w:sectPr>)
+	SectionPropertiesElementName = "sectPr"
+	// pageBreakParagraph is a paragraph which only contains a page break
+	pageBreakParagraph = `<w:p><w:r><w:br w:type="page"/></w:r></w:p>`
+)
+
+var (
+	// relationshipReferenceRegex matches all attributes of the relationships namespace, each of them references
+	// a relationship by its id, e.g. r:id="rId5" or r:dm="rId6" of SmartArt diagrams
+	relationshipReferenceRegex = regexp.MustCompile(`(\sr:[A-Za-z]+=")([^"]*)(")`)
+	// namespaceDeclarationRegex matches the namespace declarations of an element, e.g. xmlns:w="..."
+	namespaceDeclarationRegex = regexp.MustCompile(`\sxmlns:([A-Za-z0-9]+)="[^"]*"`)
+)
+
+// MergeDocuments returns a new document which contains the bodies of all given documents in order.
+// The first document is the base of the merged document, its styles, headers, footers and section properties are used.
+// The relationships referenced by the bodies of the other documents (e.g. hyperlinks and images) are copied and
+// their ids are rewritten, so they cannot collide with the relationships of the base document.
+// Like ReplaceWithDocument, the styles and numbering definitions referenced by the merged bodies are copied as well.
+// If pageBreak is set, every merged document starts on a new page.
+// None of the given documents is modified.
+func MergeDocuments(pageBreak bool, docs ...*Document) (*Document, error) {
+	if len(docs) == 0 {
+		return nil, errors.New("no documents to merge")
+	}
+
+	var buf bytes.Buffer
+	if err := docs[0].Write(&buf); err != nil {
+		return nil, fmt.Errorf("unable to copy the base document: %s", err)
+	}
+	merged, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy the base document: %s", err)
+	}
+
+	// the merged document is parsed once after all bodies are appended, not once per body
+	for _, doc := range docs[1:] {
+		if err := merged.appendDocument(doc, pageBreak); err != nil {
+			return nil, err
+		}
+	}
+	if err := merged.parseFile(merged.mainPart); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// Append appends the body of the other document to the body of the document, e.g. to assemble a document
+// from rendered chunks one after another. Unlike MergeDocuments, the document is modified in place,
+// which avoids copying it for every chunk. Like MergeDocuments, the relationships referenced by the appended body
+// (e.g. hyperlinks and images) are copied and their ids are rewritten, and the referenced styles and numbering
+// definitions are copied. The body-level section properties of the other document are dropped. If pageBreak is set, the appended body starts on a new page.
+// The other document is not modified.
+//
+// The document stays replaceable after every call, therefore every call parses the whole main document again.
+// Appending n chunks one by one costs O(n²) in the size of the chunks, to merge many documents at once
+// use MergeDocuments, which parses the merged document only once.
+func (d *Document) Append(other *Document, pageBreak bool) error {
+	if err := d.appendDocument(other, pageBreak); err != nil {
+		return err
+	}
+	return d.parseFile(d.mainPart)
+}
+
+// appendDocument appends the body of the other document to the body of the main document.
+// The body-level section properties of the other document are dropped.
+// The main document is not parsed again, the caller has to call parseFile once all documents are appended.
+func (d *Document) appendDocument(other *Document, pageBreak bool) error {
+	content, err := bodyContent(other.files[other.mainPart])
+	if err != nil {
+		return err
+	}
+	content, err = d.importRelationships(other, content)
+	if err != nil {
+		return err
+	}
+	content, err = d.importDefinitions(other, content)
+	if err != nil {
+		return err
+	}
+	if pageBreak {
+		content = append([]byte(pageBreakParagraph), content...)
+	}
+
+	documentXml := mergeNamespaces(d.files[d.mainPart], other.files[other.mainPart])
+	insertPos, err := bodyInsertPos(documentXml)
+	if err != nil {
+		return err
+	}
+	d.files[d.mainPart] = splice(documentXml, insertPos, insertPos, string(content))
+	return nil
+}
+
+// importRelationships copies all relationships of the main document of other which are referenced inside content
+// into the relationships of the main document and returns content with the references rewritten to the new ids.
+// External targets (e.g. hyperlinks) are copied as they are, images are copied into the media files and all other
+// internal targets (e.g. charts) are copied under a new name. An error is returned if a target cannot be copied.
+func (d *Document) importRelationships(other *Document, content []byte) ([]byte, error) {
+	rels, err := other.readRelationships(other.mainPart)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[string]relationship)
+	for _, rel := range rels {
+		byId[rel.ID] = rel
+	}
+
+	remapped := make(map[string]string)
+	var importErr error
+	content = relationshipReferenceRegex.ReplaceAllFunc(content, func(match []byte) []byte {
+		groups := relationshipReferenceRegex.FindSubmatch(match)
+		id := string(groups[2])
+		if newId, ok := remapped[id]; ok {
+			return []byte(string(groups[1]) + newId + string(groups[3]))
+		}
+		rel, ok := byId[id]
+		if !ok || importErr != nil {
+			return match
+		}
+
+		newId, err := d.importRelationship(other, rel)
+		if err != nil {
+			importErr = err
+			return match
+		}
+		remapped[id] = newId
+		return []byte(string(groups[1]) + newId + string(groups[3]))
+	})
+	if importErr != nil {
+		return nil, importErr
+	}
+	return content, nil
+}
+
+// importRelationship copies the given relationship of the main document of other into the main document
+// and returns the new id.
+func (d *Document) importRelationship(other *Document, rel relationship) (string, error) {
+	if rel.TargetMode == ExternalTargetMode {
+		return d.addRelationship(d.mainPart, rel)
+	}
+	if rel.Type != ImageRelationshipType {
+		part, err := d.importPart(other, relationshipTarget(other.mainPart, rel.Target))
+		if err != nil {
+			return "", fmt.Errorf("unable to merge relationship %s of type %s: %s", rel.ID, rel.Type, err)
+		}
+		return d.addRelationship(d.mainPart, relationship{Type: rel.Type, Target: relativeTarget(d.mainPart, part)})
+	}
+
+	media := relationshipTarget(other.mainPart, rel.Target)
+	data, err := other.readFile(media)
+	if err != nil {
+		return "", fmt.Errorf("unable to merge image: %s", err)
+	}
+	extension := strings.TrimPrefix(path.Ext(media), ".")
+	contentType := other.defaultContentType(extension)
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	if err := d.ensureDefaultContentType(extension, contentType); err != nil {
+		return "", err
+	}
+	newMedia := d.addMedia(extension, data)
+	return d.addRelationship(d.mainPart, relationship{Type: rel.Type, Target: relativeTarget(d.mainPart, newMedia)})
+}
+
+// importPart copies the given part of other into the document and returns its new name, which is the name of the
+// part with the first free number, e.g. 'word/charts/chart2.xml' for 'word/charts/chart1.xml'.
+// The content type of the part is copied as well. Parts which have relationships on their own cannot be copied.
+func (d *Document) importPart(other *Document, part string) (string, error) {
+	data, err := other.readFile(part)
+	if err != nil {
+		return "", err
+	}
+	if rels, err := other.readRelationships(part); err != nil || len(rels) > 0 {
+		return "", fmt.Errorf("%s references other parts", part)
+	}
+
+	existing := make(map[string]bool)
+	for _, name := range d.partNames() {
+		existing[name] = true
+	}
+	extension := path.Ext(part)
+	base := strings.TrimRight(strings.TrimSuffix(part, extension), "0123456789")
+	var name string
+	for i := 1; ; i++ {
+		name = fmt.Sprintf("%s%d%s", base, i, extension)
+		if !existing[name] {
+			break
+		}
+	}
+
+	if contentType := other.contentType(part); contentType != "" {
+		if err := d.ensureContentTypeOverride(name, contentType); err != nil {
+			return "", err
+		}
+	}
+	d.writePart(name, data)
+	return name, nil
+}
+
+// bodyContent returns the content of the body (<w:body>) without the body-level section properties.
+func bodyContent(data []byte) ([]byte, error) {
+	start, end, err := bodyContentPos(data)
+	if err != nil {
+		return nil, err
+	}
+	content := data[start:end]
+	if sectPr := bodySectionProperties(content); sectPr != nil {
+		content = content[:sectPr.Start]
+	}
+	return append([]byte{}, content...), nil
+}
+
+// bodyInsertPos returns the position at which content is appended to the body, that is
+// before the body-level section properties or at the end of the body.
+func bodyInsertPos(data []byte) (int, error) {
+	start, end, err := bodyContentPos(data)
+	if err != nil {
+		return 0, err
+	}
+	if sectPr := bodySectionProperties(data[start:end]); sectPr != nil {
+		return int(start + sectPr.Start), nil
+	}
+	return int(end), nil
+}
+
+// bodyContentPos returns the start and end position of the content of the body (<w:body>).
+func bodyContentPos(data []byte) (int64, int64, error) {
+	bodies, err := findElements(data, BodyElementName)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(bodies) == 0 {
+		return 0, 0, fmt.Errorf("missing %s element", BodyElementName)
+	}
+	body := bodies[0]
+	start := body.Start + int64(bytes.IndexByte(data[body.Start:], '>')) + 1
+	end := body.Start + int64(bytes.LastIndex(data[body.Start:body.End], []byte("</")))
+	return start, end, nil
+}
+
+// bodySectionProperties returns the position of the body-level section properties inside the given body content,
+// which are the last element of the body. Section properties of paragraphs are ignored.
+func bodySectionProperties(content []byte) *Position {
+	elements, err := findElements(content, SectionPropertiesElementName)
+	if err != nil || len(elements) == 0 {
+		return nil
+	}
+	last := elements[len(elements)-1]
+	if len(bytes.TrimSpace(content[last.End:])) != 0 {
+		return nil
+	}
+	return &last
+}
+
+// mergeNamespaces returns dst with all namespace declarations of the root element of src added to the root
+// element of dst, which are not declared there yet.
+func mergeNamespaces(dst []byte, src []byte) []byte {
+	rootTag := func(data []byte) (int, int) {
+		start := bytes.Index(data, []byte("<w:document"))
+		if start < 0 {
+			return -1, -1
+		}
+		return start, start + bytes.IndexByte(data[start:], '>')
+	}
+	dstStart, dstEnd := rootTag(dst)
+	srcStart, srcEnd := rootTag(src)
+	if dstStart < 0 || srcStart < 0 {
+		return dst
+	}
+
+	declared := make(map[string]bool)
+	for _, match := range namespaceDeclarationRegex.FindAllSubmatch(dst[dstStart:dstEnd], -1) {
+		declared[string(match[1])] = true
+	}
+	var missing strings.Builder
+	for _, match := range namespaceDeclarationRegex.FindAllSubmatch(src[srcStart:srcEnd], -1) {
+		if !declared[string(match[1])] {
+			missing.Write(match[0])
+			declared[string(match[1])] = true
+		}
+	}
+	if missing.Len() == 0 {
+		return dst
+	}
+
+	insertPos := dstEnd
+	if dst[insertPos-1] == '/' {
+		insertPos--
+	}
+	return splice(dst, insertPos, insertPos, missing.String())
+}
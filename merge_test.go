@@ -0,0 +1,333 @@
+package docx
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMergeDocuments_Hyperlinks(t *testing.T) {
+	a, err := Open("./test/hyperlink_a.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer a.Close()
+	b, err := Open("./test/hyperlink_b.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer b.Close()
+
+	merged, err := MergeDocuments(true, a, b)
+	if err != nil {
+		t.Error("merging failed", err)
+		return
+	}
+
+	// write and re-open the merged document to ensure that all relationships are persisted
+	var buf bytes.Buffer
+	if err = merged.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	merged, err = OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open merged document", err)
+		return
+	}
+
+	rels, err := merged.readRelationships(DocumentXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels {
+		targets[rel.ID] = rel.Target
+	}
+
+	documentXml := string(merged.GetFile(DocumentXml))
+	hyperlinks := regexp.MustCompile(`<w:hyperlink r:id="([^"]+)"><w:r><w:t>(Link [ab])</w:t>`).FindAllStringSubmatch(documentXml, -1)
+	if len(hyperlinks) != 2 {
+		t.Errorf("unexpected hyperlink count, want=%d, have=%d", 2, len(hyperlinks))
+		return
+	}
+	expectedTargets := map[string]string{"Link a": "https://a.example.com/", "Link b": "https://b.example.com/"}
+	for _, hyperlink := range hyperlinks {
+		if target := targets[hyperlink[1]]; target != expectedTargets[hyperlink[2]] {
+			t.Errorf("%s resolves to %s, expected %s", hyperlink[2], target, expectedTargets[hyperlink[2]])
+		}
+	}
+
+	if strings.Count(documentXml, "<w:sectPr>") != 1 {
+		t.Error("merged document must only contain the section properties of the base document")
+	}
+	if !strings.Contains(documentXml, pageBreakParagraph+"<w:p><w:r><w:t xml:space=\"preserve\">Document b: ") {
+		t.Error("page break was not inserted before the merged document")
+	}
+	if !strings.Contains(string(a.GetFile(DocumentXml)), "Link a") || strings.Contains(string(a.GetFile(DocumentXml)), "Link b") {
+		t.Error("base document was modified")
+	}
+}
+
+func TestMergeDocuments_ParsedOnce(t *testing.T) {
+	a, err := Open("./test/hyperlink_a.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer a.Close()
+	b, err := Open("./test/hyperlink_b.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer b.Close()
+
+	merged, err := MergeDocuments(false, a, b, b)
+	if err != nil {
+		t.Error("merging failed", err)
+		return
+	}
+
+	// the bodies are appended without parsing, the merged document must be parsed once at the end
+	documentXml := merged.GetFile(DocumentXml)
+	if strings.Count(string(documentXml), "Link b") != 2 {
+		t.Error("expected both bodies of document b to be merged")
+	}
+	if !bytes.Equal(merged.fileReplacers[DocumentXml].document, documentXml) {
+		t.Error("merged document was not parsed after merging")
+	}
+}
+
+func TestDocument_Append(t *testing.T) {
+	doc, err := Open("./test/hyperlink_a.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	chunk, err := Open("./test/hyperlink_b.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer chunk.Close()
+	chunkXml := string(chunk.GetFile(DocumentXml))
+
+	for i := 0; i < 3; i++ {
+		if err = doc.Append(chunk, i > 0); err != nil {
+			t.Error("appending failed", err)
+			return
+		}
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if count := strings.Count(documentXml, "<w:t>Link b</w:t>"); count != 3 {
+		t.Errorf("unexpected count of appended bodies, want=%d, have=%d", 3, count)
+	}
+	if count := strings.Count(documentXml, pageBreakParagraph); count != 2 {
+		t.Errorf("unexpected page break count, want=%d, have=%d", 2, count)
+	}
+	if strings.Index(documentXml, "Link a") > strings.Index(documentXml, "Link b") {
+		t.Error("the body must be appended after the existing content")
+	}
+
+	// every appended hyperlink references a relationship of its own
+	ids := regexp.MustCompile(`<w:hyperlink r:id="([^"]+)"><w:r><w:t>Link b</w:t>`).FindAllStringSubmatch(documentXml, -1)
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		seen[id[1]] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected distinct relationship ids for the appended hyperlinks, have=%v", ids)
+	}
+	if string(chunk.GetFile(DocumentXml)) != chunkXml {
+		t.Error("the appended document must not be modified")
+	}
+}
+
+// chartDocument opens test/chart.docx with a body which displays its chart.
+func chartDocument(t *testing.T) *Document {
+	doc, err := Open("./test/chart.docx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	documentXml := strings.Replace(string(doc.GetFile(DocumentXml)), "<w:sectPr/>",
+		`<w:p><w:r><c:chart xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" r:id="rId20"/></w:r></w:p><w:sectPr/>`, 1)
+	if err = doc.SetFile(DocumentXml, []byte(documentXml)); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDocument_Append_Chart(t *testing.T) {
+	doc, err := Open("./test/chart.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	chunk := chartDocument(t)
+	defer chunk.Close()
+
+	if err = doc.Append(chunk, false); err != nil {
+		t.Error("appending failed", err)
+		return
+	}
+
+	id := regexp.MustCompile(`<c:chart [^>]*r:id="([^"]+)"`).FindStringSubmatch(string(doc.GetFile(DocumentXml)))
+	if id == nil || id[1] == "rId20" {
+		t.Fatalf("the chart must reference a new relationship, have=%v", id)
+	}
+	rels, err := doc.readRelationships(DocumentXml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var target string
+	for _, rel := range rels {
+		if rel.ID == id[1] {
+			target = relationshipTarget(DocumentXml, rel.Target)
+		}
+	}
+	if target != "word/charts/chart2.xml" {
+		t.Fatalf("unexpected chart part, want=%s, have=%s", "word/charts/chart2.xml", target)
+	}
+	copied, err := doc.readFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original, _ := chunk.readFile("word/charts/chart1.xml")
+	if string(copied) != string(original) {
+		t.Error("the chart part was not copied")
+	}
+	if contentType := doc.contentType(target); contentType != chunk.contentType("word/charts/chart1.xml") {
+		t.Errorf("unexpected content type of the copied chart: %s", contentType)
+	}
+}
+
+func TestDocument_Append_UnsupportedRelationship(t *testing.T) {
+	doc, err := Open("./test/chart.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	chunk := chartDocument(t)
+	defer chunk.Close()
+	if _, err = chunk.addRelationship("word/charts/chart1.xml", relationship{Type: ImageRelationshipType, Target: "../media/image1.png"}); err != nil {
+		t.Fatal(err)
+	}
+
+	before := string(doc.GetFile(DocumentXml))
+	if err = doc.Append(chunk, false); err == nil {
+		t.Error("expected an error for a chart with relationships")
+	}
+	if string(doc.GetFile(DocumentXml)) != before {
+		t.Error("the document must not be modified if appending fails")
+	}
+}
+
+func TestDocument_Append_Diagram(t *testing.T) {
+	doc, err := Open("./test/hyperlink_a.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	chunk, err := Open("./test/diagram.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer chunk.Close()
+	rId, err := chunk.addRelationship(DocumentXml, relationship{
+		Type:   "http://schemas.openxmlformats.org/officeDocument/2006/relationships/diagramData",
+		Target: "diagrams/data1.xml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunkXml := chunk.GetFile(DocumentXml)
+	insertPos, err := bodyInsertPos(chunkXml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	relIds := `<w:p><w:r><dgm:relIds xmlns:dgm="http://schemas.openxmlformats.org/drawingml/2006/diagram" r:dm="` + rId + `"/></w:r></w:p>`
+	if err = chunk.SetFile(DocumentXml, splice(chunkXml, insertPos, insertPos, relIds)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = doc.Append(chunk, false); err != nil {
+		t.Error("appending failed", err)
+		return
+	}
+
+	// the diagram must not reference a relationship of the base document
+	id := regexp.MustCompile(`<dgm:relIds [^>]*r:dm="([^"]+)"`).FindStringSubmatch(string(doc.GetFile(DocumentXml)))
+	if id == nil {
+		t.Fatal("the diagram was not appended")
+	}
+	rels, err := doc.readRelationships(DocumentXml)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var target string
+	for _, rel := range rels {
+		if rel.ID == id[1] {
+			target = relationshipTarget(DocumentXml, rel.Target)
+		}
+	}
+	copied, err := doc.readFile(target)
+	if err != nil {
+		t.Fatalf("unable to read the diagram data %s: %s", target, err)
+	}
+	original, _ := chunk.readFile("word/diagrams/data1.xml")
+	if string(copied) != string(original) {
+		t.Error("the diagram data was not copied")
+	}
+}
+
+func TestDocument_Append_Definitions(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	chunk, err := Open("./test/sub_styles.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer chunk.Close()
+
+	// the document has numbering definitions of its own
+	if err = doc.ReplaceOutline("key", []ListNode{{Text: "Agenda"}}, ListDecimal); err != nil {
+		t.Error("replacing with outline failed", err)
+		return
+	}
+	if err = doc.Append(chunk, false); err != nil {
+		t.Error("appending failed", err)
+		return
+	}
+
+	styles, _ := doc.readFile(StylesXml)
+	for _, id := range []string{"Clause", "ClauseBase"} {
+		if !strings.Contains(string(styles), `w:styleId="`+id+`"`) {
+			t.Errorf("style %s was not copied", id)
+		}
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	item := documentXml[:strings.Index(documentXml, "Numbered item")]
+	match := regexp.MustCompile(`<w:numId w:val="(\d+)"/>`).FindStringSubmatch(item[strings.LastIndex(item, "<w:p>"):])
+	if match == nil {
+		t.Fatal("missing numbering reference of the appended list")
+	}
+	if match[1] == "1" {
+		t.Error("the numbering instance of the appended document must get a new id")
+	}
+}
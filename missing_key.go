@@ -0,0 +1,39 @@
+package docx
+
+// MissingKeyFunc is called for every placeholder whose key is not part of the PlaceholderMap.
+// The position is the absolute position of the placeholder inside its file.
+// If it returns true, the placeholder is replaced with the returned value, otherwise it is left untouched.
+type MissingKeyFunc func(key string, pos Position) (string, bool)
+
+// SetOnMissingKey sets the MissingKeyFunc which is called during ReplaceAll and ReplaceEverywhere for every
+// placeholder without an entry in the PlaceholderMap. It can be used to collect the missing keys or to
+// supply values lazily. Passing nil removes the callback.
+func (d *Document) SetOnMissingKey(fn MissingKeyFunc) {
+	d.onMissingKey = fn
+}
+
+// replaceMissing passes all placeholders of the replacer which are not yet replaced and whose key is not part of
+// the placeholderMap to the MissingKeyFunc, replacing those for which it returns a value.
+// It returns the number of replaced placeholders.
+func (d *Document) replaceMissing(replacer *Replacer, placeholderMap PlaceholderMap) (int, error) {
+	if d.onMissingKey == nil {
+		return 0, nil
+	}
+
+	replaced := 0
+	for _, placeholder := range replacer.Unreplaced() {
+		key := RemovePlaceholderDelimiter(placeholder.Text(replacer.document))
+		if _, exists := placeholderMap[key]; exists {
+			continue
+		}
+		value, ok := d.onMissingKey(key, Position{Start: placeholder.StartPos(), End: placeholder.EndPos()})
+		if !ok {
+			continue
+		}
+		if err := replacer.ReplacePlaceholder(placeholder, d.plainValue(key, value)); err != nil {
+			return replaced, err
+		}
+		replaced++
+	}
+	return replaced, nil
+}
@@ -0,0 +1,74 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetOnMissingKey(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var missing []string
+	doc.SetOnMissingKey(func(key string, pos Position) (string, bool) {
+		if pos.End <= pos.Start {
+			t.Errorf("invalid position of %s: %v", key, pos)
+		}
+		missing = append(missing, key)
+		if key == "key.with.dots" {
+			return "LAZY", true
+		}
+		return "", false
+	})
+
+	err = doc.ReplaceAll(PlaceholderMap{"key": "value"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	for _, key := range []string{"key.with.dots", "key-with-dash", "undefined_placholder"} {
+		found := false
+		for _, m := range missing {
+			found = found || m == key
+		}
+		if !found {
+			t.Errorf("callback was not called for %s", key)
+		}
+	}
+	for _, m := range missing {
+		if m == "key" {
+			t.Error("callback was called for a key of the map")
+		}
+	}
+
+	text := doc.Text()
+	if !strings.Contains(text, "LAZY") || strings.Contains(text, "{key.with.dots}") {
+		t.Error("value returned by the callback was not inserted")
+	}
+	if !strings.Contains(text, "{key-with-dash}") {
+		t.Error("placeholder without a value was replaced")
+	}
+}
+
+func TestDocument_SetOnMissingKey_Replace(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetOnMissingKey(func(key string, pos Position) (string, bool) {
+		t.Errorf("callback was called for %s by Replace", key)
+		return "", false
+	})
+
+	if err = doc.Replace("key", "value"); err != nil {
+		t.Error("replacing failed", err)
+	}
+}
@@ -0,0 +1,38 @@
+package docx
+
+import "strings"
+
+// NBSP is the non-breaking space (U+00A0). Words separated by it are kept on the same line, e.g. 'Dr.' + NBSP + 'Smith'.
+// Values may contain it like any other character, it is inserted into the document as it is.
+const NBSP = "\u00A0"
+
+// SetNonBreakingKeys sets the keys whose values keep their words together: all regular spaces of the values are
+// converted into non-breaking spaces (see NBSP), e.g. to avoid awkward wraps in multi-word names and addresses.
+// The conversion is applied last, so it includes the spaces of the slice separator (see SetSliceSeparator).
+// Symbols and byte values are not converted. Calling it without keys disables the conversion, which is the default.
+func (d *Document) SetNonBreakingKeys(keys ...string) {
+	d.nonBreakingKeys = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		d.nonBreakingKeys[key] = true
+	}
+}
+
+// applyNonBreaking converts all spaces of the text of the value into non-breaking spaces.
+// The value must either be a string, a FormattedText or a []FormattedText, other values are returned unchanged.
+func applyNonBreaking(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return strings.Replace(v, " ", NBSP, -1)
+	case FormattedText:
+		v.Text = strings.Replace(v.Text, " ", NBSP, -1)
+		return v
+	case []FormattedText:
+		segments := make([]FormattedText, len(v))
+		for i, segment := range v {
+			segment.Text = strings.Replace(segment.Text, " ", NBSP, -1)
+			segments[i] = segment
+		}
+		return segments
+	}
+	return value
+}
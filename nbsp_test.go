@@ -0,0 +1,51 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_NonBreakingSpaces(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetNonBreakingKeys("key-with-dash", "key_with_underscore")
+	err = doc.ReplaceAll(PlaceholderMap{
+		"key":                 "Dr." + NBSP + "Jane Doe",
+		"key-with-dash":       "Main Street 1",
+		"key_with_underscore": []string{"New York", "NY"},
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{
+		// the non-breaking space of the value is inserted unaltered, regular spaces are kept
+		"Dr.\u00a0Jane Doe",
+		"Main\u00a0Street\u00a01",
+		"New\u00a0York,\u00a0NY",
+	} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %q inside the document", expected)
+		}
+	}
+	if strings.Contains(documentXml, "&#160;") || strings.Contains(documentXml, "&nbsp;") {
+		t.Error("non-breaking spaces must not be escaped")
+	}
+}
+
+func TestApplyNonBreaking(t *testing.T) {
+	segments := applyNonBreaking([]FormattedText{{Text: "a b"}, {Text: " c"}}).([]FormattedText)
+	if segments[0].Text != "a\u00a0b" || segments[1].Text != "\u00a0c" {
+		t.Errorf("segments were not converted: %v", segments)
+	}
+	if value := applyNonBreaking(Symbol{Text: "a b"}); value.(Symbol).Text != "a b" {
+		t.Error("symbols must not be converted")
+	}
+}
@@ -0,0 +1,186 @@
+package docx
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// NumberingXml is the relative path of the numbering definitions inside the docx-archive.
+	NumberingXml = "word/numbering.xml"
+	// NumberingRelationshipType is the type of the relationship which references the numbering definitions.
+	NumberingRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering"
+	// numberingContentType is the content type of the numbering definitions.
+	numberingContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"
+
+	// maxListLevels is the number of levels a numbering definition supports
+	maxListLevels = 9
+)
+
+var (
+	// abstractNumIdRegex matches the ids of all abstract numbering definitions (<w:abstractNum>) and captures them
+	abstractNumIdRegex = regexp.MustCompile(`<w:abstractNum\s[^>]*w:abstractNumId="(\d+)"`)
+	// numIdRegex matches the ids of all numbering instances (<w:num>) and captures them
+	numIdRegex = regexp.MustCompile(`<w:num\s[^>]*w:numId="(\d+)"`)
+)
+
+// ListNode is an entry of a multi-level list, see ReplaceOutline.
+// The children are rendered one level below the entry.
+type ListNode struct {
+	Text     string
+	Children []ListNode
+}
+
+// ListStyle defines how the levels of a multi-level list are numbered.
+type ListStyle int
+
+const (
+	// ListDecimal numbers the levels alternating with decimal numbers, lowercase letters and lowercase roman numerals
+	// (e.g. '1.', 'a.', 'i.').
+	ListDecimal ListStyle = iota
+	// ListBullet marks the levels alternating with bullets, circles and squares.
+	ListBullet
+)
+
+// ReplaceOutline replaces every paragraph of the main document which contains the placeholder of the key
+// by a multi-level list of the given nodes, e.g. to render an agenda. Every node becomes a paragraph of its own,
+// its children are indented by one level. Up to 9 levels are supported.
+//
+// A new numbering definition of the given style is added to word/numbering.xml, which is created if the document
+// does not have numbering definitions yet. Every replaced paragraph starts its own list.
+// All other content of these paragraphs is removed, a placeholder should therefore be the only content of its paragraph.
+// If the key does not exist in the main document, ErrPlaceholderNotFound is returned.
+func (d *Document) ReplaceOutline(key string, root []ListNode, style ListStyle) error {
+	if depth := listDepth(root); depth > maxListLevels {
+		return fmt.Errorf("outline of %s is too deep, want at most %d levels, have %d", key, maxListLevels, depth)
+	}
+
+	replacer := d.fileReplacers[d.mainPart]
+	data := replacer.document
+
+	targets, err := placeholderParagraphs(replacer, key)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return ErrPlaceholderNotFound
+	}
+
+	numberingPart, numbering, err := d.readNumbering()
+	if err != nil {
+		return err
+	}
+	abstractNumId := nextId(numbering, abstractNumIdRegex)
+	numbering, err = insertNumbering(numbering, abstractNum(abstractNumId, style), "")
+	if err != nil {
+		return err
+	}
+
+	// replace from the end, so the positions of the preceding paragraphs stay valid
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Start > targets[j].Start
+	})
+	for _, target := range targets {
+		numId := nextId(numbering, numIdRegex)
+		num := fmt.Sprintf(`<w:num w:numId="%d"><w:abstractNumId w:val="%d"/></w:num>`, numId, abstractNumId)
+		numbering, err = insertNumbering(numbering, "", num)
+		if err != nil {
+			return err
+		}
+
+		var paragraphs strings.Builder
+		writeListParagraphs(&paragraphs, root, 0, numId)
+		data = splice(data, int(target.Start), int(target.End), d.applyInsertParagraphStyle(paragraphs.String()))
+	}
+
+	d.writePart(numberingPart, numbering)
+	d.files[d.mainPart] = data
+	return d.parseFile(d.mainPart)
+}
+
+// listDepth returns the number of levels of the given nodes.
+func listDepth(nodes []ListNode) int {
+	depth := 0
+	for _, node := range nodes {
+		if childDepth := listDepth(node.Children) + 1; childDepth > depth {
+			depth = childDepth
+		}
+	}
+	return depth
+}
+
+// writeListParagraphs writes a paragraph for each of the nodes and their children, depth first.
+func writeListParagraphs(builder *strings.Builder, nodes []ListNode, level int, numId int) {
+	for _, node := range nodes {
+		builder.WriteString(fmt.Sprintf(`<w:p><w:pPr><w:numPr><w:ilvl w:val="%d"/><w:numId w:val="%d"/></w:numPr></w:pPr>`, level, numId))
+		builder.WriteString(`<w:r><w:t xml:space="preserve">` + escapeTextValue(node.Text, `<w:t xml:space="preserve">`) + `</w:t></w:r></w:p>`)
+		writeListParagraphs(builder, node.Children, level+1, numId)
+	}
+}
+
+// readNumbering returns the path and the contents of the numbering definitions.
+// If the document does not have numbering definitions yet, an empty part is returned and its relationship
+// and content type are added.
+func (d *Document) readNumbering() (string, []byte, error) {
+	return d.readOrCreatePart(NumberingRelationshipType, NumberingXml, numberingContentType,
+		`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"></w:numbering>`)
+}
+
+// nextId returns the id following the highest id captured by the regex, or 1 if there is none.
+func nextId(data []byte, idRegex *regexp.Regexp) int {
+	next := 1
+	for _, match := range idRegex.FindAllSubmatch(data, -1) {
+		if id, _ := strconv.Atoi(string(match[1])); id >= next {
+			next = id + 1
+		}
+	}
+	return next
+}
+
+// insertNumbering inserts the given abstract numbering definition and numbering instance into the numbering definitions.
+// The schema requires all abstract numbering definitions to precede the numbering instances,
+// the abstract definition is therefore inserted before the first instance. Both may be empty.
+func insertNumbering(numbering []byte, abstract string, num string) ([]byte, error) {
+	closeTag := strings.LastIndex(string(numbering), "</w:numbering>")
+	if closeTag < 0 {
+		return nil, fmt.Errorf("unable to modify %s: missing root element", NumberingXml)
+	}
+	numbering = splice(numbering, closeTag, closeTag, num)
+
+	if abstract != "" {
+		pos := closeTag
+		if loc := numIdRegex.FindIndex(numbering); loc != nil {
+			pos = loc[0]
+		}
+		// other elements following the abstract definitions (e.g. <w:numIdMacAtCleanup>) are kept behind them
+		if first := strings.Index(string(numbering), "<w:numIdMacAtCleanup"); first >= 0 && first < pos {
+			pos = first
+		}
+		numbering = splice(numbering, pos, pos, abstract)
+	}
+	return numbering, nil
+}
+
+// abstractNum returns the abstract numbering definition (<w:abstractNum>) of all levels of the given style.
+func abstractNum(id int, style ListStyle) string {
+	var levels strings.Builder
+	for level := 0; level < maxListLevels; level++ {
+		format, text := listLevelFormat(style, level)
+		indent := 720 * (level + 1)
+		levels.WriteString(fmt.Sprintf(`<w:lvl w:ilvl="%d"><w:start w:val="1"/><w:numFmt w:val="%s"/><w:lvlText w:val="%s"/>`+
+			`<w:lvlJc w:val="left"/><w:pPr><w:ind w:left="%d" w:hanging="360"/></w:pPr></w:lvl>`, level, format, text, indent))
+	}
+	return fmt.Sprintf(`<w:abstractNum w:abstractNumId="%d"><w:multiLevelType w:val="hybridMultilevel"/>%s</w:abstractNum>`,
+		id, levels.String())
+}
+
+// listLevelFormat returns the number format and the level text of the given level of the style.
+func listLevelFormat(style ListStyle, level int) (format string, text string) {
+	if style == ListBullet {
+		return "bullet", []string{"•", "◦", "▪"}[level%3]
+	}
+	return []string{"decimal", "lowerLetter", "lowerRoman"}[level%3], fmt.Sprintf("%%%d.", level+1)
+}
@@ -0,0 +1,92 @@
+package docx
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceOutline(t *testing.T) {
+	doc, err := Open("./test/outline.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	agenda := []ListNode{
+		{Text: "Welcome", Children: []ListNode{
+			{Text: "Introductions"},
+			{Text: "Goals", Children: []ListNode{{Text: "Q3 & Q4"}}},
+		}},
+		{Text: "Closing"},
+	}
+	if err = doc.ReplaceOutline("agenda", agenda, ListDecimal); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	expected := []struct {
+		level int
+		text  string
+	}{{0, "Welcome"}, {1, "Introductions"}, {1, "Goals"}, {2, "Q3 &amp; Q4"}, {0, "Closing"}}
+	documentXml := string(doc.GetFile(DocumentXml))
+	pos := 0
+	for _, e := range expected {
+		paragraph := `<w:p><w:pPr><w:numPr><w:ilvl w:val="` + strconv.Itoa(e.level) + `"/><w:numId w:val="1"/></w:numPr></w:pPr>` +
+			`<w:r><w:t xml:space="preserve">` + e.text + `</w:t></w:r></w:p>`
+		index := strings.Index(documentXml[pos:], paragraph)
+		if index < 0 {
+			t.Errorf("missing list paragraph %s on level %d", e.text, e.level)
+			return
+		}
+		pos += index + len(paragraph)
+	}
+	if strings.Contains(documentXml, "{agenda}") {
+		t.Error("placeholder paragraph was not replaced")
+	}
+
+	numbering, err := doc.readFile(NumberingXml)
+	if err != nil {
+		t.Error("numbering definitions were not created", err)
+		return
+	}
+	for _, element := range []string{
+		`<w:abstractNum w:abstractNumId="1">`,
+		`<w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="lowerLetter"/><w:lvlText w:val="%2."/>`,
+		`<w:lvl w:ilvl="8">`,
+		`</w:abstractNum><w:num w:numId="1"><w:abstractNumId w:val="1"/></w:num></w:numbering>`,
+	} {
+		if !strings.Contains(string(numbering), element) {
+			t.Errorf("expected %s inside the numbering definitions", element)
+		}
+	}
+	if doc.contentType(NumberingXml) != numberingContentType {
+		t.Error("content type of the numbering definitions was not added")
+	}
+
+	// the remaining placeholders must still be replaceable
+	if err = doc.ReplaceAll(PlaceholderMap{"key": "value"}); err != nil {
+		t.Error("replacing after the outline failed", err)
+	}
+	if err = doc.ReplaceOutline("agenda", agenda, ListBullet); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, have=%v", err)
+	}
+}
+
+func TestInsertNumbering(t *testing.T) {
+	numbering := []byte(`<w:numbering><w:abstractNum w:abstractNumId="3"/><w:num w:numId="7"/></w:numbering>`)
+	result, err := insertNumbering(numbering, `<w:abstractNum w:abstractNumId="4"/>`, `<w:num w:numId="8"/>`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := `<w:numbering><w:abstractNum w:abstractNumId="3"/><w:abstractNum w:abstractNumId="4"/><w:num w:numId="7"/><w:num w:numId="8"/></w:numbering>`
+	if string(result) != expected {
+		t.Errorf("unexpected numbering, want=%s, have=%s", expected, result)
+	}
+	if id := nextId(result, abstractNumIdRegex); id != 5 {
+		t.Errorf("unexpected next abstract numbering id, want=%d, have=%d", 5, id)
+	}
+}
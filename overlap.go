@@ -0,0 +1,81 @@
+package docx
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// OverlappingPlaceholders returns the groups of placeholders whose byte ranges intersect.
+// Such placeholders are the result of nested delimiters which are fragmented across runs,
+// e.g. the runs '{bar{bcd}' and 'ef}' are parsed into the placeholders '{bar{bcd}' and '{bcd}ef}'.
+// Replacing one of them would corrupt the other, hence overlapping placeholders are never replaced,
+// the template should be fixed instead.
+//
+// The groups are ordered by their part (in reading order) and position, the placeholders of a group by their position.
+// If there are no overlapping placeholders, nil is returned.
+func (d *Document) OverlappingPlaceholders() [][]*Placeholder {
+	var groups [][]*Placeholder
+	for _, part := range d.placeholderParts() {
+		groups = append(groups, overlappingPlaceholders(d.filePlaceholders[part])...)
+	}
+	return groups
+}
+
+// overlappingPlaceholders returns the groups of intersecting placeholders of a single part.
+// A group contains every placeholder which intersects any other placeholder of the group.
+func overlappingPlaceholders(placeholders []*Placeholder) [][]*Placeholder {
+	sorted := append([]*Placeholder{}, placeholders...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartPos() < sorted[j].StartPos()
+	})
+
+	var groups [][]*Placeholder
+	var group []*Placeholder
+	var groupEnd int64
+	for _, placeholder := range sorted {
+		if len(group) > 0 && placeholder.StartPos() < groupEnd {
+			group = append(group, placeholder)
+			if placeholder.EndPos() > groupEnd {
+				groupEnd = placeholder.EndPos()
+			}
+			continue
+		}
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+		group = []*Placeholder{placeholder}
+		groupEnd = placeholder.EndPos()
+	}
+	if len(group) > 1 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// withoutOverlaps returns the placeholders which do not intersect any other placeholder, in their original order.
+// Every discarded group is logged.
+func withoutOverlaps(placeholders []*Placeholder, docBytes []byte) []*Placeholder {
+	groups := overlappingPlaceholders(placeholders)
+	if len(groups) == 0 {
+		return placeholders
+	}
+
+	overlapping := make(map[*Placeholder]bool)
+	for _, group := range groups {
+		texts := make([]string, len(group))
+		for i, placeholder := range group {
+			overlapping[placeholder] = true
+			texts[i] = "\"" + placeholder.Text(docBytes) + "\""
+		}
+		log.Printf("overlapping placeholders %s, skipping\n", strings.Join(texts, ", "))
+	}
+
+	var kept []*Placeholder
+	for _, placeholder := range placeholders {
+		if !overlapping[placeholder] {
+			kept = append(kept, placeholder)
+		}
+	}
+	return kept
+}
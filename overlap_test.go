@@ -0,0 +1,87 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_OverlappingPlaceholders(t *testing.T) {
+	doc, err := Open("./test/overlap.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	groups := doc.OverlappingPlaceholders()
+	if len(groups) != 1 {
+		t.Errorf("unexpected number of overlapping groups, want=1, have=%d", len(groups))
+		return
+	}
+	data := doc.GetFile(DocumentXml)
+	var texts []string
+	for _, placeholder := range groups[0] {
+		texts = append(texts, placeholder.Text(data))
+	}
+	if strings.Join(texts, " ") != "{bar{bcd} {bcd}ef}" {
+		t.Errorf("unexpected overlapping placeholders %v", texts)
+	}
+}
+
+func TestDocument_OverlappingPlaceholders_NotReplaced(t *testing.T) {
+	doc, err := Open("./test/overlap.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err := doc.ReplaceAll(PlaceholderMap{"key": "Jane", "other": "done"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{"Dear Jane, ", "<w:t>done</w:t>", "<w:t>{bar{bcd}</w:t>", "<w:t>ef}</w:t>"} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s in the replaced document", expected)
+		}
+	}
+	if remaining := doc.fileReplacers[DocumentXml].Unreplaced(); len(remaining) != 0 {
+		t.Errorf("overlapping placeholders must not be replaceable, have %d", len(remaining))
+	}
+}
+
+func TestDocument_OverlappingPlaceholders_None(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if groups := doc.OverlappingPlaceholders(); groups != nil {
+		t.Errorf("expected no overlapping placeholders, have %d groups", len(groups))
+	}
+}
+
+func TestDocument_OverlappingPlaceholders_OverlappingKey(t *testing.T) {
+	for _, key := range []string{"bcd", "bar{bcd"} {
+		doc, err := Open("./test/overlap.docx")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+
+		// overlapping placeholders are skipped, they must not make the replacement fail
+		if err := doc.ReplaceAll(PlaceholderMap{key: "x"}); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+		documentXml := string(doc.GetFile(DocumentXml))
+		for _, expected := range []string{"<w:t>{bar{bcd}</w:t>", "<w:t>ef}</w:t>"} {
+			if !strings.Contains(documentXml, expected) {
+				t.Errorf("expected %s in the replaced document", expected)
+			}
+		}
+		doc.Close()
+	}
+}
@@ -0,0 +1,97 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+)
+
+var (
+	// paragraphOpenTagRegex matches the open tags of paragraphs (<w:p>), but not of other elements like <w:pPr>
+	paragraphOpenTagRegex = regexp.MustCompile(`<w:p(?:\s[^>]*)?>`)
+	// paragraphPropertiesOpenTagRegex matches the open tag of paragraph properties (<w:pPr>) at the start of the text
+	paragraphPropertiesOpenTagRegex = regexp.MustCompile(`^<w:pPr(?:\s[^>]*)?>`)
+)
+
+// SetInsertParagraphStyle sets the id of the paragraph style (e.g. 'BodyText') of the paragraphs which are inserted
+// by ReplaceOutline, ReplaceWithDocument, ReplaceWithTOC and ReplaceWithSignatureLine and of the paragraphs which are
+// created by expanding values (see SetExpandParagraphs). Inserted paragraphs which have a style of their own keep it,
+// e.g. the headings of a sub document or the paragraphs carrying over the styled paragraph of a placeholder.
+//
+// If the style is not defined in the style definitions of the document, a warning is logged and Word renders the
+// paragraphs using the default paragraph style. An empty id inserts the paragraphs without style, which is the default.
+func (d *Document) SetInsertParagraphStyle(styleID string) {
+	d.insertParagraphStyle = styleID
+	if styleID == "" {
+		return
+	}
+	exists, err := d.paragraphStyleExists(styleID)
+	if err != nil {
+		log.Printf("unable to verify paragraph style \"%s\": %s\n", styleID, err)
+	} else if !exists {
+		log.Printf("paragraph style \"%s\" is not defined in the document\n", styleID)
+	}
+}
+
+// paragraphStyleExists returns true if the style definitions of the document define a paragraph style with the id.
+func (d *Document) paragraphStyleExists(styleID string) (bool, error) {
+	stylesPart, err := d.referencedPart(StylesRelationshipType, StylesXml)
+	if err != nil {
+		return false, err
+	}
+	stylesBytes, err := d.readFile(stylesPart)
+	if err != nil {
+		return false, nil
+	}
+	styles := new(documentStyles)
+	if err := xml.Unmarshal(stylesBytes, styles); err != nil {
+		return false, fmt.Errorf("unable to parse %s: %s", stylesPart, err)
+	}
+	for _, style := range styles.Styles {
+		if style.Type == "paragraph" && style.ID == styleID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applyInsertParagraphStyle returns the content with the insert paragraph style set on all paragraphs without a style.
+// If no insert paragraph style is set, the content is returned unchanged.
+func (d *Document) applyInsertParagraphStyle(content string) string {
+	if d.insertParagraphStyle == "" {
+		return content
+	}
+	style := fmt.Sprintf(`<w:pStyle w:val="%s"/>`, html.EscapeString(d.insertParagraphStyle))
+
+	var styled strings.Builder
+	last := 0
+	for _, loc := range paragraphOpenTagRegex.FindAllStringIndex(content, -1) {
+		if strings.HasSuffix(content[loc[0]:loc[1]], "/>") {
+			continue // an empty paragraph
+		}
+		rest := content[loc[1]:]
+		// the style is the first child of the paragraph properties
+		switch props := paragraphPropertiesOpenTagRegex.FindString(rest); {
+		case strings.HasPrefix(rest, "<w:pPr/>"):
+			styled.WriteString(content[last:loc[1]])
+			styled.WriteString("<w:pPr>" + style + "</w:pPr>")
+			last = loc[1] + len("<w:pPr/>")
+		case props != "":
+			if strings.HasPrefix(rest[len(props):], "<w:pStyle") {
+				continue
+			}
+			styled.WriteString(content[last : loc[1]+len(props)])
+			styled.WriteString(style)
+			last = loc[1] + len(props)
+		default:
+			styled.WriteString(content[last:loc[1]])
+			styled.WriteString("<w:pPr>" + style + "</w:pPr>")
+			last = loc[1]
+		}
+	}
+	styled.WriteString(content[last:])
+	return styled.String()
+}
@@ -0,0 +1,131 @@
+package docx
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetInsertParagraphStyle(t *testing.T) {
+	doc, err := Open("./test/outline.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	doc.SetInsertParagraphStyle("a3")
+	if logged.Len() != 0 {
+		t.Errorf("unexpected warning for an existing style: %s", logged.String())
+	}
+	if err = doc.ReplaceOutline("agenda", []ListNode{{Text: "Welcome"}}, ListBullet); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	expected := `<w:p><w:pPr><w:pStyle w:val="a3"/><w:numPr><w:ilvl w:val="0"/>`
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), expected) {
+		t.Error("inserted paragraph does not have the insert paragraph style")
+	}
+
+	doc.SetInsertParagraphStyle("DoesNotExist")
+	if !strings.Contains(logged.String(), `paragraph style "DoesNotExist" is not defined`) {
+		t.Errorf("expected a warning for an undefined style, have %q", logged.String())
+	}
+}
+
+func TestDocument_SetInsertParagraphStyle_Replacements(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		replace  func(doc *Document) error
+		expected []string
+	}{
+		{
+			name: "expanded paragraphs",
+			file: "./test/expand_paragraphs.docx",
+			replace: func(doc *Document) error {
+				doc.SetExpandParagraphs(true)
+				return doc.ReplaceAll(PlaceholderMap{"note": "first\n\nsecond", "address": "Main Street 1\n\n12345 Springfield"})
+			},
+			expected: []string{
+				`<w:p><w:pPr><w:pStyle w:val="a3"/><w:jc w:val="center"/></w:pPr><w:hyperlink`,
+				`<w:p><w:pPr><w:pStyle w:val="a3"/></w:pPr><w:r><w:t xml:space="preserve">12345 Springfield`,
+			},
+		},
+		{
+			name: "table of contents",
+			file: "./test/toc.docx",
+			replace: func(doc *Document) error {
+				return doc.ReplaceWithTOC("toc", TOCOptions{})
+			},
+			expected: []string{`<w:sdtContent><w:p><w:pPr><w:pStyle w:val="a3"/></w:pPr><w:r><w:fldChar`},
+		},
+		{
+			name: "signature line",
+			file: "./test/signature.docx",
+			replace: func(doc *Document) error {
+				return doc.ReplaceWithSignatureLine("signature", SignatureInfo{Name: "Jane Doe"})
+			},
+			expected: []string{`<w:sdtContent><w:p><w:pPr><w:pStyle w:val="a3"/><w:jc w:val="right"/></w:pPr><w:r><w:pict`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Open(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer doc.Close()
+
+			doc.SetInsertParagraphStyle("a3")
+			if err = tt.replace(doc); err != nil {
+				t.Fatal("replacing failed", err)
+			}
+			documentXml := string(doc.GetFile(DocumentXml))
+			for _, expected := range tt.expected {
+				if !strings.Contains(documentXml, expected) {
+					t.Errorf("expected %s inside the document:\n%s", expected, documentXml)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyInsertParagraphStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"no properties",
+			`<w:p><w:r><w:t>a</w:t></w:r></w:p>`,
+			`<w:p><w:pPr><w:pStyle w:val="Body"/></w:pPr><w:r><w:t>a</w:t></w:r></w:p>`},
+		{"empty properties",
+			`<w:p w:rsidR="00A1"><w:pPr/></w:p>`,
+			`<w:p w:rsidR="00A1"><w:pPr><w:pStyle w:val="Body"/></w:pPr></w:p>`},
+		{"properties without style",
+			`<w:p><w:pPr><w:jc w:val="center"/></w:pPr></w:p>`,
+			`<w:p><w:pPr><w:pStyle w:val="Body"/><w:jc w:val="center"/></w:pPr></w:p>`},
+		{"own style",
+			`<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr></w:p>`,
+			`<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr></w:p>`},
+		{"empty paragraph and picture",
+			`<w:p/><w:r><w:pict/></w:r>`,
+			`<w:p/><w:r><w:pict/></w:r>`},
+	}
+
+	doc := &Document{insertParagraphStyle: "Body"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if styled := doc.applyInsertParagraphStyle(tt.content); styled != tt.expected {
+				t.Errorf("unexpected content, want=%s, have=%s", tt.expected, styled)
+			}
+		})
+	}
+}
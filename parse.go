@@ -15,6 +15,12 @@ const (
 	RunElementName = "r"
 	// TextElementName is the local name of the XML tag for text-runs (<w:t> and </w:t>)
 	TextElementName = "t"
+	// ParagraphElementName is the local name of the XML tag for paragraphs (<w:p> and </w:p>)
+	ParagraphElementName = "p"
+	// DeletedElementName is the local name of the XML tag for deleted revisions (<w:del>)
+	DeletedElementName = "del"
+	// RubyTextElementName is the local name of the XML tag for ruby annotations (<w:rt>), e.g. the furigana of japanese text
+	RubyTextElementName = "rt"
 )
 
 var (
@@ -28,6 +34,10 @@ var (
 	TextOpenTagRegex = regexp.MustCompile(`(<w:t).*>`)
 	// TextCloseTagRegex matches the close tag of text-runs
 	TextCloseTagRegex = regexp.MustCompile(`(</w:t>)`)
+	// TextSingletonTagRegex matches a singleton text tag, including eventually set attributes
+	TextSingletonTagRegex = regexp.MustCompile(`(<w:t(\s[^>]*)?/>)`)
+	// RunPropertiesRegex matches the run properties element, including all properties
+	RunPropertiesRegex = regexp.MustCompile(`(?s)<w:rPr>.*?</w:rPr>|<w:rPr/>`)
 	// ErrTagsInvalid is returned if the parsing failed and the result cannot be used.
 	// Typically this means that one or more tag-offsets were not parsed correctly which
 	// would cause the document to become corrupted as soon as replacing starts.
@@ -62,6 +72,7 @@ func (parser *RunParser) Execute() error {
 	if err != nil {
 		return err
 	}
+	parser.findRunProperties()
 
 	return ValidatePositions(parser.doc, parser.runs)
 }
@@ -85,6 +96,11 @@ func (parser *RunParser) findRuns() error {
 	// on every CloseTag.
 	nestCount := 0
 
+	// paragraphs is the stack of paragraph ids the decoder is currently in. Paragraphs can be nested,
+	// e.g. inside of text boxes, the innermost paragraph is the last one.
+	var paragraphs []int
+	paragraphCount := 0
+
 	// popRun will pop the last Run from the runStack if there is any on the stack
 	popRun := func() *Run {
 		r := parser.runStack.Back().Value.(*Run)
@@ -117,6 +133,11 @@ func (parser *RunParser) findRuns() error {
 
 		switch elem := tok.(type) {
 		case xml.StartElement:
+			if elem.Name.Local == ParagraphElementName {
+				paragraphCount += 1
+				paragraphs = append(paragraphs, paragraphCount)
+			}
+
 			if elem.Name.Local == RunElementName {
 
 				nestCount += 1
@@ -134,6 +155,9 @@ func (parser *RunParser) findRuns() error {
 					Start: tagStartPos,
 					End:   tagEndPos,
 				}
+				if len(paragraphs) > 0 {
+					tmpRun.Paragraph = paragraphs[len(paragraphs)-1]
+				}
 
 				// special case, a singleton tag: <w:r/> is also considered to be a start element
 				// since there is no real end tag, the element is marked for the EndElement case to handle it appropriately
@@ -144,6 +168,10 @@ func (parser *RunParser) findRuns() error {
 			}
 
 		case xml.EndElement:
+			if elem.Name.Local == ParagraphElementName && len(paragraphs) > 0 {
+				paragraphs = paragraphs[:len(paragraphs)-1]
+			}
+
 			if elem.Name.Local == RunElementName {
 
 				// if the run is a singleton tag, it was already identified by the xml.StartElement case
@@ -195,6 +223,20 @@ func (parser *RunParser) findTextRuns() error {
 		return nil
 	}
 
+	// a singleton text tag (<w:t/>) does not contain any text. Since it also emits an EndElement,
+	// it is marked in order to skip both elements.
+	singleton := false
+
+	// deletedDepth is the nesting-level of tracked deletions (<w:del>).
+	// Deleted runs should only contain <w:delText>, but some producers also use <w:t> which must not be
+	// replaced since the text is not part of the document anymore. Inserted runs (<w:ins>) are handled like any other run.
+	deletedDepth := 0
+
+	// rubyTextDepth is the nesting-level of ruby annotations (<w:rt>).
+	// The annotation is rendered above the base text (<w:rubyBase>) and is not part of the text flow,
+	// its runs are skipped so placeholders of the base text are found and the annotation stays intact.
+	rubyTextDepth := 0
+
 	for {
 		tok, err := decoder.Token()
 		if tok == nil || err == io.EOF {
@@ -206,13 +248,25 @@ func (parser *RunParser) findTextRuns() error {
 
 		switch elem := tok.(type) {
 		case xml.StartElement:
-			if elem.Name.Local == TextElementName {
+			if elem.Name.Local == DeletedElementName {
+				deletedDepth += 1
+			}
+			if elem.Name.Local == RubyTextElementName {
+				rubyTextDepth += 1
+			}
+
+			if elem.Name.Local == TextElementName && deletedDepth == 0 && rubyTextDepth == 0 {
 
 				// tagEndPos points to '>' of the tag
 				tagEndPos := docReader.Pos()
 				// tagStartPos points to '<' of the tag
 				tagStartPos := parser.findOpenBracketPos(tagEndPos - 1)
 
+				if TextSingletonTagRegex.Match(parser.doc[tagStartPos:tagEndPos]) {
+					singleton = true
+					break
+				}
+
 				currentRun := inRun(docReader.Pos())
 				if currentRun == nil {
 					return fmt.Errorf("unable to find currentRun for text start-element")
@@ -225,7 +279,19 @@ func (parser *RunParser) findTextRuns() error {
 			}
 
 		case xml.EndElement:
-			if elem.Name.Local == TextElementName {
+			if elem.Name.Local == DeletedElementName {
+				deletedDepth -= 1
+			}
+			if elem.Name.Local == RubyTextElementName {
+				rubyTextDepth -= 1
+			}
+
+			if elem.Name.Local == TextElementName && deletedDepth == 0 && rubyTextDepth == 0 {
+
+				if singleton {
+					singleton = false
+					break
+				}
 
 				// tagEndPos points to '>' of the tag
 				tagEndPos := docReader.Pos()
@@ -240,6 +306,11 @@ func (parser *RunParser) findTextRuns() error {
 					Start: tagStartPos,
 					End:   tagEndPos,
 				}
+				// the text is read only once, GetText is called many times while parsing placeholders
+				if currentRun.HasText && currentRun.Text.OpenTag.End <= tagStartPos {
+					currentRun.text = string(parser.doc[currentRun.Text.OpenTag.End:tagStartPos])
+					currentRun.textParsed = true
+				}
 			}
 		}
 	}
@@ -247,15 +318,16 @@ func (parser *RunParser) findTextRuns() error {
 	return nil
 }
 
+// findRunProperties parses the run properties of all runs with text.
+func (parser *RunParser) findRunProperties() {
+	for _, run := range parser.runs.WithText() {
+		run.Properties = ParseRunProps([]byte(runProperties(parser.doc, run)))
+	}
+}
+
 // findOpenBracketPos searches the matching '<' for a close bracket ('>') given it's position.
 func (parser *RunParser) findOpenBracketPos(endBracketPos int64) int64 {
-	var found bool
-	for i := endBracketPos; !found; i-- {
-		if string(parser.doc[i]) == "<" {
-			return i
-		}
-	}
-	return 0
+	return findOpenBracketPos(parser.doc, endBracketPos)
 }
 
 // ValidatePositions will iterate over all runs and their texts (if any) and ensure that they match
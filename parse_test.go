@@ -1,7 +1,9 @@
 package docx
 
 import (
+	"encoding/xml"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -66,6 +68,40 @@ func TestRun_GetText(t *testing.T) {
 	}
 }
 
+func TestRun_GetText_Modified(t *testing.T) {
+	docBytes := []byte(`<w:r><w:t>{foo}</w:t></w:r>`)
+	run := &Run{HasText: true}
+	run.Text.OpenTag = Position{Start: 5, End: 10}
+	run.Text.CloseTag = Position{Start: 15, End: 21}
+
+	if text := run.GetText(docBytes); text != "{foo}" {
+		t.Errorf("unexpected text, want=%s, have=%s", "{foo}", text)
+	}
+
+	// the run was not found by the RunParser, its text is read from the given bytes
+	copy(docBytes[10:15], "value")
+	if text := run.GetText(docBytes); text != "value" {
+		t.Errorf("unexpected text after modification, want=%s, have=%s", "value", text)
+	}
+	if text := run.GetText(docBytes[:12]); text != "" {
+		t.Errorf("expected empty text for a too small byte slice, have=%s", text)
+	}
+}
+
+func TestRun_GetText_Parsed(t *testing.T) {
+	docBytes := []byte(`<w:p><w:r><w:t>{foo}</w:t></w:r></w:p>`)
+	parser := NewRunParser(docBytes)
+	if err := parser.Execute(); err != nil {
+		t.Fatalf("parser.Execute failed: %s", err)
+	}
+	run := parser.Runs().WithText()[0]
+
+	// the text is read while parsing, the given bytes are not read again
+	if text := run.GetText(nil); text != "{foo}" {
+		t.Errorf("unexpected text, want=%s, have=%s", "{foo}", text)
+	}
+}
+
 func TestRun_WithText(t *testing.T) {
 	docBytes := readFile(t, testFile)
 
@@ -95,3 +131,189 @@ func readFile(t testing.TB, path string) []byte {
 
 	return b
 }
+
+func TestRunParser_EmptyText(t *testing.T) {
+	docBytes := readFile(t, "./test/empty_text.xml")
+
+	sut := NewRunParser(docBytes)
+	err := sut.Execute()
+	if err != nil {
+		t.Errorf("parser.Execute failed: %s", err)
+		return
+	}
+
+	// the runs containing only singleton text tags do not have any text
+	expectedTextRuns := 4
+	if is := len(sut.Runs().WithText()); is != expectedTextRuns {
+		t.Errorf("parser returned %d runs with text, expected %d", is, expectedTextRuns)
+	}
+
+	placeholders, err := ParsePlaceholders(sut.Runs(), docBytes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expectedPlaceholders := []string{"{foo}", "{fragmented_placeholder}"}
+	if len(placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(placeholders))
+		return
+	}
+	for i, placeholder := range placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+	}
+
+	replacer := NewReplacer(docBytes, placeholders)
+	for key, value := range map[string]string{"foo": "bar", "fragmented_placeholder": "baz"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+}
+
+func TestRunParser_TrackedChanges(t *testing.T) {
+	docBytes := readFile(t, "./test/tracked_changes.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	// placeholders inside of insertions are found, deleted text is skipped
+	expectedPlaceholders := []string{"{name}", "{company}"}
+	if len(replacer.placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(replacer.placeholders))
+		return
+	}
+	for i, placeholder := range replacer.placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+	}
+
+	for key, value := range map[string]string{"name": "John", "company": "ACME"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+	result := string(replacer.Bytes())
+	for _, expected := range []string{"<w:t>John</w:t>", "<w:t>ACME</w:t>", "<w:delText>{name}</w:delText>", "<w:t>{company}</w:t>"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s inside the result", expected)
+		}
+	}
+}
+
+func TestRunParser_Bookmarks(t *testing.T) {
+	docBytes := readFile(t, "./test/bookmarks.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	// bookmark markers are not part of any run, they only separate the runs
+	expectedPlaceholders := []string{"{total}", "{customer}", "{total}"}
+	if len(replacer.placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(replacer.placeholders))
+		return
+	}
+	for i, placeholder := range replacer.placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+	}
+
+	for key, value := range map[string]string{"total": "42.00", "customer": "ACME"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+
+	// the bookmarks and the cross-reference must stay untouched and in place
+	result := string(replacer.Bytes())
+	for _, expected := range []string{
+		"<w:bookmarkStart w:id=\"0\" w:name=\"total\"/>\n            <w:r><w:t>42.00</w:t></w:r>\n            <w:bookmarkEnd w:id=\"0\"/>",
+		"<w:bookmarkStart w:id=\"1\" w:name=\"customer\"/>\n            <w:r><w:t>ACME</w:t></w:r>\n            <w:bookmarkEnd w:id=\"1\"/>",
+		"<w:instrText xml:space=\"preserve\"> REF total \\h </w:instrText>",
+		"<w:fldChar w:fldCharType=\"separate\"/></w:r>\n            <w:r><w:t>42.00</w:t></w:r>",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s inside the result", expected)
+		}
+	}
+	if err := xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestRunParser_CommentRanges(t *testing.T) {
+	docBytes := readFile(t, "./test/comment_range.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	// comment range markers and references are no text, the placeholders are detected across them
+	expectedPlaceholders := []string{"{customer}", "{date}"}
+	if len(replacer.placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(replacer.placeholders))
+		return
+	}
+	for i, placeholder := range replacer.placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+	}
+
+	for key, value := range map[string]string{"customer": "ACME", "date": "2024-01-31"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+
+	// the comment ranges and references must stay untouched and in place
+	result := string(replacer.Bytes())
+	for _, expected := range []string{
+		"<w:r><w:t>ACME</w:t></w:r>\n            <w:commentRangeStart w:id=\"0\"/>\n            <w:r><w:t></w:t></w:r>\n            <w:commentRangeEnd w:id=\"0\"/>",
+		"<w:commentRangeStart w:id=\"1\"/>\n            <w:r><w:t xml:space=\"preserve\">Due: 2024-01-31</w:t></w:r>\n            <w:commentRangeEnd w:id=\"1\"/>",
+		"<w:commentReference w:id=\"0\"/>",
+		"<w:commentReference w:id=\"1\"/></w:r>\n            <w:r><w:t></w:t></w:r>",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s inside the result", expected)
+		}
+	}
+	if err := xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestRunParser_Ruby(t *testing.T) {
+	docBytes := readFile(t, "./test/ruby.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	// the ruby annotations (<w:rt>) are no text of the document, only the base text is
+	expectedPlaceholders := []string{"{name}", "{customer}"}
+	if len(replacer.placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(replacer.placeholders))
+		return
+	}
+	for i, placeholder := range replacer.placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+	}
+
+	for key, value := range map[string]string{"name": "\u5c71\u7530", "customer": "ACME"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+
+	result := string(replacer.Bytes())
+	for _, expected := range []string{
+		"<w:rubyBase><w:r><w:t>\u5c71\u7530</w:t></w:r></w:rubyBase>",
+		"<w:rt><w:r><w:rPr><w:sz w:val=\"10\"/></w:rPr><w:t>\u306a\u307e\u3048</w:t></w:r></w:rt>",
+		"<w:r><w:t>ACME</w:t></w:r>",
+		"<w:rt><w:r><w:t>{\u3053\u304d\u3083\u304f}</w:t></w:r></w:rt>",
+		"<w:rubyBase><w:r><w:t></w:t></w:r></w:rubyBase>",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s inside the result", expected)
+		}
+	}
+	if err := xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
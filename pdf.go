@@ -0,0 +1,39 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// PDFRenderer converts a docx-archive into a PDF, e.g. by invoking LibreOffice or a conversion service.
+// No renderer ships with this package, it has to be provided by the caller, see ToPDF.
+type PDFRenderer interface {
+	Render(docx []byte) (pdf []byte, err error)
+}
+
+// PDFRendererFunc is an adapter to use an ordinary function as PDFRenderer.
+type PDFRendererFunc func(docx []byte) ([]byte, error)
+
+// Render calls f(docx).
+func (f PDFRendererFunc) Render(docx []byte) ([]byte, error) {
+	return f(docx)
+}
+
+// ToPDF writes the document (see Write) and converts it into a PDF using the given renderer.
+// The package does not contain a renderer itself, so it stays free of external dependencies.
+// Errors of the renderer are wrapped and can be inspected using errors.Is and errors.As.
+func (d *Document) ToPDF(r PDFRenderer) ([]byte, error) {
+	if r == nil {
+		return nil, errors.New("no PDF renderer given")
+	}
+	var docx bytes.Buffer
+	if err := d.Write(&docx); err != nil {
+		return nil, fmt.Errorf("unable to write document: %s", err)
+	}
+	pdf, err := r.Render(docx.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("unable to render PDF: %w", err)
+	}
+	return pdf, nil
+}
@@ -0,0 +1,53 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ToPDF(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.Replace("key", "rendered"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	renderer := PDFRendererFunc(func(docx []byte) ([]byte, error) {
+		rendered, err := OpenBytes(docx)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(rendered.Text(), "rendered") {
+			t.Error("renderer did not receive the replaced document")
+		}
+		return []byte("%PDF-1.7"), nil
+	})
+	pdf, err := doc.ToPDF(renderer)
+	if err != nil {
+		t.Error("rendering failed", err)
+		return
+	}
+	if !bytes.Equal(pdf, []byte("%PDF-1.7")) {
+		t.Errorf("unexpected pdf %s", pdf)
+	}
+
+	failure := errors.New("renderer unavailable")
+	_, err = doc.ToPDF(PDFRendererFunc(func(docx []byte) ([]byte, error) {
+		return nil, failure
+	}))
+	if !errors.Is(err, failure) {
+		t.Errorf("expected the error of the renderer, got %v", err)
+	}
+
+	if _, err = doc.ToPDF(nil); err == nil {
+		t.Error("expected an error without renderer")
+	}
+}
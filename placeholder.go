@@ -1,9 +1,12 @@
 package docx
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -14,6 +17,19 @@ var (
 	CloseDelimiter rune = '}'
 )
 
+// RequireSingleRunPlaceholders defines whether all placeholders must be contained in a single run.
+// If enabled, ParsePlaceholders returns an error wrapping ErrFragmentedPlaceholder for every placeholder
+// which is fragmented across multiple runs, instead of assembling it. This is useful to detect templates
+// which were fragmented by the editor.
+// It is disabled by default and must be set before a document is opened.
+var RequireSingleRunPlaceholders = false
+
+// ErrValueType is returned by PlaceholderMap.ValidateTypes if a value does not have the expected kind.
+var ErrValueType = errors.New("unexpected value type")
+
+// ErrFragmentedPlaceholder is returned if a placeholder spans multiple runs while RequireSingleRunPlaceholders is set.
+var ErrFragmentedPlaceholder = errors.New("placeholder is fragmented across multiple runs")
+
 // ChangeOpenCloseDelimiter is used for change the open and close delimiters
 func ChangeOpenCloseDelimiter(openDelimiter, closeDelimiter rune) {
 	OpenDelimiter = openDelimiter
@@ -22,14 +38,86 @@ func ChangeOpenCloseDelimiter(openDelimiter, closeDelimiter rune) {
 
 var (
 	// OpenDelimiterRegex is used to quickly match the opening delimiter and find it'str positions.
+	//
+	// Deprecated: ParsePlaceholders scans the delimiters directly, the regex is not updated by ChangeOpenCloseDelimiter.
 	OpenDelimiterRegex = regexp.MustCompile(string(OpenDelimiter))
 	// CloseDelimiterRegex is used to quickly match the closing delimiter and find it'str positions.
+	//
+	// Deprecated: ParsePlaceholders scans the delimiters directly, the regex is not updated by ChangeOpenCloseDelimiter.
 	CloseDelimiterRegex = regexp.MustCompile(string(CloseDelimiter))
 )
 
 // PlaceholderMap is the type used to map the placeholder keys (without delimiters) to the replacement values
 type PlaceholderMap map[string]interface{}
 
+// Merge returns a new PlaceholderMap which contains the entries of both maps.
+// Entries of other override the entries of the receiver with the same key. Neither map is modified.
+func (m PlaceholderMap) Merge(other PlaceholderMap) PlaceholderMap {
+	merged := make(PlaceholderMap, len(m)+len(other))
+	for key, value := range m {
+		merged[key] = value
+	}
+	for key, value := range other {
+		merged[key] = value
+	}
+	return merged
+}
+
+// sortedKeys returns the keys of the map in alphabetical order.
+// Values are inserted in this order, which keeps the media names and relationship ids of inserted images reproducible.
+func (m PlaceholderMap) sortedKeys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NormalizeKeys returns a new PlaceholderMap in which all keys are trimmed of surrounding whitespace and,
+// if lowercase is set, converted to lower case. Placeholders are matched case-sensitive, so lowercase should
+// only be set if the placeholders inside the document are lower case as well.
+// If multiple keys normalize to the same key, a key which already was normalized takes precedence,
+// otherwise the alphabetically first key wins.
+func (m PlaceholderMap) NormalizeKeys(lowercase bool) PlaceholderMap {
+	keys := m.sortedKeys()
+
+	normalized := make(PlaceholderMap, len(m))
+	for _, key := range keys {
+		normalizedKey := strings.TrimSpace(key)
+		if lowercase {
+			normalizedKey = strings.ToLower(normalizedKey)
+		}
+		if _, exists := normalized[normalizedKey]; exists && key != normalizedKey {
+			continue
+		}
+		normalized[normalizedKey] = m[key]
+	}
+	return normalized
+}
+
+// ValidateTypes checks that the values of the map have the expected kinds, e.g. to detect a key which unexpectedly
+// changed from a string to a []string before the document is rendered wrongly. Keys which are not part of expected
+// and expected keys which are missing from the map are ignored. Nil values have the kind reflect.Invalid.
+// If any value has a different kind, an error wrapping ErrValueType is returned which lists all of them.
+func (m PlaceholderMap) ValidateTypes(expected map[string]reflect.Kind) error {
+	var problems []string
+	for key, kind := range expected {
+		value, exists := m[key]
+		if !exists {
+			continue
+		}
+		if actual := reflect.ValueOf(value).Kind(); actual != kind {
+			problems = append(problems, fmt.Sprintf("%s is %s, want %s", key, actual, kind))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("%w: %s", ErrValueType, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
 // Placeholder is the internal representation of a parsed placeholder from the docx-archive.
 // A placeholder usually consists of multiple PlaceholderFragments which specify the relative
 // byte-offsets of the fragment inside the underlying byte-data.
@@ -38,6 +126,10 @@ type Placeholder struct {
 }
 
 // Text assembles the placeholder fragments using the given docBytes and returns the full placeholder literal.
+// Soft hyphens are removed and non-breaking hyphens are returned as '-', so the keys of hyphenated
+// placeholders match regardless of the hyphenation. Zero-width characters are removed if StripZeroWidth is set.
+// If DelimiterPairs are set, the delimiters of the placeholder are returned as OpenDelimiter and CloseDelimiter.
+// The quotes of a quoted key are removed (see KeyQuote), e.g. '{"weird}key"}' is returned as '{weird}key}'.
 func (p Placeholder) Text(docBytes []byte) string {
 	str := ""
 	for _, fragment := range p.Fragments {
@@ -45,7 +137,7 @@ func (p Placeholder) Text(docBytes []byte) string {
 		t := docBytes[s+fragment.Position.Start : s+fragment.Position.End]
 		str += string(t)
 	}
-	return str
+	return unquotePlaceholderText(canonicalPlaceholderText(normalizePlaceholderText(str)))
 }
 
 // StartPos returns the absolute start position of the placeholder.
@@ -59,43 +151,89 @@ func (p Placeholder) EndPos() int64 {
 	return p.Fragments[end].Run.Text.OpenTag.End + p.Fragments[end].Position.End
 }
 
+// RunProperties returns the properties of the run which contains the start of the placeholder.
+// It can be used to style generated content like the placeholder (see RunProps.FormattedText).
+func (p Placeholder) RunProperties() RunProps {
+	return p.Fragments[0].Run.Properties
+}
+
 // Valid determines whether the placeholder can be used.
 // A placeholder is considered valid, if all fragments are valid.
 func (p Placeholder) Valid() bool {
-	for _, fragment := range p.Fragments {
-		if !fragment.Valid() {
-			return false
+	return p.Validate() == nil
+}
+
+// Validate returns an error describing the first invalid fragment of the placeholder and why it is invalid,
+// or nil if the placeholder is valid. See Valid.
+func (p Placeholder) Validate() error {
+	for i, fragment := range p.Fragments {
+		if fragment == nil {
+			return fmt.Errorf("%w: fragment %d of the placeholder is nil", ErrInvalidFragment, i)
+		}
+		if err := fragment.Validate(); err != nil {
+			return fmt.Errorf("fragment %d of the placeholder is invalid: %w", i, err)
 		}
 	}
-	return true
+	return nil
 }
 
 // ParsePlaceholders will, given the document run positions and the bytes, parse out all placeholders including
-// their fragments.
+// their fragments. Placeholders which are not closed within their paragraph are discarded with a warning,
+// see Document.SetAllowCrossParagraphPlaceholders.
 func ParsePlaceholders(runs DocumentRuns, docBytes []byte) (placeholders []*Placeholder, err error) {
+	return parsePlaceholders(runs, docBytes, false)
+}
+
+// parsePlaceholders works like ParsePlaceholders. If crossParagraph is set, a placeholder opened in one paragraph
+// and closed in a following one (e.g. '{foo' and 'bar}') is assembled just like a placeholder which is fragmented
+// inside a single paragraph.
+func parsePlaceholders(runs DocumentRuns, docBytes []byte, crossParagraph bool) (placeholders []*Placeholder, err error) {
+	if len(DelimiterPairs) > 0 {
+		return validPlaceholders(parseDelimiterPairs(runs, docBytes, crossParagraph), docBytes)
+	}
+
 	// tmp vars used to preserve state across iterations
 	unclosedPlaceholder := new(Placeholder)
 	hasOpenPlaceholder := false
+	quotes := new(quotedKeyScanner)
+	unmatchedOpenDelimiters := 0
+	lastParagraph := -1
 
 	for _, run := range runs.WithText() {
 		runText := run.GetText(docBytes)
 
-		openDelimPositions := OpenDelimiterRegex.FindAllStringIndex(runText, -1)
-		closeDelimPositions := CloseDelimiterRegex.FindAllStringIndex(runText, -1)
+		// quoted keys cannot span paragraphs
+		if run.Paragraph != lastParagraph {
+			quotes.reset()
+			lastParagraph = run.Paragraph
+		}
 
-		// FindAllStringIndex returns a [][]int whereas the nested []int has only 2 keys (0 and 1)
-		// We're only interested in the first key as that one indicates the position of the delimiter
-		delimPositions := func(positions [][]int) []int {
-			var pos []int
-			for _, position := range positions {
-				pos = append(pos, position[0])
+		// an open placeholder cannot be continued in a different paragraph unless explicitly allowed
+		discardedPlaceholder := false
+		if hasOpenPlaceholder && !crossParagraph {
+			lastRun := unclosedPlaceholder.Fragments[len(unclosedPlaceholder.Fragments)-1].Run
+			if lastRun.Paragraph != run.Paragraph {
+				log.Printf("placeholder \"%s\" is not closed within its paragraph, discarding\n", unclosedPlaceholder.Text(docBytes))
+				unclosedPlaceholder = new(Placeholder)
+				hasOpenPlaceholder = false
+				discardedPlaceholder = true
 			}
-			return pos
 		}
 
+		// the delimiters inside of quoted keys are masked, they are part of the key
+		scanText := quotes.mask(runText)
+
 		// index all delimiters
-		openPos := delimPositions(openDelimPositions)
-		closePos := delimPositions(closeDelimPositions)
+		openPos, closePos := delimiterPositions(scanText)
+
+		// More open delimiters than a single unclosed placeholder accounts for, e.g. '{{{foo}{bar'.
+		// The surplus open delimiters cannot be matched and are dropped, otherwise they would be paired
+		// with the wrong close delimiters.
+		if len(openPos) > len(closePos)+1 {
+			var surplus int
+			openPos, surplus = discardSurplusOpenDelimiters(openPos, closePos)
+			unmatchedOpenDelimiters += surplus
+		}
 
 		// In case there are the same amount of open and close delimiters.
 		// Here we will have three three different sub-cases.
@@ -153,17 +291,20 @@ func ParsePlaceholders(runs DocumentRuns, docBytes []byte) (placeholders []*Plac
 				firstClosePos := closePos[0]
 
 				// we MUST be having an unclosedPlaceholder or the user made a typo like double-closing ('{foo}}{bar')
-				if !hasOpenPlaceholder {
+				// the only exception is a placeholder which was just discarded at the paragraph boundary.
+				if !hasOpenPlaceholder && !discardedPlaceholder {
 					return nil, fmt.Errorf("unexpected %c in run %d \"%s\"), missing preceeding %c", CloseDelimiter, run.ID, run.GetText(docBytes), OpenDelimiter)
 				}
 
 				// everything up to firstClosePos belongs to the currently open placeholder
-				fragment := NewPlaceholderFragment(0, Position{0, int64(firstClosePos) + 1}, run)
-				unclosedPlaceholder.Fragments = append(unclosedPlaceholder.Fragments, fragment)
-				placeholders = append(placeholders, unclosedPlaceholder)
+				if hasOpenPlaceholder {
+					fragment := NewPlaceholderFragment(0, Position{0, int64(firstClosePos) + 1}, run)
+					unclosedPlaceholder.Fragments = append(unclosedPlaceholder.Fragments, fragment)
+					placeholders = append(placeholders, unclosedPlaceholder)
+				}
 
 				// a new, unclosed, placeholder starts at lastOpenPos
-				fragment = NewPlaceholderFragment(0, Position{int64(lastOpenPos), int64(len(runText))}, run)
+				fragment := NewPlaceholderFragment(0, Position{int64(lastOpenPos), int64(len(runText))}, run)
 				unclosedPlaceholder = new(Placeholder)
 				unclosedPlaceholder.Fragments = append(unclosedPlaceholder.Fragments, fragment)
 				hasOpenPlaceholder = true
@@ -239,10 +380,55 @@ func ParsePlaceholders(runs DocumentRuns, docBytes []byte) (placeholders []*Plac
 		}
 	}
 
-	// Make sure that we're dealing with valid and proper placeholders only.
-	// Everything else may cause issues like out of bounds errors or any other sort of weird things.
-	// Here we will also assemble the final list of placeholders and return only the valid ones.
-	var validPlaceholders []*Placeholder
+	// a single diagnostic, even for adversarial templates with thousands of them
+	if unmatchedOpenDelimiters > 0 {
+		log.Printf("%d unmatched %c, skipping\n", unmatchedOpenDelimiters, OpenDelimiter)
+	}
+
+	return validPlaceholders(placeholders, docBytes)
+}
+
+// discardSurplusOpenDelimiters returns the open delimiter positions which can be matched with the close delimiter
+// positions of the same run: the open delimiter directly preceding every close delimiter and the last open delimiter
+// following the last close delimiter, which starts an unclosed placeholder. The number of discarded open delimiters
+// is returned as well. Both position lists must be ordered, the runtime is linear.
+func discardSurplusOpenDelimiters(openPos, closePos []int) ([]int, int) {
+	kept := make([]int, 0, len(closePos)+1)
+	i := 0
+	for _, end := range closePos {
+		last := -1
+		for ; i < len(openPos) && openPos[i] < end; i++ {
+			last = openPos[i]
+		}
+		if last >= 0 {
+			kept = append(kept, last)
+		}
+	}
+	if i < len(openPos) {
+		kept = append(kept, openPos[len(openPos)-1])
+	}
+	return kept, len(openPos) - len(kept)
+}
+
+// delimiterPositions returns the byte positions of all OpenDelimiter and CloseDelimiter runes inside the text.
+// Unlike matching the delimiters with a regular expression, it does not allocate for every delimiter.
+func delimiterPositions(text string) (openPos, closePos []int) {
+	for i, r := range text {
+		switch r {
+		case OpenDelimiter:
+			openPos = append(openPos, i)
+		case CloseDelimiter:
+			closePos = append(closePos, i)
+		}
+	}
+	return openPos, closePos
+}
+
+// validPlaceholders returns the valid placeholders of the given ones.
+// Make sure that we're dealing with valid and proper placeholders only.
+// Everything else may cause issues like out of bounds errors or any other sort of weird things.
+func validPlaceholders(placeholders []*Placeholder, docBytes []byte) ([]*Placeholder, error) {
+	var valid []*Placeholder
 	for _, placeholder := range placeholders {
 		if !placeholder.Valid() {
 			continue
@@ -255,10 +441,20 @@ func ParsePlaceholders(runs DocumentRuns, docBytes []byte) (placeholders []*Plac
 			continue
 		}
 
+		if RequireSingleRunPlaceholders && len(placeholder.Fragments) > 1 {
+			return nil, fmt.Errorf("%w: \"%s\" spans %d runs", ErrFragmentedPlaceholder, text, len(placeholder.Fragments))
+		}
+
+		// empty placeholders (e.g. '{}') are most likely left by accident and cannot be mapped to a key
+		if isEmptyPlaceholder(text) {
+			log.Printf("empty placeholder \"%s\", skipping\n", text)
+			continue
+		}
+
 		// placeholder is valid
-		validPlaceholders = append(validPlaceholders, placeholder)
+		valid = append(valid, placeholder)
 	}
-	return validPlaceholders, nil
+	return valid, nil
 }
 
 // assembleFullPlaceholders will extract all complete placeholders inside the run given a open and close position.
@@ -277,6 +473,12 @@ func assembleFullPlaceholders(run *Run, openPos, closePos []int) (placeholders [
 	return placeholders
 }
 
+// isEmptyPlaceholder returns true if the given placeholder text does not contain anything but the
+// delimiters and whitespace, e.g. '{}' or '{ }'.
+func isEmptyPlaceholder(text string) bool {
+	return IsDelimitedPlaceholder(text) && strings.TrimSpace(text[1:len(text)-1]) == ""
+}
+
 // AddPlaceholderDelimiter will wrap the given string with OpenDelimiter and CloseDelimiter.
 // If the given string is already a delimited placeholder, it is returned unchanged.
 func AddPlaceholderDelimiter(s string) string {
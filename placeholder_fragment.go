@@ -1,11 +1,18 @@
 package docx
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
 
 var (
-	fragmentId = 0 // global fragment id counter, incremented on NewPlaceholderFragment
+	fragmentId int64 = 0 // global fragment id counter, incremented on NewPlaceholderFragment
 )
 
+// ErrInvalidFragment is returned by PlaceholderFragment.Validate and Placeholder.Validate if a fragment is invalid.
+var ErrInvalidFragment = errors.New("invalid placeholder fragment")
+
 // PlaceholderFragment is a part of a placeholder within the document.xml
 // If the full placeholder is e.g. '{foo-bar}', the placeholder might be ripped
 // apart according to the WordprocessingML spec. So it will most likely occur, that
@@ -105,20 +112,41 @@ func (p PlaceholderFragment) String(docBytes []byte) string {
 
 // Valid returns true if all positions of the fragment are valid.
 func (p PlaceholderFragment) Valid() bool {
-	return p.Run.OpenTag.Valid() &&
-		p.Run.CloseTag.Valid() &&
-		p.Run.Text.OpenTag.Valid() &&
-		p.Run.Text.CloseTag.Valid() &&
-		p.Position.Valid()
+	return p.Validate() == nil
+}
+
+// Validate returns an error wrapping ErrInvalidFragment which describes why the fragment is invalid,
+// or nil if it is valid. See Valid.
+func (p PlaceholderFragment) Validate() error {
+	if p.Run == nil {
+		return fmt.Errorf("%w: fragment %d does not have a run", ErrInvalidFragment, p.ID)
+	}
+	positions := []struct {
+		name     string
+		position Position
+	}{
+		{"open tag of the run", p.Run.OpenTag},
+		{"close tag of the run", p.Run.CloseTag},
+		{"open tag of the run text", p.Run.Text.OpenTag},
+		{"close tag of the run text", p.Run.Text.CloseTag},
+		{"position within the run text", p.Position},
+	}
+	for _, pos := range positions {
+		if !pos.position.Valid() {
+			return fmt.Errorf("%w: %s of fragment %d ends before it starts [%d:%d]",
+				ErrInvalidFragment, pos.name, p.ID, pos.position.Start, pos.position.End)
+		}
+	}
+	return nil
 }
 
 // NewFragmentID returns the next Fragment.ID
+// The counter is incremented atomically, so documents can be parsed concurrently (see Clone).
 func NewFragmentID() int {
-	fragmentId += 1
-	return fragmentId
+	return int(atomic.AddInt64(&fragmentId, 1))
 }
 
 // ResetFragmentIdCounter will reset the fragmentId counter to 0
 func ResetFragmentIdCounter() {
-	fragmentId = 0
+	atomic.StoreInt64(&fragmentId, 0)
 }
@@ -0,0 +1,43 @@
+package docx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPlaceholder_Validate(t *testing.T) {
+	run := &Run{HasText: true}
+	run.OpenTag = Position{Start: 0, End: 5}
+	run.Text.OpenTag = Position{Start: 5, End: 10}
+	run.Text.CloseTag = Position{Start: 15, End: 21}
+	run.CloseTag = Position{Start: 21, End: 27}
+
+	valid := Placeholder{Fragments: []*PlaceholderFragment{NewPlaceholderFragment(0, Position{Start: 0, End: 5}, run)}}
+	if err := valid.Validate(); err != nil || !valid.Valid() {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+
+	tests := []struct {
+		placeholder Placeholder
+		reason      string
+	}{
+		{Placeholder{Fragments: []*PlaceholderFragment{{ID: 1}}}, "fragment 1 does not have a run"},
+		{Placeholder{Fragments: []*PlaceholderFragment{valid.Fragments[0], nil}}, "fragment 1 of the placeholder is nil"},
+		{Placeholder{Fragments: []*PlaceholderFragment{{ID: 2, Run: run, Position: Position{Start: 4, End: 2}}}},
+			"position within the run text of fragment 2 ends before it starts [4:2]"},
+	}
+	for _, tt := range tests {
+		err := tt.placeholder.Validate()
+		if !errors.Is(err, ErrInvalidFragment) {
+			t.Errorf("expected ErrInvalidFragment, have=%v", err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.reason) {
+			t.Errorf("expected %s inside the error, have=%s", tt.reason, err)
+		}
+		if tt.placeholder.Valid() {
+			t.Error("invalid placeholder reported as valid")
+		}
+	}
+}
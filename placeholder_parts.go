@@ -0,0 +1,47 @@
+package docx
+
+import "sort"
+
+// PartsWithPlaceholders returns the sorted names of all parts of the archive which contain at least one placeholder,
+// e.g. to find out why a placeholder was not replaced. Besides the parts which are replaced by ReplaceAll (the main
+// document, headers and footers), all parts which are replaced by ReplaceEverywhere are scanned, even if they are
+// not loaded yet: footnotes, endnotes, comments, the glossary document, altChunk parts, diagrams and charts.
+//
+// The parts are scanned for delimited text, regardless of whether the placeholders are fragmented.
+// Parts which cannot be read are skipped.
+func (d *Document) PartsWithPlaceholders() []string {
+	var parts []string
+	scan := func(name string, text string) {
+		if len(remainingPlaceholders(normalizePlaceholderText(text))) > 0 {
+			parts = append(parts, name)
+		}
+	}
+
+	for name, data := range d.files {
+		if _, parsed := d.runParsers[name]; parsed {
+			scan(name, plainText(data))
+		}
+	}
+	for _, name := range additionalParts {
+		if _, loaded := d.files[name]; loaded {
+			continue
+		}
+		if data, err := d.readFile(name); err == nil {
+			scan(name, plainText(data))
+		}
+	}
+	for _, name := range d.altChunkFiles {
+		if data, err := d.readFile(name); err == nil {
+			scan(name, string(data))
+		}
+	}
+	// the text of DrawingML runs is read just like the text of WordprocessingML runs
+	for _, name := range append(append([]string{}, d.diagramFiles...), d.chartFiles...) {
+		if data, err := d.readFile(name); err == nil {
+			scan(name, plainText(data))
+		}
+	}
+
+	sort.Strings(parts)
+	return parts
+}
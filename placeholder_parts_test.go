@@ -0,0 +1,30 @@
+package docx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_PartsWithPlaceholders(t *testing.T) {
+	doc, err := Open("./test/chart.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	expected := []string{"word/charts/chart1.xml", DocumentXml, "word/footer1.xml", "word/header1.xml"}
+	if parts := doc.PartsWithPlaceholders(); !reflect.DeepEqual(parts, expected) {
+		t.Errorf("unexpected parts, want=%v, have=%v", expected, parts)
+	}
+
+	// replaced parts no longer contain placeholders, charts are not replaced by default
+	if err = doc.ReplaceAll(PlaceholderMap{"year": 2024, "key": "value"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	expected = []string{"word/charts/chart1.xml"}
+	if parts := doc.PartsWithPlaceholders(); !reflect.DeepEqual(parts, expected) {
+		t.Errorf("unexpected parts after replacing, want=%v, have=%v", expected, parts)
+	}
+}
@@ -1,6 +1,14 @@
 package docx
 
-import "testing"
+import (
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 var (
 	textMapping = PlaceholderMap{
@@ -22,7 +30,8 @@ func TestParsePlaceholders(t *testing.T) {
 		t.Errorf("parser.Execute failed: %s", err)
 	}
 
-	placeholders, err := ParsePlaceholders(parser.Runs().WithText(), docBytes)
+	// '{yet-another' and '-placeholder}' are in different paragraphs
+	placeholders, err := parsePlaceholders(parser.Runs().WithText(), docBytes, true)
 	if err != nil {
 		t.Error(err)
 		return
@@ -57,3 +66,330 @@ func TestPlaceholder_AssembleFullPlaceholders(t *testing.T) {
 		t.Errorf("not all full placeholders were parsed, want=%d, have=%d", expectedCount, len(placeholders))
 	}
 }
+
+func TestParsePlaceholders_CrossParagraph(t *testing.T) {
+	docBytes := readFile(t, "./test/placeholder.xml")
+
+	parser := NewRunParser(docBytes)
+	err := parser.Execute()
+	if err != nil {
+		t.Errorf("parser.Execute failed: %s", err)
+	}
+
+	placeholders, err := ParsePlaceholders(parser.Runs().WithText(), docBytes)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// '{yet-another' and '-placeholder}' are in different paragraphs
+	expectedPlaceholderCount := 5
+	if len(placeholders) != expectedPlaceholderCount {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", expectedPlaceholderCount, len(placeholders))
+	}
+	for _, placeholder := range placeholders {
+		if placeholder.Text(docBytes) == "{yet-another-placeholder}" {
+			t.Error("cross-paragraph placeholder was assembled although it is not allowed")
+		}
+	}
+
+	placeholders, err = parsePlaceholders(parser.Runs().WithText(), docBytes, true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	found := false
+	for _, placeholder := range placeholders {
+		if placeholder.Text(docBytes) == "{yet-another-placeholder}" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("cross-paragraph placeholder was not assembled although it is allowed")
+	}
+}
+
+func TestDocument_SetAllowCrossParagraphPlaceholders(t *testing.T) {
+	doc, err := Open("./test/cross_paragraph.docx")
+	if err != nil {
+		t.Fatalf("unable to open document: %s", err)
+	}
+	defer doc.Close()
+
+	if err := doc.Replace("yet-another-placeholder", "REPLACED"); err != nil {
+		t.Fatalf("Replace failed: %s", err)
+	}
+	if strings.Contains(string(doc.GetFile(DocumentXml)), "REPLACED") {
+		t.Error("cross-paragraph placeholder was replaced although it is not allowed")
+	}
+
+	if err := doc.SetAllowCrossParagraphPlaceholders(true); err != nil {
+		t.Fatalf("SetAllowCrossParagraphPlaceholders failed: %s", err)
+	}
+	if err := doc.Replace("yet-another-placeholder", "REPLACED"); err != nil {
+		t.Fatalf("Replace failed: %s", err)
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), "REPLACED") {
+		t.Error("cross-paragraph placeholder was not replaced although it is allowed")
+	}
+}
+
+func TestParsePlaceholders_RequireSingleRun(t *testing.T) {
+	docBytes := readFile(t, "./test/placeholder.xml")
+
+	parser := NewRunParser(docBytes)
+	err := parser.Execute()
+	if err != nil {
+		t.Errorf("parser.Execute failed: %s", err)
+	}
+
+	RequireSingleRunPlaceholders = true
+	defer func() { RequireSingleRunPlaceholders = false }()
+
+	_, err = ParsePlaceholders(parser.Runs().WithText(), docBytes)
+	if !errors.Is(err, ErrFragmentedPlaceholder) {
+		t.Errorf("expected ErrFragmentedPlaceholder, got %v", err)
+	}
+
+	// documents without fragmented placeholders are parsed as usual
+	docBytes = readFile(t, "./test/tracked_changes.xml")
+	docBytes = []byte(strings.Replace(string(docBytes), "<w:t>{comp</w:t>", "<w:t>{company}</w:t>", 1))
+	docBytes = []byte(strings.Replace(string(docBytes), "<w:t>any}</w:t>", "<w:t></w:t>", 1))
+	parser = NewRunParser(docBytes)
+	if err = parser.Execute(); err != nil {
+		t.Errorf("parser.Execute failed: %s", err)
+	}
+	if _, err = ParsePlaceholders(parser.Runs().WithText(), docBytes); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestPlaceholderMap_Merge(t *testing.T) {
+	base := PlaceholderMap{"name": "John", "city": "Berlin"}
+	merged := base.Merge(PlaceholderMap{"city": "Hamburg", "zip": 20095})
+
+	expected := PlaceholderMap{"name": "John", "city": "Hamburg", "zip": 20095}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("unexpected merge result, want=%v, have=%v", expected, merged)
+	}
+	if base["city"] != "Berlin" {
+		t.Error("receiver was modified")
+	}
+}
+
+func TestPlaceholderMap_NormalizeKeys(t *testing.T) {
+	m := PlaceholderMap{" Name ": "padded", "Name": "exact", "CITY\t": "Berlin"}
+
+	expected := PlaceholderMap{"Name": "exact", "CITY": "Berlin"}
+	if normalized := m.NormalizeKeys(false); !reflect.DeepEqual(normalized, expected) {
+		t.Errorf("unexpected normalized keys, want=%v, have=%v", expected, normalized)
+	}
+
+	expected = PlaceholderMap{"name": "padded", "city": "Berlin"}
+	if normalized := m.NormalizeKeys(true); !reflect.DeepEqual(normalized, expected) {
+		t.Errorf("unexpected lower case keys, want=%v, have=%v", expected, normalized)
+	}
+}
+
+func TestPlaceholderMap_ValidateTypes(t *testing.T) {
+	expected := map[string]reflect.Kind{"name": reflect.String, "tags": reflect.Slice, "missing": reflect.Int}
+
+	valid := PlaceholderMap{"name": "John", "tags": []string{"a", "b"}, "other": 42}
+	if err := valid.ValidateTypes(expected); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+
+	drifted := PlaceholderMap{"name": []string{"John"}, "tags": nil}
+	err := drifted.ValidateTypes(expected)
+	if !errors.Is(err, ErrValueType) {
+		t.Errorf("expected ErrValueType, have=%v", err)
+		return
+	}
+	for _, problem := range []string{"name is slice, want string", "tags is invalid, want slice"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected %s inside the error, have=%s", problem, err)
+		}
+	}
+}
+
+func TestParsePlaceholders_Empty(t *testing.T) {
+	docBytes := readFile(t, "./test/empty_placeholder.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	expectedPlaceholders := []string{"{foo}", "{bar}"}
+	if len(replacer.placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(replacer.placeholders))
+		return
+	}
+	for i, placeholder := range replacer.placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+	}
+
+	if err := replacer.Replace("", "value"); err != ErrPlaceholderNotFound {
+		t.Errorf("expected ErrPlaceholderNotFound for an empty key, got %v", err)
+	}
+}
+
+func TestParsePlaceholders_Adjacent(t *testing.T) {
+	docBytes := readFile(t, "./test/adjacent.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	expectedPlaceholders := []string{"{first}", "{last}", "{a}", "{b}", "{c}", "{first}", "{last}"}
+	if len(replacer.placeholders) != len(expectedPlaceholders) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expectedPlaceholders), len(replacer.placeholders))
+		return
+	}
+	for i, placeholder := range replacer.placeholders {
+		if text := placeholder.Text(docBytes); text != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder, want=%s, have=%s", expectedPlaceholders[i], text)
+		}
+		// the positions of fragmented placeholders include the xml between the fragments
+		if len(placeholder.Fragments) == 1 && string(docBytes[placeholder.StartPos():placeholder.EndPos()]) != expectedPlaceholders[i] {
+			t.Errorf("unexpected placeholder position of %s, have=%s", expectedPlaceholders[i],
+				docBytes[placeholder.StartPos():placeholder.EndPos()])
+		}
+	}
+	// placeholders of the same run must neither overlap nor leave a gap
+	for _, pair := range [][2]int{{0, 1}, {2, 3}, {3, 4}} {
+		first, second := replacer.placeholders[pair[0]], replacer.placeholders[pair[1]]
+		if first.EndPos() != second.StartPos() {
+			t.Errorf("adjacent placeholders %d and %d are not adjacent, end=%d, start=%d", pair[0], pair[1], first.EndPos(), second.StartPos())
+		}
+	}
+
+	for key, value := range map[string]string{"first": "John", "last": "Doe", "a": "1", "b": "22", "c": "333"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+	result := string(replacer.Bytes())
+	for _, expected := range []string{"<w:t>JohnDoe</w:t>", "<w:t>122333</w:t>", "<w:t>JohnDoe</w:t></w:r>\n            <w:r><w:t></w:t>"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s inside the result", expected)
+		}
+	}
+}
+
+func BenchmarkParsePlaceholders(b *testing.B) {
+	docBytes := readFile(b, "./test/placeholder.xml")
+	parser := NewRunParser(docBytes)
+	if err := parser.Execute(); err != nil {
+		b.Fatalf("parser.Execute failed: %s", err)
+	}
+	runs := parser.Runs()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := ParsePlaceholders(runs, docBytes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestDiscardSurplusOpenDelimiters(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []int
+		surplus  int
+	}{
+		{text: "{{", expected: []int{1}, surplus: 1},
+		{text: "{{{foo}{bar", expected: []int{2, 7}, surplus: 2},
+		{text: "{a{{b}{c}", expected: []int{3, 6}, surplus: 2},
+		{text: "}{{{a", expected: []int{3}, surplus: 2},
+	}
+	for _, tt := range tests {
+		var openPos, closePos []int
+		for i, c := range tt.text {
+			switch c {
+			case OpenDelimiter:
+				openPos = append(openPos, i)
+			case CloseDelimiter:
+				closePos = append(closePos, i)
+			}
+		}
+		kept, surplus := discardSurplusOpenDelimiters(openPos, closePos)
+		if !reflect.DeepEqual(kept, tt.expected) || surplus != tt.surplus {
+			t.Errorf("%s: want=%v (%d surplus), have=%v (%d surplus)", tt.text, tt.expected, tt.surplus, kept, surplus)
+		}
+	}
+}
+
+func TestParsePlaceholders_UnmatchedOpenDelimiters(t *testing.T) {
+	docBytes := unmatchedOpenDelimitersDocument(1000)
+	replacer := newTestReplacer(t, docBytes)
+
+	var placeholders []string
+	for _, placeholder := range replacer.placeholders {
+		placeholders = append(placeholders, placeholder.Text(docBytes))
+	}
+	expected := []string{"{foo}", "{bar}"}
+	if !reflect.DeepEqual(placeholders, expected) {
+		t.Errorf("unexpected placeholders, want=%v, have=%v", expected, placeholders)
+	}
+}
+
+// unmatchedOpenDelimitersDocument returns a document with a paragraph of n open delimiters, followed by the
+// placeholders {foo} and {bar}.
+func unmatchedOpenDelimitersDocument(n int) []byte {
+	return []byte(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		`<w:p><w:r><w:t>` + strings.Repeat(string(OpenDelimiter), n) + `foo}</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>{bar}</w:t></w:r></w:p></w:body></w:document>`)
+}
+
+func BenchmarkParsePlaceholders_UnmatchedOpenDelimiters(b *testing.B) {
+	log.SetOutput(ioutil.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	docBytes := unmatchedOpenDelimitersDocument(100000)
+	parser := NewRunParser(docBytes)
+	if err := parser.Execute(); err != nil {
+		b.Fatalf("parser.Execute failed: %s", err)
+	}
+	runs := parser.Runs()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := ParsePlaceholders(runs, docBytes); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// fragmentedPlaceholdersDocument returns a document with n paragraphs, each containing a placeholder
+// which is fragmented across three runs and a placeholder inside a single run.
+func fragmentedPlaceholdersDocument(n int) []byte {
+	paragraph := `<w:p><w:r><w:t>Dear {first</w:t></w:r><w:r><w:t>_</w:t></w:r><w:r><w:t>name}, </w:t></w:r>` +
+		`<w:r><w:t>your order {order} has been shipped.</w:t></w:r></w:p>`
+	return []byte(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		strings.Repeat(paragraph, n) + `</w:body></w:document>`)
+}
+
+func BenchmarkParsePlaceholders_Fragmented(b *testing.B) {
+	docBytes := fragmentedPlaceholdersDocument(1000)
+	parser := NewRunParser(docBytes)
+	if err := parser.Execute(); err != nil {
+		b.Fatalf("parser.Execute failed: %s", err)
+	}
+	runs := parser.Runs()
+
+	parse := func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			if _, err := ParsePlaceholders(runs, docBytes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.Run("Delimiters", parse)
+	b.Run("DelimiterPairs", func(b *testing.B) {
+		DelimiterPairs = []DelimiterPair{{Open: "{", Close: "}"}}
+		defer func() {
+			DelimiterPairs = nil
+		}()
+		parse(b)
+	})
+}
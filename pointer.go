@@ -0,0 +1,80 @@
+package docx
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// withoutNilPointers returns a copy of the placeholderMap without the entries whose value is a nil pointer,
+// hence their placeholders are treated like the placeholders of missing keys (see SetOnMissingKey).
+// If there are no nil pointers, the placeholderMap itself is returned.
+func withoutNilPointers(placeholderMap PlaceholderMap) PlaceholderMap {
+	var filtered PlaceholderMap
+	for key, value := range placeholderMap {
+		if !isNilPointer(value) {
+			continue
+		}
+		if filtered == nil {
+			filtered = make(PlaceholderMap, len(placeholderMap))
+			for k, v := range placeholderMap {
+				filtered[k] = v
+			}
+		}
+		delete(filtered, key)
+	}
+	if filtered == nil {
+		return placeholderMap
+	}
+	return filtered
+}
+
+// dereferenceValues returns a copy of the placeholderMap with all pointer values dereferenced (see dereferenceValue),
+// e.g. so a *[]string is expanded into indexed keys like a []string. If there are no pointers to dereference,
+// the placeholderMap itself is returned.
+func dereferenceValues(placeholderMap PlaceholderMap) PlaceholderMap {
+	var dereferenced PlaceholderMap
+	for key, value := range placeholderMap {
+		if reflect.ValueOf(value).Kind() != reflect.Ptr {
+			continue
+		}
+		v := dereferenceValue(value)
+		if v == value {
+			continue
+		}
+		if dereferenced == nil {
+			dereferenced = make(PlaceholderMap, len(placeholderMap))
+			for k, val := range placeholderMap {
+				dereferenced[k] = val
+			}
+		}
+		dereferenced[key] = v
+	}
+	if dereferenced == nil {
+		return placeholderMap
+	}
+	return dereferenced
+}
+
+// isNilPointer returns true if the value is a nil pointer, e.g. a (*string)(nil).
+func isNilPointer(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// dereferenceValue returns the value the given pointer points to, following pointers to pointers.
+// Pointers which are values on their own are returned as they are: pointers with a ValueHandler registered
+// for their type and pointers implementing fmt.Stringer or error (e.g. *big.Int). Nil pointers and values
+// which are not pointers are returned unchanged.
+func dereferenceValue(value interface{}) interface{} {
+	for {
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.Ptr || v.IsNil() || lookupValueHandler(value) != nil {
+			return value
+		}
+		switch value.(type) {
+		case fmt.Stringer, error:
+			return value
+		}
+		value = v.Elem().Interface()
+	}
+}
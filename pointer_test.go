@@ -0,0 +1,108 @@
+package docx
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceAll_Pointers(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var missing []string
+	doc.SetOnMissingKey(func(key string, pos Position) (string, bool) {
+		missing = append(missing, key)
+		return "", false
+	})
+
+	count := 42
+	countPtr := &count
+	err = doc.ReplaceAll(PlaceholderMap{
+		"key":                 (*string)(nil),
+		"key-with-dash":       &count,
+		"key-with-dashes":     &countPtr,
+		"key_with_underscore": big.NewInt(7),
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if strings.Contains(documentXml, "&lt;nil&gt;") || strings.Contains(documentXml, ">0xc") {
+		t.Error("pointer was inserted instead of its value")
+	}
+	if !strings.Contains(documentXml, "{key}") {
+		t.Error("placeholder of a nil pointer was not left untouched")
+	}
+	found := false
+	for _, key := range missing {
+		found = found || key == "key"
+	}
+	if !found {
+		t.Error("nil pointer was not treated like a missing key")
+	}
+	for _, key := range []string{"key-with-dash", "key-with-dashes", "key_with_underscore"} {
+		if strings.Contains(documentXml, AddPlaceholderDelimiter(key)) {
+			t.Errorf("placeholder %s was not replaced", key)
+		}
+	}
+	if strings.Count(documentXml, ">42<") < 2 {
+		t.Error("value of the pointer was not inserted")
+	}
+	// pointers implementing fmt.Stringer are formatted as they are
+	if !strings.Contains(documentXml, ">7<") {
+		t.Error("fmt.Stringer pointer was not formatted using its String method")
+	}
+}
+
+func TestDereferenceValue(t *testing.T) {
+	text := "text"
+	textPtr := &text
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected interface{}
+	}{
+		{"value", 1, 1},
+		{"pointer", &text, "text"},
+		{"pointer to pointer", &textPtr, "text"},
+		{"nil pointer", (*int)(nil), (*int)(nil)},
+		{"nil", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if value := dereferenceValue(tt.value); value != tt.expected {
+				t.Errorf("unexpected value, want=%v, have=%v", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestDocument_ReplaceAll_PointerIndexedKeys(t *testing.T) {
+	doc, err := Open("./test/indexed.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	tags := []string{"red", "green"}
+	amounts := [2]float64{1.5, 2.5}
+	err = doc.ReplaceAll(PlaceholderMap{"tags": &tags, "amounts": &amounts})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	for _, expected := range []string{"Tags: red, green and {tags[5]}", "Amount: 2.5"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q inside the document", expected)
+		}
+	}
+}
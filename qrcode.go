@@ -0,0 +1,37 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoQREncoder is returned by ReplaceQRCode if no QREncoder has been set.
+	ErrNoQREncoder = errors.New("no QREncoder set")
+)
+
+// QREncoder encodes data into an image, e.g. a QR code or a barcode.
+// The lib does not ship an encoder in order to not depend on a specific QR code library,
+// an adapter for the library of choice needs to be set using SetQREncoder.
+type QREncoder interface {
+	// Encode returns the png, jpeg or gif image encoding the data. The size is the width and height in pixels.
+	Encode(data string, size int) ([]byte, error)
+}
+
+// SetQREncoder sets the QREncoder which is used by ReplaceQRCode.
+func (d *Document) SetQREncoder(encoder QREncoder) {
+	d.qrEncoder = encoder
+}
+
+// ReplaceQRCode will replace the given key with the image generated by the QREncoder from data in every file.
+// The image is inserted with a width and height of size pixels, just like any other image (see ReplaceImage).
+func (d *Document) ReplaceQRCode(key string, data string, size int) error {
+	if d.qrEncoder == nil {
+		return ErrNoQREncoder
+	}
+	img, err := d.qrEncoder.Encode(data, size)
+	if err != nil {
+		return fmt.Errorf("unable to encode %s: %s", key, err)
+	}
+	return d.ReplaceImage(key, ImageData{Data: img, Width: size, Height: size})
+}
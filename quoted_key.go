@@ -0,0 +1,69 @@
+package docx
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// KeyQuote is the character which quotes a placeholder key, e.g. '{"weird}key"}'.
+// If an open delimiter is directly followed by a KeyQuote, everything up to the next KeyQuote which is directly
+// followed by a close delimiter is the key, so the key may contain the delimiters.
+// The key of the example is 'weird}key', the placeholder is replaced by ReplaceAll(PlaceholderMap{"weird}key": ...}).
+// A quoted key cannot span multiple paragraphs.
+const KeyQuote = '"'
+
+// quotedKeyScanner masks the delimiters inside of quoted keys.
+// The state is preserved across runs, so quoted keys may span multiple runs of a paragraph.
+type quotedKeyScanner struct {
+	afterOpen    bool // afterOpen is set if the last rune was an open delimiter
+	quoted       bool // quoted is set while inside of a quoted key
+	closingQuote bool // closingQuote is set if the last rune inside of a quoted key was a quote
+}
+
+// reset discards the current state, e.g. at a paragraph boundary.
+func (s *quotedKeyScanner) reset() {
+	*s = quotedKeyScanner{}
+}
+
+// mask returns the text with all delimiters inside of quoted keys replaced by spaces.
+// The masked text has the same byte length as the given one, so the positions of the delimiters remain the same.
+func (s *quotedKeyScanner) mask(text string) string {
+	var masked strings.Builder
+	for _, r := range text {
+		switch {
+		case s.quoted && s.closingQuote && r == CloseDelimiter:
+			s.quoted = false
+			s.closingQuote = false
+		case s.quoted:
+			s.closingQuote = r == KeyQuote
+			if r == OpenDelimiter || r == CloseDelimiter {
+				masked.WriteString(strings.Repeat(" ", utf8.RuneLen(r)))
+				continue
+			}
+		case s.afterOpen && r == KeyQuote:
+			s.quoted = true
+			s.afterOpen = false
+		default:
+			s.afterOpen = r == OpenDelimiter
+		}
+		masked.WriteRune(r)
+	}
+	return masked.String()
+}
+
+// unquotePlaceholderText returns the placeholder text with the quotes of a quoted key removed,
+// e.g. '{"weird}key"}' is returned as '{weird}key}'. Any other text is returned unchanged.
+func unquotePlaceholderText(text string) string {
+	open := string(OpenDelimiter) + string(KeyQuote)
+	close := string(KeyQuote) + string(CloseDelimiter)
+	if len(text) < len(open)+len(close) || !strings.HasPrefix(text, open) || !strings.HasSuffix(text, close) {
+		return text
+	}
+	return string(OpenDelimiter) + text[len(open):len(text)-len(close)] + string(CloseDelimiter)
+}
+
+// quotePlaceholderText returns the quoted form of the delimited placeholder text, e.g. '{"key"}' for '{key}'.
+func quotePlaceholderText(placeholder string) string {
+	key := placeholder[utf8.RuneLen(OpenDelimiter) : len(placeholder)-utf8.RuneLen(CloseDelimiter)]
+	return string(OpenDelimiter) + string(KeyQuote) + key + string(KeyQuote) + string(CloseDelimiter)
+}
@@ -0,0 +1,49 @@
+package docx
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePlaceholders_QuotedKey(t *testing.T) {
+	docBytes := readFile(t, "./test/quoted_key.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	var placeholders []string
+	for _, placeholder := range replacer.placeholders {
+		placeholders = append(placeholders, placeholder.Text(docBytes))
+	}
+	// an unterminated quote does not swallow the placeholders of the following paragraphs
+	expected := []string{"{weird}key}", "{plain}", "{a{b}c}", "{say\"hi\"}", "{next}"}
+	if !reflect.DeepEqual(placeholders, expected) {
+		t.Errorf("unexpected placeholders, want=%v, have=%v", expected, placeholders)
+	}
+
+	for key, value := range map[string]string{"weird}key": "WEIRD", "a{b}c": "SPLIT", "say\"hi\"": "HI"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+	result := string(replacer.Bytes())
+	for _, text := range []string{"Weird: WEIRD and {plain}", "Quote: HI"} {
+		if !strings.Contains(result, text) {
+			t.Errorf("expected %s inside the result", text)
+		}
+	}
+	if !strings.Contains(plainText(replacer.Bytes()), "Split: SPLIT, done") {
+		t.Error("fragmented quoted placeholder was not replaced")
+	}
+	if err := xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestQuotedKey_Remaining(t *testing.T) {
+	text := `{"weird}key"} {weird}key} {"other"}`
+	expected := []string{`{"weird}key"}`, "{weird}", `{"other"}`}
+	if remaining := remainingPlaceholders(text); !reflect.DeepEqual(remaining, expected) {
+		t.Errorf("unexpected remaining placeholders, want=%v, have=%v", expected, remaining)
+	}
+}
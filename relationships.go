@@ -0,0 +1,112 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"path"
+	"strings"
+)
+
+const (
+	// ImageRelationshipType is the type of relationships which reference images.
+	ImageRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+	// HyperlinkRelationshipType is the type of relationships which reference the targets of hyperlinks.
+	HyperlinkRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
+	// OfficeDocumentRelationshipType is the type of the package relationship which references the main document part.
+	OfficeDocumentRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	// ExternalTargetMode is the target mode of relationships which reference resources outside of the archive.
+	ExternalTargetMode = "External"
+	// relationshipsNamespace is the namespace of the relationships parts.
+	relationshipsNamespace = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+// relationship is a single relationship (<Relationship>) of a part inside the docx-archive.
+type relationship struct {
+	ID         string `xml:"Id,attr"`
+	Type       string `xml:"Type,attr"`
+	Target     string `xml:"Target,attr"`
+	TargetMode string `xml:"TargetMode,attr,omitempty"`
+}
+
+// relationships is the root element of a relationships part.
+type relationships struct {
+	Relationships []relationship `xml:"Relationship"`
+}
+
+// relationshipsPath returns the path of the relationships part which belongs to the given part.
+// For example, the relationships of 'word/document.xml' are located at 'word/_rels/document.xml.rels'.
+func relationshipsPath(part string) string {
+	return path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+}
+
+// readRelationships reads all relationships of the given part.
+// If the part does not have any relationships, an empty slice is returned.
+func (d *Document) readRelationships(part string) ([]relationship, error) {
+	data, err := d.readFile(relationshipsPath(part))
+	if err != nil {
+		return nil, nil
+	}
+	rels := new(relationships)
+	if err := xml.Unmarshal(data, rels); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", relationshipsPath(part), err)
+	}
+	return rels.Relationships, nil
+}
+
+// relationshipTarget resolves the target of a relationship of the given part to the path inside the docx-archive.
+// Targets are relative to the directory of the part, unless they are absolute (e.g. '/word/document.xml').
+func relationshipTarget(part string, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(part), target)
+}
+
+// relativeTarget returns the target of a relationship of the given part which references the given file.
+// Files inside the directory of the part are referenced relative to it, all others are referenced absolute.
+func relativeTarget(part string, file string) string {
+	dir := path.Dir(part) + "/"
+	if strings.HasPrefix(file, dir) {
+		return strings.TrimPrefix(file, dir)
+	}
+	return "/" + file
+}
+
+// addRelationship adds the given relationship to the relationships of the given part and returns its id.
+// The id of the given relationship is ignored, the first free id of the form 'rIdN' counting up from the number
+// of relationships is used instead. If the part does not have any relationships yet, they are created.
+func (d *Document) addRelationship(part string, rel relationship) (string, error) {
+	rels, err := d.readRelationships(part)
+	if err != nil {
+		return "", err
+	}
+	ids := make(map[string]bool)
+	for _, r := range rels {
+		ids[r.ID] = true
+	}
+	var id string
+	for i := len(rels) + 1; ; i++ {
+		id = fmt.Sprintf("rId%d", i)
+		if !ids[id] {
+			break
+		}
+	}
+
+	relsPath := relationshipsPath(part)
+	data, err := d.readFile(relsPath)
+	if err != nil {
+		data = []byte(xml.Header + `<Relationships xmlns="` + relationshipsNamespace + `"></Relationships>`)
+	}
+	closeTag := strings.LastIndex(string(data), "</Relationships>")
+	if closeTag < 0 {
+		return "", fmt.Errorf("unable to modify %s: missing root element", relsPath)
+	}
+	targetMode := ""
+	if rel.TargetMode != "" {
+		targetMode = fmt.Sprintf(` TargetMode="%s"`, rel.TargetMode)
+	}
+	element := fmt.Sprintf(`<Relationship Id="%s" Type="%s" Target="%s"%s/>`, id, rel.Type, html.EscapeString(rel.Target), targetMode)
+	d.writePart(relsPath, splice(data, closeTag, closeTag, element))
+	return id, nil
+}
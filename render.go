@@ -0,0 +1,68 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Render opens the template, replaces its placeholders everywhere (see ReplaceEverywhere) with the data of the
+// JSON file at dataPath and writes the result to outPath. It is the core of a command line tool which fills templates.
+//
+// The JSON file must contain an object. Nested objects are flattened into dotted keys, e.g. the value of
+// {"customer": {"name": "ACME"}} replaces {customer.name}. The elements of arrays can be accessed by their index,
+// e.g. {tags[0]}. Numbers are inserted exactly as they are written in the JSON file.
+func Render(templatePath, dataPath, outPath string) error {
+	data, err := ioutil.ReadFile(dataPath)
+	if err != nil {
+		return fmt.Errorf("unable to read data: %s", err)
+	}
+	placeholderMap, err := jsonPlaceholderMap(data)
+	if err != nil {
+		return fmt.Errorf("unable to parse data %s: %s", dataPath, err)
+	}
+
+	doc, err := Open(templatePath)
+	if err != nil {
+		return err
+	}
+	defer doc.Close()
+
+	if _, err := doc.ReplaceEverywhere(placeholderMap); err != nil {
+		return err
+	}
+	return doc.WriteToFile(outPath)
+}
+
+// jsonPlaceholderMap unmarshals the JSON object into a PlaceholderMap, flattening nested objects into dotted keys.
+func jsonPlaceholderMap(data []byte) (PlaceholderMap, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	placeholderMap := make(PlaceholderMap)
+	flattenObject(placeholderMap, "", object)
+	return placeholderMap, nil
+}
+
+// flattenObject adds all values of the object to the placeholderMap, the keys of nested objects
+// are prefixed with the keys of their parents.
+func flattenObject(placeholderMap PlaceholderMap, prefix string, object map[string]interface{}) {
+	for key, value := range object {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenObject(placeholderMap, key, v)
+		case nil:
+			placeholderMap[key] = ""
+		default:
+			placeholderMap[key] = v
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package docx
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	err := Render("./test/template.docx", "./test/render.json", "./test/out_render.docx")
+	if err != nil {
+		t.Error("rendering failed", err)
+		return
+	}
+	defer os.Remove("./test/out_render.docx")
+
+	doc, err := Open("./test/out_render.docx")
+	if err != nil {
+		t.Error("unable to open rendered document", err)
+		return
+	}
+	defer doc.Close()
+
+	text := doc.Text()
+	for _, expected := range []string{"Render", "1.50"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %s inside the rendered document", expected)
+		}
+	}
+	for _, unexpected := range []string{"{key with space}", "{key.with.dots}", "{yet-another_placeholder}"} {
+		if strings.Contains(text, unexpected) {
+			t.Errorf("placeholder %s was not replaced", unexpected)
+		}
+	}
+
+	if err = Render("./test/template.docx", "./test/does-not-exist.json", "./test/out_render.docx"); err == nil {
+		t.Error("expected an error for missing data")
+	}
+}
+
+func TestJsonPlaceholderMap(t *testing.T) {
+	placeholderMap, err := jsonPlaceholderMap([]byte(`{"a": {"b": {"c": 1e3}}, "tags": ["x", 2], "flag": true}`))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := PlaceholderMap{
+		"a.b.c": json.Number("1e3"),
+		"tags":  []interface{}{"x", json.Number("2")},
+		"flag":  true,
+	}
+	if !reflect.DeepEqual(placeholderMap, expected) {
+		t.Errorf("unexpected placeholder map, want=%v, have=%v", expected, placeholderMap)
+	}
+
+	if _, err = jsonPlaceholderMap([]byte(`["not", "an", "object"]`)); err == nil {
+		t.Error("expected an error for a JSON array")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"html"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -19,9 +21,13 @@ type Replacer struct {
 	document     []byte
 	placeholders []*Placeholder
 	distinctRuns []*Run // slice of all distinct runs extracted from the placeholders used for validation
+	replaced     map[*Placeholder]bool
 	ReplaceCount int
 	BytesChanged int64
 	mu           sync.Mutex
+
+	// onReplace is called with every placeholder and its escaped value right before it is replaced, may be nil
+	onReplace func(placeholder *Placeholder, value string)
 }
 
 // NewReplacer returns a new Replacer.
@@ -29,6 +35,7 @@ func NewReplacer(docBytes []byte, placeholder []*Placeholder) *Replacer {
 	r := &Replacer{
 		document:     docBytes,
 		placeholders: placeholder,
+		replaced:     make(map[*Placeholder]bool),
 		ReplaceCount: 0,
 	}
 	r.distinctRuns = r.getDistinctRuns(placeholder)
@@ -39,37 +46,53 @@ func NewReplacer(docBytes []byte, placeholder []*Placeholder) *Replacer {
 // Replace will replace all occurrences of the placeholderKey with the given value.
 // The function is synced with a mutex as it is not concurrency safe.
 func (r *Replacer) Replace(placeholderKey string, value string) error {
+	return r.replace(placeholderKey, -1, func(placeholder *Placeholder) string {
+		return r.textValue(placeholder.Fragments[0].Run, value)
+	})
+}
+
+// ReplaceFormatted will replace all occurrences of the placeholderKey with the given FormattedText segments.
+// The run of the placeholder is split at the placeholder and every segment is inserted as a run of its own.
+// The properties of the segments are merged into the properties of the placeholder run, e.g. a bold segment inserted
+// into an italic run is bold and italic. The text following the placeholder is moved into a new run which keeps
+// the properties of the original run.
+func (r *Replacer) ReplaceFormatted(placeholderKey string, texts ...FormattedText) error {
+	if err := validateFormattedTexts(texts); err != nil {
+		return err
+	}
+	return r.replace(placeholderKey, -1, func(placeholder *Placeholder) string {
+		run := placeholder.Fragments[0].Run
+		return r.splitRun(run, formattedRuns(texts, runProperties(r.document, run)))
+	})
+}
+
+// replace will replace the first n occurrences of the placeholderKey with the value returned by valueFunc.
+// If n < 0, all occurrences are replaced. The occurrences are replaced in the order of their position.
+// The value returned by valueFunc is inserted as-is, it must already be escaped.
+// The function is synced with a mutex as it is not concurrency safe.
+func (r *Replacer) replace(placeholderKey string, n int, valueFunc func(placeholder *Placeholder) string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if !strings.ContainsRune(placeholderKey, OpenDelimiter) ||
-		!strings.ContainsRune(placeholderKey, CloseDelimiter) {
-		placeholderKey = AddPlaceholderDelimiter(placeholderKey)
-	}
+	// keys of quoted placeholders may contain the delimiters
+	placeholderKey = AddPlaceholderDelimiter(placeholderKey)
 
 	// find all occurrences of the placeholderKey inside r.placeholders
-	found := false
-	for i := 0; i < len(r.placeholders); i++ {
-		placeholder := r.placeholders[i]
-
+	var occurrences []*Placeholder
+	for _, placeholder := range r.placeholders {
 		if placeholder.Text(r.document) == placeholderKey {
-			found = true
-
-			// ensure html escaping of special chars
-			// reassign to prevent overwriting the actual value which would cause multiple-escapes
-			tmpVal := html.EscapeString(value)
-			valueInBytes := bytes.Replace(
-				[]byte(tmpVal),
-				[]byte("\n"), []byte("</w:t><w:br/><w:t>"), -1)
-
-			// replace text of the placeholder'str first fragment with the actual value
-			r.replaceFragmentValue(placeholder.Fragments[0], string(valueInBytes))
-
-			// the other fragments of the placeholder are cut, leaving only the value inside the document.
-			for i := 1; i < len(placeholder.Fragments); i++ {
-				r.cutFragment(placeholder.Fragments[i])
-			}
+			occurrences = append(occurrences, placeholder)
 		}
 	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartPos() < occurrences[j].StartPos()
+	})
+	if n >= 0 && len(occurrences) > n {
+		occurrences = occurrences[:n]
+	}
+
+	for _, placeholder := range occurrences {
+		r.replacePlaceholder(placeholder, valueFunc(placeholder))
+	}
 
 	// all replacing actions might potentially screw up the XML structure
 	// in order to capture this, all tags are re-validated after replacing a value
@@ -77,12 +100,124 @@ func (r *Replacer) Replace(placeholderKey string, value string) error {
 		return fmt.Errorf("replace produced invalid result: %w", err)
 	}
 
-	if !found {
+	if len(occurrences) == 0 {
 		return ErrPlaceholderNotFound
 	}
 	return nil
 }
 
+// ReplacePlaceholder will replace the given placeholder with the value.
+// The placeholder must be one of the placeholders of the Replacer.
+func (r *Replacer) ReplacePlaceholder(placeholder *Placeholder, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.replacePlaceholder(placeholder, r.textValue(placeholder.Fragments[0].Run, value))
+	if err := ValidatePositions(r.document, r.distinctRuns); err != nil {
+		return fmt.Errorf("replace produced invalid result: %w", err)
+	}
+	return nil
+}
+
+// replacePlaceholder replaces the given placeholder with the value, which must already be escaped.
+func (r *Replacer) replacePlaceholder(placeholder *Placeholder, value string) {
+	if r.onReplace != nil {
+		r.onReplace(placeholder, value)
+	}
+
+	// replace text of the placeholder'str first fragment with the actual value
+	r.replaceFragmentValue(placeholder.Fragments[0], value)
+
+	// the other fragments of the placeholder are cut, leaving only the value inside the document.
+	for i := 1; i < len(placeholder.Fragments); i++ {
+		r.cutFragment(placeholder.Fragments[i])
+	}
+	r.replaced[placeholder] = true
+}
+
+// Unreplaced returns all placeholders which have not been replaced yet, in the order of their position.
+func (r *Replacer) Unreplaced() []*Placeholder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var placeholders []*Placeholder
+	for _, placeholder := range r.placeholders {
+		if !r.replaced[placeholder] {
+			placeholders = append(placeholders, placeholder)
+		}
+	}
+	sort.SliceStable(placeholders, func(i, j int) bool {
+		return placeholders[i].StartPos() < placeholders[j].StartPos()
+	})
+	return placeholders
+}
+
+// splitRun returns the value which splits the given run at the position of the value and inserts
+// the given runs in between.
+// The text following the value is put into a new run, carrying over the run properties and the
+// text element of the original run.
+// Since the text before the value now ends a text element, the original text element is
+// ensured to preserve its whitespace.
+func (r *Replacer) splitRun(run *Run, runs string) string {
+	r.preserveSpace(run)
+	textOpenTag := string(r.document[run.Text.OpenTag.Start:run.Text.OpenTag.End])
+	return "</w:t></w:r>" + runs + "<w:r>" + runProperties(r.document, run) + textOpenTag
+}
+
+// textValue returns the escaped value which is inserted into the text element of the given run.
+// Newlines are converted into breaks, the text following a break is put into a text element with the same
+// attributes (e.g. 'xml:lang') as the one of the run. If a line of the value starts or ends with whitespace,
+// the text element of the run is ensured to preserve it.
+func (r *Replacer) textValue(run *Run, value string) string {
+	if hasSurroundingSpace(value) {
+		r.preserveSpace(run)
+	}
+	textOpenTag := string(r.document[run.Text.OpenTag.Start:run.Text.OpenTag.End])
+	return escapeTextValue(value, textOpenTag)
+}
+
+// hasSurroundingSpace returns true if a line of the value starts or ends with whitespace,
+// which is only kept by text elements preserving whitespace.
+func hasSurroundingSpace(value string) bool {
+	for _, line := range strings.Split(value, "\n") {
+		if strings.TrimSpace(line) != line {
+			return true
+		}
+	}
+	return false
+}
+
+// preserveSpace ensures that the text element of the given run has the 'xml:space="preserve"' attribute set.
+// If the attribute needs to be added, the run and all following runs are shifted accordingly.
+func (r *Replacer) preserveSpace(run *Run) {
+	openTag := r.document[run.Text.OpenTag.Start:run.Text.OpenTag.End]
+	if bytes.Contains(openTag, []byte("xml:space")) {
+		return
+	}
+
+	attribute := []byte(` xml:space="preserve"`)
+	insertPos := run.Text.OpenTag.End - 1 // before the closing '>'
+	deltaLength := int64(len(attribute))
+
+	docBytes := r.document
+	docBytes = append(docBytes[:insertPos], append(attribute, docBytes[insertPos:]...)...)
+	r.document = docBytes
+	r.BytesChanged += deltaLength
+
+	// the positions of all following runs, including the rest of the given run, moved
+	for _, following := range r.distinctRuns {
+		if following == run || following.OpenTag.Start < run.OpenTag.Start {
+			continue
+		}
+		following.shift(deltaLength)
+	}
+	run.Text.OpenTag.End += deltaLength
+	run.Text.CloseTag.Start += deltaLength
+	run.Text.CloseTag.End += deltaLength
+	run.CloseTag.Start += deltaLength
+	run.CloseTag.End += deltaLength
+}
+
 // replaceFragmentValue will replace the fragment text with the given value, adjusting all following
 // fragments afterwards.
 func (r *Replacer) replaceFragmentValue(fragment *PlaceholderFragment, value string) {
@@ -236,7 +371,7 @@ func (r *Replacer) getDistinctRuns(placeholder []*Placeholder) []*Run {
 	var seenRuns []int
 	seen := func(runID int) bool {
 		for _, id := range seenRuns {
-			if runId == id {
+			if runID == id {
 				return true
 			}
 		}
@@ -255,8 +390,36 @@ func (r *Replacer) getDistinctRuns(placeholder []*Placeholder) []*Run {
 	return runs
 }
 
+// ownRunPropertiesRegex matches the complete run properties element of a single run. Unlike RunPropertiesRegex,
+// it matches up to the last closing tag, since the properties may contain nested run properties (e.g. of <w:rPrChange>).
+var ownRunPropertiesRegex = regexp.MustCompile(`(?s)<w:rPr>.*</w:rPr>|<w:rPr/>`)
+
+// runProperties returns the raw run properties (<w:rPr>) of the given run.
+// If the run has no properties, an empty string is returned.
+func runProperties(docBytes []byte, run *Run) string {
+	return string(ownRunPropertiesRegex.Find(docBytes[run.OpenTag.End:run.Text.OpenTag.Start]))
+}
+
+// escapeTextValue escapes the given value so it can be inserted into a text element.
+// Newlines are converted into breaks (<w:br/>), reopening the text element with the given textOpenTag.
+func escapeTextValue(value string, textOpenTag string) string {
+	// ensure html escaping of special chars
+	return strings.Replace(html.EscapeString(value), "\n", "</w:t><w:br/>"+textOpenTag, -1)
+}
+
 // Bytes returns the document bytes.
 // If called after Replace(), the bytes will be modified.
 func (r *Replacer) Bytes() []byte {
 	return r.document
 }
+
+// contains returns true if there is at least one placeholder with the given key.
+func (r *Replacer) contains(placeholderKey string) bool {
+	placeholderKey = AddPlaceholderDelimiter(placeholderKey)
+	for _, placeholder := range r.placeholders {
+		if placeholder.Text(r.document) == placeholderKey {
+			return true
+		}
+	}
+	return false
+}
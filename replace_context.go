@@ -0,0 +1,65 @@
+package docx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrReplaceTimeout is returned by ReplaceAllTimeout if replacing did not finish in time.
+var ErrReplaceTimeout = errors.New("replacing timed out")
+
+// ReplaceAllContext is like ReplaceAll, but stops replacing once the given context is done.
+// The context is checked before every file and every key, the error of the context is returned wrapped.
+// Note that the document is left partially replaced in this case, it should be discarded.
+func (d *Document) ReplaceAllContext(ctx context.Context, placeholderMap PlaceholderMap) error {
+	defer d.shareMedia()()
+	placeholderMap, _ = expandIndexedKeys(dereferenceValues(placeholderMap))
+	placeholderMap = withoutNilPointers(placeholderMap)
+	for _, name := range d.replaceOrder() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("replacing aborted: %w", err)
+		}
+		changedBytes, err := d.replaceContext(ctx, placeholderMap, name)
+		if err != nil && errors.Is(err, ctx.Err()) {
+			// keep the file consistent with its replacer, even though not all keys were replaced
+			d.files[name] = changedBytes
+			return fmt.Errorf("replacing aborted: %w", err)
+		}
+		if err != nil {
+			return err
+		}
+
+		// only the map based replacements pass the remaining placeholders to the MissingKeyFunc
+		replacer := d.fileReplacers[name]
+		if _, err = d.replaceMissing(replacer, placeholderMap); err != nil {
+			return err
+		}
+		changedBytes = replacer.Bytes()
+
+		err = d.SetFile(name, changedBytes)
+		if err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("replacing aborted: %w", err)
+	}
+	return d.replaceUnparsedParts(placeholderMap)
+}
+
+// ReplaceAllTimeout is like ReplaceAll, but stops replacing after the given duration.
+// It protects against documents which would take unreasonably long to replace.
+// If the timeout is exceeded, an error wrapping ErrReplaceTimeout is returned and the document is left
+// partially replaced, it should be discarded.
+func (d *Document) ReplaceAllTimeout(placeholderMap PlaceholderMap, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := d.ReplaceAllContext(ctx, placeholderMap)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrReplaceTimeout, timeout)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package docx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDocument_ReplaceAllContext(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAllContext(context.Background(), PlaceholderMap{"key": "REPLACED"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "REPLACED") {
+		t.Error("placeholder was not replaced")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = doc.ReplaceAllContext(ctx, PlaceholderMap{"key-with-dash": "DASH"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, have=%v", err)
+	}
+	if strings.Contains(doc.Text(), "DASH") {
+		t.Error("replacing must stop once the context is done")
+	}
+}
+
+func TestDocument_ReplaceAllTimeout(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAllTimeout(PlaceholderMap{"key": "REPLACED"}, time.Minute)
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "REPLACED") {
+		t.Error("placeholder was not replaced")
+	}
+
+	err = doc.ReplaceAllTimeout(PlaceholderMap{"key-with-dash": "DASH"}, -time.Second)
+	if !errors.Is(err, ErrReplaceTimeout) {
+		t.Errorf("expected ErrReplaceTimeout, have=%v", err)
+	}
+}
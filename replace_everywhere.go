@@ -0,0 +1,105 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+)
+
+// additionalParts are the text parts which are not replaced by ReplaceAll, but by ReplaceEverywhere.
+// Text boxes are part of the file they are anchored in and need not be listed.
+var additionalParts = []string{FootnotesXml, EndnotesXml, CommentsXml, GlossaryDocumentXml}
+
+// ReplaceEverywhere replaces the placeholders of the placeholderMap in every text part of the document:
+// the main document, headers, footers, footnotes, endnotes, comments, the glossary document, altChunk parts and diagrams.
+// Charts are replaced as well if enabled by SetReplaceCharts.
+//
+// Unlike ReplaceAll, the parts which are not opened by default are loaded first.
+// Parts which cannot be parsed are skipped and reported as a warning in the returned stats.
+// Once loaded, the parts are also subject to later replacements and are written with the document.
+func (d *Document) ReplaceEverywhere(placeholderMap PlaceholderMap) (ReplaceStats, error) {
+	warnings := d.loadAdditionalParts()
+	stats, err := d.replaceWithStats(placeholderMap)
+	stats.Warnings = append(warnings, stats.Warnings...)
+	return stats, err
+}
+
+// replaceWithStats replaces the placeholders of the placeholderMap in all loaded text parts and the parts
+// which are not parsed (altChunk parts, diagrams and charts), summarizing the result.
+func (d *Document) replaceWithStats(placeholderMap PlaceholderMap) (ReplaceStats, error) {
+	defer d.shareMedia()()
+	stats := newReplaceStats()
+	placeholderMap, indexed := expandIndexedKeys(dereferenceValues(placeholderMap))
+	placeholderMap = withoutNilPointers(placeholderMap)
+
+	used := make(map[string]bool)
+	keys := placeholderMap.sortedKeys()
+	for _, name := range d.replaceOrder() {
+		replacer := d.fileReplacers[name]
+		replacedBefore := replacer.ReplaceCount
+
+		for _, key := range keys {
+			err := d.replaceValue(replacer, key, placeholderMap[key], -1)
+			if errors.Is(err, ErrPlaceholderNotFound) {
+				continue
+			}
+			if err != nil {
+				return stats, fmt.Errorf("unable to replace %s in %s: %s", key, name, err)
+			}
+			used[key] = true
+			if source, ok := indexed[key]; ok {
+				used[source] = true
+			}
+		}
+		if _, err := d.replaceMissing(replacer, placeholderMap); err != nil {
+			return stats, fmt.Errorf("unable to replace missing keys in %s: %s", name, err)
+		}
+
+		if replaced := replacer.ReplaceCount - replacedBefore; replaced > 0 {
+			stats.Parts[name] = replaced
+			stats.Replaced += replaced
+		}
+		if err := d.SetFile(name, replacer.Bytes()); err != nil {
+			return stats, err
+		}
+	}
+
+	if err := d.replaceUnparsedParts(placeholderMap); err != nil {
+		return stats, err
+	}
+
+	for key := range placeholderMap {
+		if _, generated := indexed[key]; !used[key] && !generated {
+			stats.UnusedKeys = append(stats.UnusedKeys, key)
+		}
+	}
+	sort.Strings(stats.UnusedKeys)
+
+	return stats, nil
+}
+
+// loadAdditionalParts reads and parses all additional parts of the archive which have not been loaded yet.
+// Parts which cannot be parsed are skipped, the returned warnings describe why.
+func (d *Document) loadAdditionalParts() (warnings []string) {
+	for _, name := range additionalParts {
+		if _, loaded := d.files[name]; loaded {
+			continue
+		}
+		data, err := d.readFile(name)
+		if err != nil {
+			continue // the document does not have this part
+		}
+
+		d.files[name] = mergeHyphenElements(data)
+		if err := d.parseFile(name); err != nil {
+			delete(d.files, name)
+			warning := fmt.Sprintf("skipping %s: %s", name, err)
+			log.Println(warning)
+			warnings = append(warnings, warning)
+			continue
+		}
+		d.additionalFiles = append(d.additionalFiles, name)
+	}
+	return warnings
+}
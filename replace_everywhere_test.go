@@ -0,0 +1,57 @@
+package docx
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceEverywhere(t *testing.T) {
+	doc, err := Open("./test/everywhere.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	stats, err := doc.ReplaceEverywhere(PlaceholderMap{"title": "Report", "source": "Wikipedia", "unused": "value"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	if stats.Replaced != 2 {
+		t.Errorf("unexpected replace count, want=%d, have=%d", 2, stats.Replaced)
+	}
+	expectedParts := map[string]int{DocumentXml: 1, FootnotesXml: 1}
+	if !reflect.DeepEqual(stats.Parts, expectedParts) {
+		t.Errorf("unexpected parts, want=%v, have=%v", expectedParts, stats.Parts)
+	}
+	if !reflect.DeepEqual(stats.UnusedKeys, []string{"unused"}) {
+		t.Errorf("unexpected unused keys, have=%v", stats.UnusedKeys)
+	}
+	// the comments are malformed and must be skipped
+	if len(stats.Warnings) != 1 || !strings.Contains(stats.Warnings[0], CommentsXml) {
+		t.Errorf("expected a warning for %s, have=%v", CommentsXml, stats.Warnings)
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open written document", err)
+		return
+	}
+	footnotes, err := written.readFile(FootnotesXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(footnotes), "<w:t>Wikipedia</w:t>") {
+		t.Error("replaced footnotes were not written")
+	}
+}
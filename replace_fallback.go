@@ -0,0 +1,13 @@
+package docx
+
+// ReplaceAllWithFallback works like ReplaceAll, but looks the keys up in two maps, e.g. to apply per-tenant
+// overrides on top of global defaults without merging the maps beforehand.
+// The precedence of a key is:
+//  1. the value of primary, if primary contains the key (even if the value is empty or nil)
+//  2. the value of defaults, if defaults contains the key
+//  3. the value of the MissingKeyFunc (see SetOnMissingKey), otherwise the placeholder is left untouched
+//
+// Neither map is modified.
+func (d *Document) ReplaceAllWithFallback(primary, defaults PlaceholderMap) error {
+	return d.ReplaceAll(defaults.Merge(primary))
+}
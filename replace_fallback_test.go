@@ -0,0 +1,48 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceAllWithFallback(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var missing []string
+	doc.SetOnMissingKey(func(key string, pos Position) (string, bool) {
+		missing = append(missing, key)
+		return "", false
+	})
+
+	primary := PlaceholderMap{"key": "PRIMARY", "key-with-dash": ""}
+	defaults := PlaceholderMap{"key": "DEFAULT", "key-with-dash": "DEFAULT", "key_with_underscore": "FALLBACK"}
+	if err = doc.ReplaceAllWithFallback(primary, defaults); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	text := doc.Text()
+	if !strings.Contains(text, "PRIMARY") || !strings.Contains(text, "FALLBACK") {
+		t.Errorf("expected the values of both maps inside the text: %s", text)
+	}
+	// an empty primary value takes precedence over the default
+	if strings.Contains(text, "DEFAULT") {
+		t.Errorf("default value must not override the primary value: %s", text)
+	}
+	for _, key := range missing {
+		if _, exists := defaults[key]; exists {
+			t.Errorf("key %s was passed to the MissingKeyFunc although it has a default", key)
+		}
+	}
+	if len(missing) == 0 {
+		t.Error("expected the remaining keys to be passed to the MissingKeyFunc")
+	}
+	if len(primary) != 2 || len(defaults) != 3 {
+		t.Error("the maps must not be modified")
+	}
+}
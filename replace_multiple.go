@@ -0,0 +1,56 @@
+package docx
+
+import (
+	"errors"
+)
+
+// KV is a single key-value pair of ReplaceMultiple.
+type KV struct {
+	Key   string
+	Value interface{}
+}
+
+// SetRescanValues defines whether ReplaceMultiple scans the inserted values for placeholders.
+// If enabled, the files are parsed again after every pair, so that a value may contain the placeholder of a key
+// which is replaced by one of the following pairs. Keys of preceding pairs are not replaced again.
+// Rescanning is disabled by default, inserted values are then never treated as placeholders.
+func (d *Document) SetRescanValues(enabled bool) {
+	d.rescanValues = enabled
+}
+
+// ReplaceMultiple replaces all occurrences of the given keys one after another, in the given order.
+// Unlike ReplaceAll, the order of the replacements is defined, which matters if values are rescanned
+// (see SetRescanValues). Keys which do not exist in the document are skipped.
+// Like ReplaceAll, the elements of slice values can be accessed by their index, the indexed keys (e.g. {tags[0]})
+// are replaced right after the key of the slice.
+func (d *Document) ReplaceMultiple(pairs ...KV) error {
+	defer d.shareMedia()()
+	for _, pair := range indexedPairs(pairs) {
+		for _, name := range d.replaceOrder() {
+			replacer := d.fileReplacers[name]
+			replacedBefore := replacer.ReplaceCount
+
+			err := d.replaceValue(replacer, pair.Key, pair.Value, -1)
+			if errors.Is(err, ErrPlaceholderNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err = d.SetFile(name, replacer.Bytes()); err != nil {
+				return err
+			}
+
+			if d.rescanValues && replacer.ReplaceCount > replacedBefore {
+				if err = d.parseFile(name); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := d.replaceUnparsedParts(PlaceholderMap{pair.Key: pair.Value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
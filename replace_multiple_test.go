@@ -0,0 +1,83 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceMultiple(t *testing.T) {
+	tests := []struct {
+		name     string
+		rescan   bool
+		expected []string
+		missing  []string
+	}{
+		{
+			name:     "without rescan",
+			rescan:   false,
+			expected: []string{"Dear {key_with_underscore}", "{key_with_underscore}"},
+			missing:  []string{"{key}"},
+		},
+		{
+			name:     "with rescan",
+			rescan:   true,
+			expected: []string{"Dear John"},
+			missing:  []string{"{key}", "{key_with_underscore}"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Open("./test/template.docx")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer doc.Close()
+
+			doc.SetRescanValues(tt.rescan)
+			err = doc.ReplaceMultiple(
+				KV{Key: "key", Value: "Dear {key_with_underscore}"},
+				KV{Key: "key_with_underscore", Value: "John"},
+				KV{Key: "does-not-exist", Value: "foo"},
+			)
+			if err != nil {
+				t.Error("replacing failed", err)
+				return
+			}
+
+			text := doc.Text()
+			for _, s := range tt.expected {
+				if !strings.Contains(text, s) {
+					t.Errorf("expected text %q in document", s)
+				}
+			}
+			for _, s := range tt.missing {
+				if strings.Contains(text, s) {
+					t.Errorf("unexpected text %q in document", s)
+				}
+			}
+		})
+	}
+}
+
+func TestDocument_ReplaceMultiple_IndexedKeys(t *testing.T) {
+	doc, err := Open("./test/indexed.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	amounts := [2]float64{1.5, 2.5}
+	err = doc.ReplaceMultiple(KV{Key: "tags", Value: []string{"red", "green"}}, KV{Key: "amounts", Value: &amounts})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	for _, expected := range []string{"Tags: red, green and {tags[5]}", "Amount: 2.5"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q inside the document", expected)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package docx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReplaceAllReader replaces the placeholders with the values read from r, which contains one 'key=value' pair
+// per line like a .env file. Lines are split at the first '=', so values may contain '=' as well.
+// Keys and values are trimmed of surrounding whitespace, blank lines and lines starting with '#' are ignored.
+//
+// Like ReplaceAll, all loaded text parts are replaced. The returned stats report how many placeholders were
+// replaced in which part and which keys were not used.
+func (d *Document) ReplaceAllReader(r io.Reader) (ReplaceStats, error) {
+	placeholderMap, err := readKeyValues(r)
+	if err != nil {
+		return newReplaceStats(), err
+	}
+	return d.replaceWithStats(placeholderMap)
+}
+
+// readKeyValues reads the 'key=value' lines of r into a PlaceholderMap.
+// If a key occurs multiple times, the last value wins.
+func readKeyValues(r io.Reader) (PlaceholderMap, error) {
+	placeholderMap := make(PlaceholderMap)
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pair := strings.SplitN(line, "=", 2)
+		if len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return nil, fmt.Errorf("invalid line %d, expected key=value: %s", lineNumber, line)
+		}
+		placeholderMap[strings.TrimSpace(pair[0])] = strings.TrimSpace(pair[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read key-value pairs: %s", err)
+	}
+	return placeholderMap, nil
+}
@@ -0,0 +1,37 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceAllReader(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	input := "# generated by CI\n\nkey = REPLACED\nkey-with-dash=a=b\n  unused=value  \n"
+	stats, err := doc.ReplaceAllReader(strings.NewReader(input))
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	if !strings.Contains(text, "REPLACED") || !strings.Contains(text, "a=b") {
+		t.Error("placeholders were not replaced")
+	}
+	if stats.Replaced == 0 || stats.Parts[DocumentXml] == 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if !reflect.DeepEqual(stats.UnusedKeys, []string{"unused"}) {
+		t.Errorf("unexpected unused keys, want=%v, have=%v", []string{"unused"}, stats.UnusedKeys)
+	}
+
+	if _, err = doc.ReplaceAllReader(strings.NewReader("key\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("expected an error for the invalid line, have=%v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package docx
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// ReplaceStruct replaces the placeholders with the fields of the given struct (or pointer to a struct), e.g.
+// to fill a template from a typed data model instead of building a PlaceholderMap.
+//
+// The key of a field is the name given by its 'docx' tag (e.g. `docx:"customer_name"`), or the field name if it is
+// untagged. Fields tagged with `docx:"-"` and unexported fields are skipped. The fields of nested structs are
+// accessed by dotted keys, e.g. {Customer.Name}, while the fields of embedded structs are promoted like in
+// encoding/json. Structs which are values on their own (time.Time, FormattedText, Symbol and every fmt.Stringer)
+// are inserted as they are. Like the nil pointers of ReplaceAll, nil pointer fields are treated like missing keys:
+// their placeholders are left untouched or passed to the MissingKeyFunc (see SetOnMissingKey).
+func (d *Document) ReplaceStruct(v interface{}) error {
+	placeholderMap, err := structPlaceholderMap(v)
+	if err != nil {
+		return err
+	}
+	return d.ReplaceAll(placeholderMap)
+}
+
+// structPlaceholderMap returns the PlaceholderMap of all fields of the given struct, see ReplaceStruct.
+func structPlaceholderMap(v interface{}) (PlaceholderMap, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unable to replace %T, a struct is required", v)
+	}
+
+	placeholderMap := make(PlaceholderMap)
+	flattenStruct(placeholderMap, "", value)
+	return placeholderMap, nil
+}
+
+// flattenStruct adds all fields of the struct value to the placeholderMap, the keys of the fields of nested structs
+// are prefixed with the keys of their parents.
+func flattenStruct(placeholderMap PlaceholderMap, prefix string, value reflect.Value) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue // unexported
+		}
+		key, tagged := field.Tag.Lookup("docx")
+		if key == "-" {
+			continue
+		}
+		if !tagged || key == "" {
+			key = field.Name
+		}
+
+		fieldValue := value.Field(i)
+		for fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() == reflect.Struct && !isStructValue(fieldValue) {
+			// the fields of embedded structs are promoted unless the embedded struct is tagged
+			if field.Anonymous && !tagged {
+				flattenStruct(placeholderMap, prefix, fieldValue)
+			} else {
+				flattenStruct(placeholderMap, prefix+key+".", fieldValue)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue // unexported embedded non-struct type
+		}
+		// nil pointers are passed on, ReplaceAll treats them like missing keys
+		placeholderMap[prefix+key] = fieldValue.Interface()
+	}
+}
+
+// isStructValue returns true if the struct is a replacement value on its own rather than a container of fields.
+func isStructValue(value reflect.Value) bool {
+	if !value.CanInterface() {
+		return false
+	}
+	switch value.Interface().(type) {
+	case time.Time, FormattedText, Symbol, fmt.Stringer:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testAddress struct {
+	City string `docx:"city"`
+	Zip  int
+}
+
+type testAudit struct {
+	CreatedBy string
+}
+
+type testInvoice struct {
+	testAudit
+	Number   string       `docx:"number"`
+	Customer *testAddress `docx:"customer"`
+	Billing  *testAddress
+	Date     time.Time
+	Total    FormattedText
+	Internal string `docx:"-"`
+	secret   string
+}
+
+func TestStructPlaceholderMap(t *testing.T) {
+	date := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	invoice := testInvoice{
+		testAudit: testAudit{CreatedBy: "Jane"},
+		Number:    "INV-1",
+		Customer:  &testAddress{City: "Berlin", Zip: 10115},
+		Date:      date,
+		Total:     FormattedText{Text: "42.00", Bold: true},
+		Internal:  "hidden",
+		secret:    "hidden",
+	}
+
+	placeholderMap, err := structPlaceholderMap(&invoice)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := PlaceholderMap{
+		"CreatedBy":     "Jane",
+		"number":        "INV-1",
+		"customer.city": "Berlin",
+		"customer.Zip":  10115,
+		"Billing":       (*testAddress)(nil),
+		"Date":          date,
+		"Total":         FormattedText{Text: "42.00", Bold: true},
+	}
+	if !reflect.DeepEqual(placeholderMap, expected) {
+		t.Errorf("unexpected placeholder map, want=%v, have=%v", expected, placeholderMap)
+	}
+
+	if _, err = structPlaceholderMap("not a struct"); err == nil {
+		t.Error("expected an error for values which are no structs")
+	}
+}
+
+func TestDocument_ReplaceStruct(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	data := struct {
+		Key        string `docx:"key"`
+		Underscore string `docx:"key_with_underscore"`
+	}{Key: "REPLACED", Underscore: "UNDERSCORE"}
+	if err = doc.ReplaceStruct(data); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	for _, expected := range []string{"REPLACED", "UNDERSCORE"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+}
+
+func TestDocument_ReplaceStruct_NilPointer(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var missing []string
+	doc.SetOnMissingKey(func(key string, pos Position) (string, bool) {
+		missing = append(missing, key)
+		return "", false
+	})
+
+	data := struct {
+		Key        *string `docx:"key"`
+		Underscore string  `docx:"key_with_underscore"`
+	}{Underscore: "UNDERSCORE"}
+	if err = doc.ReplaceStruct(data); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), "{key}") {
+		t.Error("placeholder of a nil pointer field was not left untouched")
+	}
+	found := false
+	for _, key := range missing {
+		found = found || key == "key"
+	}
+	if !found {
+		t.Error("nil pointer field was not treated like a missing key")
+	}
+}
@@ -3,6 +3,7 @@ package docx
 import (
 	"encoding/xml"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -67,3 +68,31 @@ func TestReplacer_Replace(t *testing.T) {
 	// cleanup
 	_ = os.Remove("./test/out.docx")
 }
+
+func TestReplacer_Replace_TextAttributes(t *testing.T) {
+	docBytes := readFile(t, "./test/text_attributes.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	for key, value := range map[string]string{"address": "Street 1\n 12345 City", "padded": " value ", "plain": "value"} {
+		if err := replacer.Replace(key, value); err != nil {
+			t.Errorf("replacing %s failed: %s", key, err)
+		}
+	}
+
+	result := string(replacer.Bytes())
+	for _, expected := range []string{
+		// the text following a break keeps all attributes of the text element
+		`<w:t xml:space="preserve" xml:lang="de-DE">Adresse: Street 1</w:t><w:br/><w:t xml:space="preserve" xml:lang="de-DE"> 12345 City</w:t>`,
+		// leading and trailing whitespace is preserved
+		`<w:t xml:lang="fr-FR" xml:space="preserve"> value </w:t>`,
+		// the text element is left as it is if there is no whitespace to preserve
+		`<w:t xml:lang="fr-FR">value end</w:t>`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("expected %s inside the result", expected)
+		}
+	}
+	if err := xml.Unmarshal(replacer.Bytes(), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
@@ -0,0 +1,74 @@
+package docx
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReplacementRecord describes a single replaced placeholder, see ReplaceAllVerbose.
+type ReplacementRecord struct {
+	Part     string // Part is the path of the part which contained the placeholder, e.g. 'word/header1.xml'
+	Key      string // Key is the key of the placeholder
+	OldText  string // OldText is the text of the placeholder, e.g. '{name}'
+	NewValue string // NewValue is the text which was inserted, without any formatting
+	Start    int64  // Start is the byte position of the placeholder inside the part before replacing
+	End      int64  // End is the byte position after the placeholder inside the part before replacing
+}
+
+// ReplaceAllVerbose works like ReplaceAll, but additionally returns a record of every replaced placeholder,
+// e.g. to persist an audit trail of generated documents. The records cover all parsed parts (the main document,
+// the headers, the footers and the loaded additional parts) and are ordered by part, in reading order,
+// and by position. The replacements inside the parts which are not parsed (altChunk parts, diagrams and charts)
+// are not recorded, since they do not have placeholders.
+// The records of the replacements made before an error occurred are returned together with the error.
+func (d *Document) ReplaceAllVerbose(placeholderMap PlaceholderMap) ([]ReplacementRecord, error) {
+	parts := append(d.readingOrder(), d.additionalFiles...)
+	partOrder := make(map[string]int, len(parts))
+	for i, part := range parts {
+		partOrder[part] = i
+	}
+
+	var records []ReplacementRecord
+	for name, replacer := range d.fileReplacers {
+		name, replacer := name, replacer
+
+		// the positions change while replacing, record the original ones
+		positions := make(map[*Placeholder]Position)
+		for _, placeholder := range replacer.Unreplaced() {
+			positions[placeholder] = Position{Start: placeholder.StartPos(), End: placeholder.EndPos()}
+		}
+
+		replacer.onReplace = func(placeholder *Placeholder, value string) {
+			text := placeholder.Text(replacer.document)
+			records = append(records, ReplacementRecord{
+				Part:     name,
+				Key:      RemovePlaceholderDelimiter(text),
+				OldText:  text,
+				NewValue: insertedText(value),
+				Start:    positions[placeholder].Start,
+				End:      positions[placeholder].End,
+			})
+		}
+		defer func() {
+			replacer.onReplace = nil
+		}()
+	}
+
+	err := d.ReplaceAll(placeholderMap)
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Part != records[j].Part {
+			return partOrder[records[i].Part] < partOrder[records[j].Part]
+		}
+		return records[i].Start < records[j].Start
+	})
+	return records, err
+}
+
+// insertedText returns the text of an escaped value which is inserted into a text element.
+// The value may close the text element and insert runs of its own, see Replacer.splitRun.
+// Breaks are returned as newlines.
+func insertedText(value string) string {
+	value = strings.Replace(value, "<w:br/>", "<w:t>\n</w:t>", -1)
+	return elementText([]byte("<w:t>" + value + "</w:t>"))
+}
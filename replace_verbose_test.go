@@ -0,0 +1,65 @@
+package docx
+
+import (
+	"testing"
+)
+
+func TestDocument_ReplaceAllVerbose(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	records, err := doc.ReplaceAllVerbose(PlaceholderMap{
+		"key":           "first\nsecond",
+		"key-with-dash": FormattedText{Text: "formatted", Bold: true},
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	parts := make(map[string]int)
+	for i, record := range records {
+		parts[record.Part]++
+		switch record.Key {
+		case "key":
+			if record.NewValue != "first\nsecond" {
+				t.Errorf("unexpected value of %s, have=%q", record.Key, record.NewValue)
+			}
+		case "key-with-dash":
+			if record.NewValue != "formatted" {
+				t.Errorf("unexpected value of %s, have=%q", record.Key, record.NewValue)
+			}
+		default:
+			t.Errorf("unexpected record of %s", record.Key)
+		}
+		if record.OldText != AddPlaceholderDelimiter(record.Key) {
+			t.Errorf("unexpected old text, have=%s", record.OldText)
+		}
+		if record.Part == DocumentXml && record.End-record.Start == int64(len(record.OldText)) {
+			if documentXml[record.Start:record.End] != record.OldText {
+				t.Errorf("position does not match the original placeholder, have=%s", documentXml[record.Start:record.End])
+			}
+		}
+		if i > 0 && records[i-1].Part == record.Part && records[i-1].Start > record.Start {
+			t.Error("records are not ordered by position")
+		}
+	}
+
+	// the headers and footers contain the key as well
+	for _, part := range append(append([]string{}, doc.headerFiles...), doc.footerFiles...) {
+		if parts[part] == 0 {
+			t.Errorf("no replacement recorded for %s", part)
+		}
+	}
+	if parts[DocumentXml] == 0 {
+		t.Error("no replacement recorded for the main document")
+	}
+	if records[0].Part != doc.readingOrder()[0] {
+		t.Errorf("records are not ordered by part, first=%s", records[0].Part)
+	}
+}
@@ -1,9 +1,12 @@
 package docx
 
-import "fmt"
+import (
+	"fmt"
+	"sync/atomic"
+)
 
 var (
-	runId = 0 // global Run ID counter. Incremented by NewRun()
+	runId int64 = 0 // global Run ID counter. Incremented by NewRun()
 )
 
 // TagPair describes an opening and closing tag position.
@@ -17,9 +20,14 @@ type TagPair struct {
 // In our case the run is specified by four byte positions (start and end tag).
 type Run struct {
 	TagPair
-	ID      int
-	Text    TagPair // Text is the <w:t> tag pair which is always within a run and cannot be standalone.
-	HasText bool
+	ID         int
+	Text       TagPair // Text is the <w:t> tag pair which is always within a run and cannot be standalone.
+	HasText    bool
+	Paragraph  int      // Paragraph identifies the paragraph (<w:p>) of the run, 0 if the run is not inside a paragraph.
+	Properties RunProps // Properties are the run properties (<w:rPr>) of a run with text, as they were parsed.
+
+	text       string // text is the text of the run as read by the RunParser, see GetText
+	textParsed bool   // textParsed is true if the text was read by the RunParser
 }
 
 // NewEmptyRun returns a new, empty run which has only an ID set.
@@ -30,21 +38,41 @@ func NewEmptyRun() *Run {
 }
 
 // GetText returns the text of the run, if any.
-// If the run does not have a text or the given byte slice is too small, an empty string is returned
+// If the run does not have a text, the text positions are invalid or the given byte slice is too small,
+// an empty string is returned
+//
+// The text of the runs found by the RunParser is read once while parsing, those runs return it without
+// reading the given bytes again. Hence the text of such a run must not be modified in the bytes afterwards,
+// the bytes must be parsed again instead (e.g. the placeholders of a replaced document are parsed from new runs).
 func (r *Run) GetText(documentBytes []byte) string {
 	if !r.HasText {
 		return ""
 	}
+	if r.textParsed {
+		return r.text
+	}
 	startPos := r.Text.OpenTag.End
 	endPos := r.Text.CloseTag.Start
 
-	if int64(len(documentBytes)) < startPos || int64(len(documentBytes)) < endPos {
+	if int64(len(documentBytes)) < startPos || int64(len(documentBytes)) < endPos || startPos > endPos {
 		return ""
 	}
 
 	return string(documentBytes[startPos:endPos])
 }
 
+// shift will shift all tag positions of the run by the given amount.
+func (r *Run) shift(deltaLength int64) {
+	r.OpenTag.Start += deltaLength
+	r.OpenTag.End += deltaLength
+	r.CloseTag.Start += deltaLength
+	r.CloseTag.End += deltaLength
+	r.Text.OpenTag.Start += deltaLength
+	r.Text.OpenTag.End += deltaLength
+	r.Text.CloseTag.Start += deltaLength
+	r.Text.CloseTag.End += deltaLength
+}
+
 // String returns a string representation of the run, given the source bytes.
 // It may be helpful in debugging.
 func (r *Run) String(bytes []byte) string {
@@ -94,12 +122,12 @@ func (dr *DocumentRuns) Pop() *Run {
 }
 
 // NewRunID returns the next Fragment.ID
+// The counter is incremented atomically, so documents can be parsed concurrently (see Clone).
 func NewRunID() int {
-	runId += 1
-	return runId
+	return int(atomic.AddInt64(&runId, 1))
 }
 
 // ResetRunIdCounter will reset the runId counter to 0
 func ResetRunIdCounter() {
-	runId = 0
+	atomic.StoreInt64(&runId, 0)
 }
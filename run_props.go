@@ -0,0 +1,102 @@
+package docx
+
+import (
+	"encoding/xml"
+	"math"
+	"strconv"
+)
+
+// RunProps are the most common properties of a run (<w:rPr>).
+// Only the direct formatting of the run is captured, formatting inherited from styles is not resolved.
+type RunProps struct {
+	Bold   bool
+	Italic bool
+	Size   float64 // Size is the font size in points, 0 if not set
+	Color  string  // Color is the hex RGB value of the text color, e.g. 'FF0000'
+	Font   string  // Font is the name of the font used for latin text
+
+	ThemeColor string // ThemeColor is the theme color of the text, e.g. 'accent1'
+}
+
+// runPropsElement is the subset of the run properties element which is read into RunProps.
+type runPropsElement struct {
+	Bold   *onOffElement `xml:"b"`
+	Italic *onOffElement `xml:"i"`
+	Size   *struct {
+		Value string `xml:"val,attr"`
+	} `xml:"sz"`
+	Color *struct {
+		Value      string `xml:"val,attr"`
+		ThemeColor string `xml:"themeColor,attr"`
+	} `xml:"color"`
+	Fonts *struct {
+		ASCII string `xml:"ascii,attr"`
+		HAnsi string `xml:"hAnsi,attr"`
+	} `xml:"rFonts"`
+}
+
+// onOffElement is a toggle property like bold (<w:b/>), which is enabled unless its value is explicitly off.
+type onOffElement struct {
+	Value string `xml:"val,attr"`
+}
+
+// on returns true if the toggle property is enabled.
+func (e *onOffElement) on() bool {
+	if e == nil {
+		return false
+	}
+	switch e.Value {
+	case "0", "false", "off":
+		return false
+	}
+	return true
+}
+
+// ParseRunProps parses the given run properties element (<w:rPr>).
+// If the element is empty or cannot be parsed, empty RunProps are returned.
+func ParseRunProps(runProperties []byte) RunProps {
+	var props RunProps
+	if len(runProperties) == 0 {
+		return props
+	}
+	element := new(runPropsElement)
+	if err := xml.Unmarshal(runProperties, element); err != nil {
+		return props
+	}
+
+	props.Bold = element.Bold.on()
+	props.Italic = element.Italic.on()
+	if element.Size != nil {
+		if halfPoints, err := strconv.Atoi(element.Size.Value); err == nil {
+			props.Size = float64(halfPoints) / 2
+		}
+	}
+	if element.Color != nil && element.Color.Value != "auto" {
+		props.Color = element.Color.Value
+	}
+	if element.Color != nil {
+		props.ThemeColor = element.Color.ThemeColor
+	}
+	if element.Fonts != nil {
+		props.Font = element.Fonts.ASCII
+		if props.Font == "" {
+			props.Font = element.Fonts.HAnsi
+		}
+	}
+	return props
+}
+
+// FormattedText returns a FormattedText of the given text, styled with the run properties.
+// The size is rounded to full points.
+func (p RunProps) FormattedText(text string) FormattedText {
+	return FormattedText{
+		Text:   text,
+		Bold:   p.Bold,
+		Italic: p.Italic,
+		Color:  p.Color,
+		Font:   p.Font,
+		Size:   int(math.Round(p.Size)),
+
+		ThemeColor: p.ThemeColor,
+	}
+}
@@ -0,0 +1,40 @@
+package docx
+
+import (
+	"testing"
+)
+
+func TestPlaceholder_RunProperties(t *testing.T) {
+	docBytes := readFile(t, "./test/run_props.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	expected := map[string]RunProps{
+		"{styled}": {Bold: true, Italic: false, Size: 10.5, Color: "FF0000", Font: "Arial"},
+		"{plain}":  {},
+	}
+	if len(replacer.placeholders) != len(expected) {
+		t.Errorf("unexpected placeholder count, want=%d, have=%d", len(expected), len(replacer.placeholders))
+		return
+	}
+	for _, placeholder := range replacer.placeholders {
+		text := placeholder.Text(docBytes)
+		if props := placeholder.RunProperties(); props != expected[text] {
+			t.Errorf("unexpected run properties of %s, want=%+v, have=%+v", text, expected[text], props)
+		}
+	}
+
+	formatted := replacer.placeholders[0].RunProperties().FormattedText("value")
+	if formatted != (FormattedText{Text: "value", Bold: true, Color: "FF0000", Font: "Arial", Size: 11}) {
+		t.Errorf("unexpected formatted text: %+v", formatted)
+	}
+}
+
+func TestParseRunProps_ThemeColor(t *testing.T) {
+	props := ParseRunProps([]byte(`<w:rPr><w:color w:val="4F81BD" w:themeColor="accent1"/></w:rPr>`))
+	if props.Color != "4F81BD" || props.ThemeColor != "accent1" {
+		t.Errorf("unexpected run properties: %+v", props)
+	}
+	if formatted := props.FormattedText("value"); formatted.ThemeColor != "accent1" {
+		t.Errorf("theme color was not carried over: %+v", formatted)
+	}
+}
@@ -0,0 +1,53 @@
+package docx
+
+import (
+	"regexp"
+	"sort"
+)
+
+var (
+	// headerFooterReferenceRegex matches the header and footer references of section properties
+	// (e.g. <w:headerReference w:type="first" r:id="rId8"/>) and captures the kind and the relationship id
+	headerFooterReferenceRegex = regexp.MustCompile(`<w:(header|footer)Reference\s[^>]*r:id="([^"]*)"`)
+)
+
+// loadSectionParts loads the headers and footers referenced by the section properties of the main document
+// which were not found by their path (see HeaderPathRegex and FooterPathRegex).
+// Every section may reference its own default, first-page and even-page header and footer, the parts are
+// resolved through the relationships of the main document and can be named arbitrarily.
+func (d *Document) loadSectionParts() error {
+	rels, err := d.readRelationships(d.mainPart)
+	if err != nil {
+		return err
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels {
+		if rel.TargetMode != ExternalTargetMode {
+			targets[rel.ID] = relationshipTarget(d.mainPart, rel.Target)
+		}
+	}
+
+	for _, reference := range headerFooterReferenceRegex.FindAllSubmatch(d.files[d.mainPart], -1) {
+		part, ok := targets[string(reference[2])]
+		if !ok {
+			continue
+		}
+		if _, loaded := d.files[part]; loaded {
+			continue
+		}
+		data, err := d.readFile(part)
+		if err != nil {
+			continue // broken reference, Word ignores it as well
+		}
+
+		d.files[part] = mergeHyphenElements(data)
+		if string(reference[1]) == "header" {
+			d.headerFiles = append(d.headerFiles, part)
+		} else {
+			d.footerFiles = append(d.footerFiles, part)
+		}
+	}
+	sort.Strings(d.headerFiles)
+	sort.Strings(d.footerFiles)
+	return nil
+}
@@ -0,0 +1,47 @@
+package docx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDocument_SectionParts(t *testing.T) {
+	doc, err := Open("./test/sections.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if _, err = doc.ReplaceEverywhere(PlaceholderMap{"title": "Report"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open written document", err)
+		return
+	}
+
+	parts := map[string]string{
+		DocumentXml:                "Section 1 Report",
+		"word/header_first.xml":    "First page Report",
+		"word/header_even.xml":     "Even page Report",
+		"word/footer_section2.xml": "Section 2 Report",
+	}
+	for part, expected := range parts {
+		if !strings.Contains(string(written.GetFile(part)), expected) {
+			t.Errorf("placeholder was not replaced in %s", part)
+		}
+	}
+	if !strings.Contains(written.Text(), "First page Report") {
+		t.Error("section headers are missing in the text")
+	}
+}
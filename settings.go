@@ -0,0 +1,268 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"strings"
+)
+
+const (
+	// SettingsXml is the relative path of the document settings inside the docx-archive.
+	SettingsXml = "word/settings.xml"
+	// SettingsRelationshipType is the type of the relationship which references the document settings.
+	SettingsRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/settings"
+	// settingsContentType is the content type of the document settings.
+	settingsContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.settings+xml"
+	// CompatibilityModeSetting is the name of the compatibility setting which declares the Word version
+	// the document targets.
+	CompatibilityModeSetting = "compatibilityMode"
+	// DefaultTabStopTwips is the distance between the automatic tab stops Word uses
+	// if the settings do not declare a default tab stop.
+	DefaultTabStopTwips = 720
+)
+
+// settingsElementOrder is the order of the child elements of <w:settings> as defined by the WordprocessingML schema.
+// New elements must be inserted according to this order, otherwise Word considers the document to be corrupt.
+var settingsElementOrder = []string{
+	"writeProtection", "view", "zoom", "removePersonalInformation", "removeDateAndTime", "doNotDisplayPageBoundaries",
+	"displayBackgroundShape", "printPostScriptOverText", "printFractionalCharacterWidth", "printFormsData",
+	"embedTrueTypeFonts", "embedSystemFonts", "saveSubsetFonts", "saveFormsData", "mirrorMargins",
+	"alignBordersAndEdges", "bordersDoNotSurroundHeader", "bordersDoNotSurroundFooter", "gutterAtTop",
+	"hideSpellingErrors", "hideGrammaticalErrors", "activeWritingStyle", "proofState", "formsDesign",
+	"attachedTemplate", "linkStyles", "stylePaneFormatFilter", "stylePaneSortMethod", "documentType", "mailMerge",
+	"revisionView", "trackRevisions", "doNotTrackMoves", "doNotTrackFormatting", "documentProtection",
+	"autoFormatOverride", "styleLockTheme", "styleLockQFSet", "defaultTabStop", "autoHyphenation",
+	"consecutiveHyphenLimit", "hyphenationZone", "doNotHyphenateCaps", "showEnvelope", "summaryLength",
+	"clickAndTypeStyle", "defaultTableStyle", "evenAndOddHeaders", "bookFoldRevPrinting", "bookFoldPrinting",
+	"bookFoldPrintingSheets", "drawingGridHorizontalSpacing", "drawingGridVerticalSpacing",
+	"displayHorizontalDrawingGridEvery", "displayVerticalDrawingGridEvery", "doNotUseMarginsForDrawingGridOrigin",
+	"drawingGridHorizontalOrigin", "drawingGridVerticalOrigin", "doNotShadeFormData", "noPunctuationKerning",
+	"characterSpacingControl", "printTwoOnOne", "strictFirstAndLastChars", "noLineBreaksAfter",
+	"noLineBreaksBefore", "savePreviewPicture", "doNotValidateAgainstSchema", "saveInvalidXml",
+	"ignoreMixedContent", "alwaysShowPlaceholderText", "doNotDemarcateInvalidXml", "saveXmlDataOnly",
+	"useXSLTWhenSaving", "saveThroughXslt", "showXMLTags", "alwaysMergeEmptyNamespace", "updateFields",
+	"hdrShapeDefaults", "footnotePr", "endnotePr", "compat", "docVars", "rsids", "mathPr", "attachedSchema",
+	"themeFontLang", "clrSchemeMapping", "doNotIncludeSubdocsInStats", "doNotAutoCompressPictures",
+	"forceUpgrade", "captions", "readModeInkLockDown", "smartTagType", "schemaLibrary", "shapeDefaults",
+	"doNotEmbedSmartTags", "decimalSymbol", "listSeparator",
+}
+
+// documentSettings is the subset of the document settings (<w:settings>) which is read by the lib.
+type documentSettings struct {
+	Compat struct {
+		Settings []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:"val,attr"`
+		} `xml:"compatSetting"`
+	} `xml:"compat"`
+	DocVars        []docVar `xml:"docVars>docVar"`
+	DefaultTabStop *struct {
+		Value int `xml:"val,attr"`
+	} `xml:"defaultTabStop"`
+}
+
+// docVar is a single document variable (<w:docVar>).
+type docVar struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"val,attr"`
+}
+
+// CompatibilityMode returns the compatibility mode declared in the document settings.
+// The mode is the Word version for which the document is laid out, e.g. '14' for Word 2010 or '15' for Word 2013 and newer.
+// Features which require a newer version might not be displayed by older clients.
+//
+// Documents without a declared compatibility mode (usually created with Word 2007 or older) or with unreadable
+// settings return an empty string.
+func (d *Document) CompatibilityMode() string {
+	settings, err := d.readSettings()
+	if err != nil {
+		return ""
+	}
+	for _, setting := range settings.Compat.Settings {
+		if setting.Name == CompatibilityModeSetting {
+			return setting.Value
+		}
+	}
+	return ""
+}
+
+// readSettings reads and unmarshals the document settings.
+// If the document does not have any settings, empty settings are returned.
+func (d *Document) readSettings() (*documentSettings, error) {
+	settings := new(documentSettings)
+	settingsPart, err := d.referencedPart(SettingsRelationshipType, SettingsXml)
+	if err != nil {
+		return nil, err
+	}
+	settingsBytes, err := d.readFile(settingsPart)
+	if err != nil {
+		return settings, nil
+	}
+	if err := xml.Unmarshal(settingsBytes, settings); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", settingsPart, err)
+	}
+	return settings, nil
+}
+
+// DocVars returns all document variables (<w:docVars>) stored in the document settings.
+// Document variables are typically used by macro-driven templates and are not visible inside the document.
+func (d *Document) DocVars() (map[string]string, error) {
+	settings, err := d.readSettings()
+	if err != nil {
+		return nil, err
+	}
+	vars := make(map[string]string)
+	for _, v := range settings.DocVars {
+		vars[v.Name] = v.Value
+	}
+	return vars, nil
+}
+
+// SetDocVar sets the document variable with the given name to value.
+// If the variable does not exist yet, it is added.
+func (d *Document) SetDocVar(name, value string) error {
+	settings, err := d.readSettings()
+	if err != nil {
+		return err
+	}
+
+	// keep the order of the existing variables, only update or append
+	found := false
+	for i, v := range settings.DocVars {
+		if v.Name == name {
+			settings.DocVars[i].Value = value
+			found = true
+		}
+	}
+	if !found {
+		settings.DocVars = append(settings.DocVars, docVar{Name: name, Value: value})
+	}
+
+	var element strings.Builder
+	element.WriteString("<w:docVars>")
+	for _, v := range settings.DocVars {
+		element.WriteString(fmt.Sprintf(`<w:docVar w:name="%s" w:val="%s"/>`, html.EscapeString(v.Name), html.EscapeString(v.Value)))
+	}
+	element.WriteString("</w:docVars>")
+
+	return d.setSettingsElement("docVars", element.String())
+}
+
+// DefaultTabStop returns the distance between the automatic tab stops of the document in twips (1/20 pt).
+// If the settings do not declare a default tab stop, DefaultTabStopTwips is returned.
+func (d *Document) DefaultTabStop() (int, error) {
+	settings, err := d.readSettings()
+	if err != nil {
+		return 0, err
+	}
+	if settings.DefaultTabStop == nil {
+		return DefaultTabStopTwips, nil
+	}
+	return settings.DefaultTabStop.Value, nil
+}
+
+// SetDefaultTabStop sets the distance between the automatic tab stops of the document in twips (1/20 pt).
+func (d *Document) SetDefaultTabStop(twips int) error {
+	if twips < 0 {
+		return fmt.Errorf("invalid default tab stop %d, must not be negative", twips)
+	}
+	return d.setSettingsElement("defaultTabStop", fmt.Sprintf(`<w:defaultTabStop w:val="%d"/>`, twips))
+}
+
+// setSettingsElement replaces the settings element with the given local name by the given element.
+// If the element does not exist yet, it is inserted at the position required by the schema.
+// If the document does not have settings, they are created.
+func (d *Document) setSettingsElement(name string, element string) error {
+	settingsPart, settingsBytes, err := d.readOrCreatePart(SettingsRelationshipType, SettingsXml, settingsContentType,
+		`<w:settings xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"></w:settings>`)
+	if err != nil {
+		return err
+	}
+	settingsBytes, err = setElement(settingsBytes, "settings", name, element, settingsElementOrder)
+	if err != nil {
+		return fmt.Errorf("unable to modify %s: %s", settingsPart, err)
+	}
+	d.writePart(settingsPart, settingsBytes)
+	return nil
+}
+
+// setElement replaces the child element with the given local name of the root element by the given element.
+// If the child does not exist, it is inserted before the first element which follows it according to the order.
+// If none of the following elements exist, the element is appended to the root element.
+func setElement(data []byte, root string, name string, element string, order []string) ([]byte, error) {
+	if start, end := elementRange(data, name); start >= 0 {
+		return splice(data, start, end, element), nil
+	}
+
+	insertPos := -1
+	following := false
+	for _, elementName := range order {
+		if elementName == name {
+			following = true
+			continue
+		}
+		if !following {
+			continue
+		}
+		if pos := elementTagIndex(data, elementName); pos >= 0 && (insertPos < 0 || pos < insertPos) {
+			insertPos = pos
+		}
+	}
+	if insertPos < 0 {
+		insertPos = bytes.LastIndex(data, []byte("</w:"+root+">"))
+	}
+	if insertPos < 0 {
+		return nil, fmt.Errorf("missing root element %s", root)
+	}
+	return splice(data, insertPos, insertPos, element), nil
+}
+
+// elementTagIndex returns the position of the first tag of the element with the given local name, or -1 if there is none.
+// The element is searched without a regular expression, setElement is called for every inserted value.
+func elementTagIndex(data []byte, name string) int {
+	tag := []byte("<w:" + name)
+	for offset := 0; ; {
+		i := bytes.Index(data[offset:], tag)
+		if i < 0 {
+			return -1
+		}
+		end := offset + i + len(tag)
+		if end < len(data) && bytes.IndexByte([]byte(" \t\n\f\r/>"), data[end]) >= 0 {
+			return offset + i
+		}
+		offset = end
+	}
+}
+
+// elementRange returns the start and end position of the first element with the given local name, including its
+// content and closing tag. If the element does not exist, -1 is returned for both positions.
+func elementRange(data []byte, name string) (int, int) {
+	start := elementTagIndex(data, name)
+	if start < 0 {
+		return -1, -1
+	}
+	tagEnd := bytes.IndexByte(data[start:], '>')
+	if tagEnd < 0 {
+		return -1, -1
+	}
+	tagEnd += start
+	if data[tagEnd-1] == '/' {
+		return start, tagEnd + 1
+	}
+	closeTag := []byte("</w:" + name + ">")
+	closeStart := bytes.Index(data[tagEnd:], closeTag)
+	if closeStart < 0 {
+		return -1, -1
+	}
+	return start, tagEnd + closeStart + len(closeTag)
+}
+
+// splice returns a copy of data in which the bytes from start to end are replaced by the given value.
+func splice(data []byte, start, end int, value string) []byte {
+	result := make([]byte, 0, len(data)-(end-start)+len(value))
+	result = append(result, data[:start]...)
+	result = append(result, value...)
+	return append(result, data[end:]...)
+}
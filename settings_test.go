@@ -0,0 +1,148 @@
+package docx
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDocument_CompatibilityMode(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if mode := doc.CompatibilityMode(); mode != "15" {
+		t.Errorf("unexpected compatibility mode, want=%s, have=%s", "15", mode)
+	}
+}
+
+func TestDocument_SetDocVar(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	vars, err := doc.DocVars()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(vars) != 0 {
+		t.Errorf("unexpected document variables, want=%d, have=%d", 0, len(vars))
+	}
+
+	if err = doc.SetDocVar("customer", "Foo & Bar"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = doc.SetDocVar("contract", "42"); err != nil {
+		t.Error(err)
+		return
+	}
+	if err = doc.SetDocVar("customer", "Baz"); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the variables must survive writing and re-opening the document
+	err = doc.WriteToFile("./test/out_docvars.docx")
+	if err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	defer os.Remove("./test/out_docvars.docx")
+
+	written, err := Open("./test/out_docvars.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer written.Close()
+
+	vars, err = written.DocVars()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := map[string]string{"customer": "Baz", "contract": "42"}
+	for name, value := range expected {
+		if vars[name] != value {
+			t.Errorf("unexpected value of document variable %s, want=%s, have=%s", name, value, vars[name])
+		}
+	}
+
+	// docVars must be placed after compat and before rsids
+	settingsBytes, err := written.readFile(SettingsXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	settings := string(settingsBytes)
+	if !(strings.Index(settings, "<w:compat>") < strings.Index(settings, "<w:docVars>") &&
+		strings.Index(settings, "<w:docVars>") < strings.Index(settings, "<w:rsids>")) {
+		t.Error("docVars are not inserted according to the schema order")
+	}
+}
+
+func TestDocument_SetDefaultTabStop(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	tabStop, err := doc.DefaultTabStop()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if tabStop != 720 {
+		t.Errorf("unexpected default tab stop, want=%d, have=%d", 720, tabStop)
+	}
+
+	if err = doc.SetDefaultTabStop(567); err != nil {
+		t.Error(err)
+		return
+	}
+	if tabStop, _ = doc.DefaultTabStop(); tabStop != 567 {
+		t.Errorf("default tab stop was not set, want=%d, have=%d", 567, tabStop)
+	}
+	if err = doc.SetDefaultTabStop(-1); err == nil {
+		t.Error("negative default tab stop must be rejected")
+	}
+}
+
+func TestSetElement(t *testing.T) {
+	order := []string{"b", "sz", "szCs", "u"}
+	tests := []struct {
+		data     string
+		name     string
+		expected string
+	}{
+		{`<w:rPr><w:sz w:val="20"/></w:rPr>`, "sz", `<w:rPr><w:new/></w:rPr>`},
+		{`<w:rPr><w:szCs w:val="20"/><w:sz w:val="20"/></w:rPr>`, "sz", `<w:rPr><w:szCs w:val="20"/><w:new/></w:rPr>`},
+		{`<w:rPr><w:u w:val="single">x</w:u></w:rPr>`, "u", `<w:rPr><w:new/></w:rPr>`},
+		{`<w:rPr><w:b/><w:szCs w:val="20"/><w:u w:val="single"/></w:rPr>`, "sz", `<w:rPr><w:b/><w:new/><w:szCs w:val="20"/><w:u w:val="single"/></w:rPr>`},
+		{`<w:rPr><w:bCs/></w:rPr>`, "b", `<w:rPr><w:bCs/><w:new/></w:rPr>`},
+	}
+	for _, tt := range tests {
+		result, err := setElement([]byte(tt.data), "rPr", tt.name, "<w:new/>", order)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if string(result) != tt.expected {
+			t.Errorf("setElement(%s, %s), want=%s, have=%s", tt.data, tt.name, tt.expected, result)
+		}
+	}
+
+	if _, err := setElement([]byte(`<w:pPr/>`), "rPr", "b", "<w:new/>", order); err == nil {
+		t.Error("expected an error for a missing root element")
+	}
+}
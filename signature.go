@@ -0,0 +1,121 @@
+package docx
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SignatureInfo describes the suggested signer of a signature line, see ReplaceWithSignatureLine.
+type SignatureInfo struct {
+	Name          string // Name is the name of the suggested signer
+	Title         string // Title is the title of the suggested signer, e.g. 'Managing Director'
+	Email         string // Email is the e-mail address of the suggested signer
+	Instructions  string // Instructions are shown to the signer in the signing dialog
+	AllowComments bool   // AllowComments allows the signer to add a purpose for signing in the signing dialog
+	HideSignDate  bool   // HideSignDate hides the date of signing in the signature line
+}
+
+// signatureLineShapeType is the VML shape type of the picture frame which contains the signature line.
+const signatureLineShapeType = `<v:shapetype id="_x0000_t75" coordsize="21600,21600" o:spt="75" o:preferrelative="t" path="m@4@5l@4@11@9@11@9@5xe" filled="f" stroked="f">` +
+	`<v:stroke joinstyle="miter"/><v:formulas><v:f eqn="if lineDrawn pixelLineWidth 0"/><v:f eqn="sum @0 1 0"/><v:f eqn="sum 0 0 @1"/>` +
+	`<v:f eqn="prod @2 1 2"/><v:f eqn="prod @3 21600 pixelWidth"/><v:f eqn="prod @3 21600 pixelHeight"/><v:f eqn="sum @0 0 1"/>` +
+	`<v:f eqn="prod @6 1 2"/><v:f eqn="prod @7 21600 pixelWidth"/><v:f eqn="sum @8 21600 0"/><v:f eqn="prod @7 21600 pixelHeight"/>` +
+	`<v:f eqn="sum @10 21600 0"/></v:formulas><v:path o:extrusionok="f" gradientshapeok="t" o:connecttype="rect"/>` +
+	`<o:lock v:ext="edit" aspectratio="t"/></v:shapetype>`
+
+// signatureLineIdRegex matches the ids of the signature lines inserted by ReplaceWithSignatureLine.
+var signatureLineIdRegex = regexp.MustCompile(`\sid="\{00000000-0000-4000-8000-([0-9A-F]{12})\}"`)
+
+// ReplaceWithSignatureLine replaces every paragraph of the main document which contains the placeholder of the key
+// by a signature line of the given signer. The signature line is wrapped into a structured document tag
+// (tagged 'signature') which cannot be deleted, its paragraph keeps the properties of the replaced paragraph.
+// Word shows the name and title of the signer below the line and opens the signing dialog on double-click.
+// The signature line has no preview image, applications which do not support signature lines may show an empty frame.
+//
+// All other content of these paragraphs is removed, a placeholder should therefore be the only content of its paragraph.
+// If the key does not exist in the main document, ErrPlaceholderNotFound is returned.
+func (d *Document) ReplaceWithSignatureLine(key string, signer SignatureInfo) error {
+	replacer := d.fileReplacers[d.mainPart]
+	data := replacer.document
+
+	targets, err := placeholderParagraphs(replacer, key)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return ErrPlaceholderNotFound
+	}
+
+	// replace from the end, so the positions of the preceding paragraphs stay valid
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Start > targets[j].Start
+	})
+	firstId := d.nextSignatureLineId()
+	for i, target := range targets {
+		id := signatureLineId(firstId + len(targets) - 1 - i)
+		props := paragraphPropertiesRegex.Find(data[target.Start:target.End])
+		data = splice(data, int(target.Start), int(target.End), d.applyInsertParagraphStyle(signatureLine(id, string(props), signer)))
+	}
+
+	d.files[d.mainPart] = data
+	return d.parseFile(d.mainPart)
+}
+
+// signatureLine returns a structured document tag with a single paragraph of the given properties,
+// containing the signature line of the signer.
+func signatureLine(id string, paragraphProps string, signer SignatureInfo) string {
+	attributes := []string{
+		`v:ext="edit"`,
+		fmt.Sprintf(`id="%s"`, id),
+		`provid="{00000000-0000-0000-0000-000000000000}"`,
+		fmt.Sprintf(`o:suggestedsigner="%s"`, html.EscapeString(signer.Name)),
+		fmt.Sprintf(`o:suggestedsigner2="%s"`, html.EscapeString(signer.Title)),
+		fmt.Sprintf(`o:suggestedsigneremail="%s"`, html.EscapeString(signer.Email)),
+	}
+	if signer.Instructions != "" {
+		attributes = append(attributes, `signinginstructionsset="t"`,
+			fmt.Sprintf(`o:signinginstructions="%s"`, html.EscapeString(signer.Instructions)))
+	}
+	if signer.AllowComments {
+		attributes = append(attributes, `allowcomments="t"`)
+	}
+	if signer.HideSignDate {
+		attributes = append(attributes, `showsigndate="f"`)
+	}
+	attributes = append(attributes, `issignatureline="t"`)
+
+	var line strings.Builder
+	line.WriteString(`<w:sdt><w:sdtPr><w:alias w:val="Signature"/><w:tag w:val="signature"/><w:lock w:val="sdtLocked"/></w:sdtPr>`)
+	line.WriteString(`<w:sdtContent><w:p>` + paragraphProps + `<w:r>`)
+	line.WriteString(`<w:pict xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office">`)
+	line.WriteString(signatureLineShapeType)
+	line.WriteString(fmt.Sprintf(`<v:shape id="%s" type="#_x0000_t75" alt="Microsoft Office Signature Line..." style="width:192pt;height:96pt">`,
+		strings.Trim(id, "{}")))
+	line.WriteString(`<o:lock v:ext="edit" ungrouping="t" rotation="t" cropping="t" verticies="t" text="t" grouping="t"/>`)
+	line.WriteString(`<o:signatureline ` + strings.Join(attributes, " ") + `/>`)
+	line.WriteString(`</v:shape></w:pict></w:r></w:p></w:sdtContent></w:sdt>`)
+	return line.String()
+}
+
+// nextSignatureLineId returns a number for a new signature line which is unique inside the document.
+// Like the drawing ids (see nextDrawingId), the ids are derived from the document, so the output is reproducible.
+func (d *Document) nextSignatureLineId() int {
+	maxId := 0
+	for name := range d.fileReplacers {
+		for _, match := range signatureLineIdRegex.FindAllSubmatch(d.fileReplacers[name].document, -1) {
+			if id, err := strconv.ParseInt(string(match[1]), 16, 64); err == nil && int(id) > maxId {
+				maxId = int(id)
+			}
+		}
+	}
+	return maxId + 1
+}
+
+// signatureLineId returns the GUID of the n-th signature line in registry format, e.g. '{00000000-0000-4000-8000-000000000001}'.
+func signatureLineId(n int) string {
+	return fmt.Sprintf("{00000000-0000-4000-8000-%012X}", n)
+}
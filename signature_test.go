@@ -0,0 +1,102 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceWithSignatureLine(t *testing.T) {
+	doc, err := Open("./test/signature.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	signer := SignatureInfo{Name: "Jane Doe", Title: "Managing Director", Email: "jane@example.com", Instructions: "Sign & date", HideSignDate: true}
+	if err = doc.ReplaceWithSignatureLine("signature", signer); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, element := range []string{
+		`<w:tag w:val="signature"/>`,
+		`<w:sdtContent><w:p><w:pPr><w:jc w:val="right"/></w:pPr><w:r><w:pict`,
+		`o:suggestedsigner="Jane Doe"`,
+		`o:suggestedsigner2="Managing Director"`,
+		`o:suggestedsigneremail="jane@example.com"`,
+		`o:signinginstructions="Sign &amp; date"`,
+		`showsigndate="f"`,
+		`issignatureline="t"`,
+	} {
+		if !strings.Contains(documentXml, element) {
+			t.Errorf("signature line does not contain %s", element)
+		}
+	}
+	if strings.Contains(documentXml, "{signature}") {
+		t.Error("placeholder paragraph was not replaced")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+
+	// the remaining placeholders are still replaceable
+	if err = doc.Replace("name", "Jane Doe"); err != nil {
+		t.Error("replacing after inserting the signature line failed", err)
+	}
+
+	if err = doc.ReplaceWithSignatureLine("does-not-exist", signer); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, got %v", err)
+	}
+}
+
+func TestDocument_ReplaceWithSignatureLine_Reproducible(t *testing.T) {
+	var outputs []string
+	for i := 0; i < 2; i++ {
+		doc, err := Open("./test/signature.docx")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		signer := SignatureInfo{Name: "Jane Doe"}
+		if err = doc.ReplaceWithSignatureLine("signature", signer); err != nil {
+			t.Error("replacing failed", err)
+			doc.Close()
+			return
+		}
+		outputs = append(outputs, string(doc.GetFile(DocumentXml)))
+		doc.Close()
+	}
+	if outputs[0] != outputs[1] {
+		t.Error("the same input results in different signature lines")
+	}
+	if !strings.Contains(outputs[0], `id="{00000000-0000-4000-8000-000000000001}"`) {
+		t.Error("signature line id is not derived from the document")
+	}
+}
+
+func TestDocument_nextSignatureLineId(t *testing.T) {
+	doc, err := Open("./test/signature.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if id := doc.nextSignatureLineId(); id != 1 {
+		t.Errorf("expected id 1 for a document without signature lines, got %d", id)
+	}
+	if err = doc.ReplaceWithSignatureLine("signature", SignatureInfo{Name: "Jane Doe"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if id := doc.nextSignatureLineId(); id != 2 {
+		t.Errorf("expected id 2 after inserting a signature line, got %d", id)
+	}
+	if id := signatureLineId(26); id != "{00000000-0000-4000-8000-00000000001A}" {
+		t.Errorf("invalid signature line id %s", id)
+	}
+}
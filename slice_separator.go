@@ -0,0 +1,40 @@
+package docx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DefaultSliceSeparator joins the elements of slice values which are inserted as text, unless another separator is set.
+const DefaultSliceSeparator = ", "
+
+// SetSliceSeparator sets the separator which joins the elements of slice and array values (e.g. a []string)
+// if they are inserted as text, e.g. []string{"red", "green"} is inserted as 'red, green' using the
+// DefaultSliceSeparator. The elements are formatted like any other value, e.g. numbers are localized.
+// In order to render the elements as a list instead, use ReplaceOutline.
+//
+// Byte slices, []FormattedText and the elements of indexed keys (e.g. {tags[0]}) are not affected.
+func (d *Document) SetSliceSeparator(sep string) {
+	d.sliceSeparator = sep
+}
+
+// joinSlice returns the elements of the value joined by the slice separator.
+// If the value is neither a slice nor an array, false is returned.
+func (d *Document) joinSlice(value interface{}) (string, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return "", false
+	}
+
+	elements := make([]string, v.Len())
+	for i := range elements {
+		element := v.Index(i).Interface()
+		if localized, ok := d.localize(element); ok {
+			elements[i] = localized
+		} else {
+			elements[i] = fmt.Sprint(element)
+		}
+	}
+	return strings.Join(elements, d.sliceSeparator), true
+}
@@ -0,0 +1,34 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetSliceSeparator(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": []string{"red", "green", "blue"}})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "red, green, blue") {
+		t.Error("slice was not joined by the default separator")
+	}
+
+	doc.SetSliceSeparator(" / ")
+	err = doc.ReplaceAll(PlaceholderMap{"key_with_underscore": [2]int{1, 2}})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "1 / 2") {
+		t.Error("array was not joined by the custom separator")
+	}
+}
@@ -0,0 +1,18 @@
+package docx
+
+// ReplaceStats summarizes the result of a replacement.
+type ReplaceStats struct {
+	// Replaced is the total number of replaced placeholders
+	Replaced int
+	// Parts maps the path of every part in which placeholders were replaced to the number of replaced placeholders
+	Parts map[string]int
+	// UnusedKeys are the keys of the PlaceholderMap which did not match any placeholder, sorted alphabetically
+	UnusedKeys []string
+	// Warnings are problems which did not abort the replacement, e.g. parts which could not be parsed
+	Warnings []string
+}
+
+// newReplaceStats returns empty ReplaceStats.
+func newReplaceStats() ReplaceStats {
+	return ReplaceStats{Parts: make(map[string]int)}
+}
@@ -0,0 +1,35 @@
+package docx
+
+// StripMode defines how StripPlaceholders treats the placeholders.
+type StripMode int
+
+const (
+	// StripKeysOnly removes the delimiters of the placeholders, leaving only their keys (e.g. '{name}' becomes 'name').
+	StripKeysOnly StripMode = iota
+	// StripRemove removes the placeholders entirely.
+	StripRemove
+)
+
+// StripPlaceholders removes all placeholders which have not been replaced yet according to the given mode.
+// This is useful to render a preview of the blank template or to generate a skeleton document.
+func (d *Document) StripPlaceholders(mode StripMode) error {
+	for name, replacer := range d.fileReplacers {
+		placeholders := replacer.Unreplaced()
+		if len(placeholders) == 0 {
+			continue
+		}
+		for _, placeholder := range placeholders {
+			value := ""
+			if mode == StripKeysOnly {
+				value = RemovePlaceholderDelimiter(placeholder.Text(replacer.document))
+			}
+			if err := replacer.ReplacePlaceholder(placeholder, value); err != nil {
+				return err
+			}
+		}
+		if err := d.SetFile(name, replacer.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_StripPlaceholders(t *testing.T) {
+	tests := []struct {
+		mode     StripMode
+		expected string
+	}{
+		{StripKeysOnly, "key-with-dash"},
+		{StripRemove, ""},
+	}
+
+	for _, tt := range tests {
+		doc, err := Open("./test/template.docx")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// the template contains placeholders spanning paragraphs
+		if err = doc.SetAllowCrossParagraphPlaceholders(true); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if err = doc.Replace("key", "VALUE"); err != nil {
+			t.Error("replacing failed", err)
+		}
+		if err = doc.StripPlaceholders(tt.mode); err != nil {
+			t.Error("stripping failed", err)
+		}
+
+		text := doc.Text()
+		if strings.ContainsAny(text, "{}") {
+			t.Errorf("mode %d: placeholders were not stripped", tt.mode)
+		}
+		if tt.expected != "" && !strings.Contains(text, tt.expected) {
+			t.Errorf("mode %d: expected key %s inside the text", tt.mode, tt.expected)
+		}
+		if tt.mode == StripRemove && strings.Contains(text, "key-with-dash") {
+			t.Errorf("mode %d: placeholder was not removed", tt.mode)
+		}
+		if !strings.Contains(text, "VALUE") {
+			t.Errorf("mode %d: replaced value was stripped", tt.mode)
+		}
+		doc.Close()
+	}
+}
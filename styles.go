@@ -0,0 +1,157 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// StylesXml is the relative path of the style definitions inside the docx-archive.
+	StylesXml = "word/styles.xml"
+	// StylesRelationshipType is the type of the relationship which references the style definitions.
+	StylesRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+	// stylesContentType is the content type of the style definitions.
+	stylesContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"
+)
+
+// paragraphPropertiesElementOrder is the order of the child elements of <w:pPr> as defined by the WordprocessingML schema.
+var paragraphPropertiesElementOrder = []string{
+	"pStyle", "keepNext", "keepLines", "pageBreakBefore", "framePr", "widowControl", "numPr", "suppressLineNumbers",
+	"pBdr", "shd", "tabs", "suppressAutoHyphens", "kinsoku", "wordWrap", "overflowPunct", "topLinePunct",
+	"autoSpaceDE", "autoSpaceDN", "bidi", "adjustRightInd", "snapToGrid", "spacing", "ind", "contextualSpacing",
+	"mirrorIndents", "suppressOverlap", "jc", "textDirection", "textAlignment", "textboxTightWrap", "outlineLvl",
+	"divId", "cnfStyle", "rPr", "sectPr", "pPrChange",
+}
+
+var (
+	// paragraphDefaultsRegex matches the default paragraph properties (<w:pPrDefault>) of the style definitions
+	paragraphDefaultsRegex = regexp.MustCompile(`(?s)<w:pPrDefault>.*?</w:pPrDefault>|<w:pPrDefault/>`)
+	// spacingRegex matches the spacing element (<w:spacing>) of paragraph properties
+	spacingRegex = regexp.MustCompile(`<w:spacing(?:\s[^>]*)?/>`)
+)
+
+// documentStyles is the subset of the style definitions (<w:styles>) which is read by the lib.
+type documentStyles struct {
+	Spacing *struct {
+		Before int `xml:"before,attr"`
+		After  int `xml:"after,attr"`
+	} `xml:"docDefaults>pPrDefault>pPr>spacing"`
+	Styles []struct {
+		Type string `xml:"type,attr"`
+		ID   string `xml:"styleId,attr"`
+	} `xml:"style"`
+}
+
+// DefaultParagraphSpacing returns the space before and after paragraphs in twips (1/20 pt)
+// which applies to all paragraphs not overriding it by their style or properties.
+// If the document does not declare a default spacing, 0 is returned for both.
+func (d *Document) DefaultParagraphSpacing() (before, after int, err error) {
+	stylesPart, err := d.referencedPart(StylesRelationshipType, StylesXml)
+	if err != nil {
+		return 0, 0, err
+	}
+	stylesBytes, err := d.readFile(stylesPart)
+	if err != nil {
+		return 0, 0, nil
+	}
+	styles := new(documentStyles)
+	if err := xml.Unmarshal(stylesBytes, styles); err != nil {
+		return 0, 0, fmt.Errorf("unable to parse %s: %s", stylesPart, err)
+	}
+	if styles.Spacing == nil {
+		return 0, 0, nil
+	}
+	return styles.Spacing.Before, styles.Spacing.After, nil
+}
+
+// SetDefaultParagraphSpacing sets the space before and after paragraphs in twips (1/20 pt)
+// which applies to all paragraphs not overriding it by their style or properties.
+// Other spacing attributes of the document defaults (e.g. the line spacing) are kept.
+// If the document does not have style definitions, they are created.
+func (d *Document) SetDefaultParagraphSpacing(before, after int) error {
+	if before < 0 || after < 0 {
+		return fmt.Errorf("invalid paragraph spacing %d/%d, must not be negative", before, after)
+	}
+	stylesPart, stylesBytes, err := d.readOrCreatePart(StylesRelationshipType, StylesXml, stylesContentType,
+		`<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"></w:styles>`)
+	if err != nil {
+		return err
+	}
+	stylesBytes, err = setDefaultSpacing(stylesBytes, before, after)
+	if err != nil {
+		return fmt.Errorf("unable to modify %s: %s", stylesPart, err)
+	}
+	d.writePart(stylesPart, stylesBytes)
+	return nil
+}
+
+// setDefaultSpacing sets the spacing of the default paragraph properties, creating all missing parent elements.
+func setDefaultSpacing(styles []byte, before, after int) ([]byte, error) {
+	spacing := fmt.Sprintf(`<w:spacing w:before="%d" w:after="%d"/>`, before, after)
+	defaults := "<w:pPrDefault><w:pPr>" + spacing + "</w:pPr></w:pPrDefault>"
+
+	loc := paragraphDefaultsRegex.FindIndex(styles)
+	if loc == nil {
+		// the paragraph defaults follow the run defaults, they are always the last child of <w:docDefaults>
+		if pos := strings.Index(string(styles), "</w:docDefaults>"); pos >= 0 {
+			return splice(styles, pos, pos, defaults), nil
+		}
+		if docDefaults := regexp.MustCompile(`<w:docDefaults\s*/>`).FindIndex(styles); docDefaults != nil {
+			return splice(styles, docDefaults[0], docDefaults[1], "<w:docDefaults>"+defaults+"</w:docDefaults>"), nil
+		}
+		root := regexp.MustCompile(`<w:styles(?:\s[^>]*)?>`).FindIndex(styles)
+		if root == nil {
+			return nil, fmt.Errorf("missing root element styles")
+		}
+		return splice(styles, root[1], root[1], "<w:docDefaults>"+defaults+"</w:docDefaults>"), nil
+	}
+
+	paragraphDefaults := styles[loc[0]:loc[1]]
+	propsLoc := paragraphPropertiesRegex.FindIndex(paragraphDefaults)
+	if propsLoc == nil {
+		return splice(styles, loc[0], loc[1], defaults), nil
+	}
+	props := string(paragraphDefaults[propsLoc[0]:propsLoc[1]])
+	if props == "<w:pPr/>" {
+		props = "<w:pPr></w:pPr>"
+	}
+
+	// the run properties of the paragraph mark may have a spacing element of their own
+	ownProps := strings.TrimSuffix(props, "</w:pPr>")
+	if pos := strings.Index(props, "<w:rPr"); pos >= 0 {
+		ownProps = props[:pos]
+	}
+	if spacingLoc := spacingRegex.FindStringIndex(ownProps); spacingLoc != nil {
+		element := ownProps[spacingLoc[0]:spacingLoc[1]]
+		element = setAttribute(element, "w:before", fmt.Sprint(before))
+		element = setAttribute(element, "w:after", fmt.Sprint(after))
+		props = props[:spacingLoc[0]] + element + props[spacingLoc[1]:]
+	} else {
+		// spacing precedes the run properties, insert it into the own properties only
+		result, err := setElement([]byte(ownProps+"</w:pPr>"), "pPr", "spacing", spacing, paragraphPropertiesElementOrder)
+		if err != nil {
+			return nil, err
+		}
+		props = strings.TrimSuffix(string(result), "</w:pPr>") + props[len(ownProps):]
+	}
+
+	start, end := loc[0]+propsLoc[0], loc[0]+propsLoc[1]
+	return splice(styles, start, end, props), nil
+}
+
+// setAttribute sets the attribute with the given qualified name of the (open or singleton) tag to value.
+// If the tag does not have the attribute yet, it is appended.
+func setAttribute(tag string, name string, value string) string {
+	existing := regexp.MustCompile(fmt.Sprintf(`\s%s="[^"]*"`, regexp.QuoteMeta(name)))
+	attribute := fmt.Sprintf(` %s="%s"`, name, value)
+	if loc := existing.FindStringIndex(tag); loc != nil {
+		return tag[:loc[0]] + attribute + tag[loc[1]:]
+	}
+	end := strings.LastIndex(tag, ">")
+	if strings.HasSuffix(tag, "/>") {
+		end--
+	}
+	return tag[:end] + attribute + tag[end:]
+}
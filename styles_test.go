@@ -0,0 +1,74 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_SetDefaultParagraphSpacing(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	before, after, err := doc.DefaultParagraphSpacing()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if before != 0 || after != 0 {
+		t.Errorf("unexpected default spacing, want=%d/%d, have=%d/%d", 0, 0, before, after)
+	}
+
+	if err = doc.SetDefaultParagraphSpacing(120, 240); err != nil {
+		t.Error(err)
+		return
+	}
+	if before, after, _ = doc.DefaultParagraphSpacing(); before != 120 || after != 240 {
+		t.Errorf("default spacing was not set, want=%d/%d, have=%d/%d", 120, 240, before, after)
+	}
+
+	styles, _ := doc.readFile(StylesXml)
+	expected := `<w:pPr><w:widowControl w:val="0"/><w:spacing w:before="120" w:after="240"/></w:pPr>`
+	if !strings.Contains(string(styles), expected) {
+		t.Error("spacing was not inserted after the existing paragraph defaults")
+	}
+}
+
+func TestSetDefaultSpacing(t *testing.T) {
+	tests := []struct {
+		name     string
+		styles   string
+		expected string
+	}{
+		{
+			name:     "keep line spacing",
+			styles:   `<w:styles><w:docDefaults><w:pPrDefault><w:pPr><w:spacing w:after="160" w:line="259" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults></w:styles>`,
+			expected: `<w:styles><w:docDefaults><w:pPrDefault><w:pPr><w:spacing w:after="0" w:line="259" w:lineRule="auto" w:before="60"/></w:pPr></w:pPrDefault></w:docDefaults></w:styles>`,
+		},
+		{
+			name:     "missing defaults",
+			styles:   `<w:styles><w:style w:styleId="Normal"/></w:styles>`,
+			expected: `<w:styles><w:docDefaults><w:pPrDefault><w:pPr><w:spacing w:before="60" w:after="0"/></w:pPr></w:pPrDefault></w:docDefaults><w:style w:styleId="Normal"/></w:styles>`,
+		},
+		{
+			name:     "paragraph mark properties",
+			styles:   `<w:styles><w:docDefaults><w:pPrDefault><w:pPr><w:rPr><w:spacing w:val="10"/></w:rPr></w:pPr></w:pPrDefault></w:docDefaults></w:styles>`,
+			expected: `<w:styles><w:docDefaults><w:pPrDefault><w:pPr><w:spacing w:before="60" w:after="0"/><w:rPr><w:spacing w:val="10"/></w:rPr></w:pPr></w:pPrDefault></w:docDefaults></w:styles>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := setDefaultSpacing([]byte(tt.styles), 60, 0)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if string(result) != tt.expected {
+				t.Errorf("unexpected styles, want=%s, have=%s", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package docx
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// styleReferenceRegex matches the references of styles (e.g. <w:pStyle w:val="Heading1"/>) and captures the style id
+	styleReferenceRegex = regexp.MustCompile(`<w:(?:pStyle|rStyle|tblStyle|basedOn|next|link)\s[^>]*w:val="([^"]*)"`)
+	// numberingReferenceRegex matches the references of numbering instances (<w:numId w:val="1"/>) and captures the id
+	numberingReferenceRegex = regexp.MustCompile(`(<w:numId\s[^>]*w:val=")(\d+)(")`)
+	// abstractNumReferenceRegex matches the reference of a numbering instance to its abstract numbering definition
+	abstractNumReferenceRegex = regexp.MustCompile(`<w:abstractNumId\s[^>]*w:val="(\d+)"`)
+)
+
+// ReplaceWithDocument replaces every paragraph of the main document which contains the placeholder of the key
+// by the body of the main document of sub, e.g. to assemble contracts from a library of clauses.
+// All other content of these paragraphs is removed, a placeholder should therefore be the only content of its paragraph.
+//
+// Like MergeDocuments, the relationships referenced by the inserted body (e.g. hyperlinks and images) are copied
+// and their ids are rewritten. The styles referenced by the inserted body which the document does not define are
+// copied from sub, styles defined by both documents keep the definition of the document. The numbering definitions
+// of the inserted lists are copied with new ids, so they do not continue the lists of the document.
+// Paragraphs without style get the insert paragraph style, if set (see SetInsertParagraphStyle).
+// The body-level section properties of sub are dropped.
+// The sub document is not modified. If the key does not exist in the main document, ErrPlaceholderNotFound is returned.
+func (d *Document) ReplaceWithDocument(key string, sub *Document) error {
+	replacer := d.fileReplacers[d.mainPart]
+	data := replacer.document
+
+	targets, err := placeholderParagraphs(replacer, key)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return ErrPlaceholderNotFound
+	}
+
+	content, err := bodyContent(sub.files[sub.mainPart])
+	if err != nil {
+		return err
+	}
+	content, err = d.importRelationships(sub, content)
+	if err != nil {
+		return err
+	}
+	content, err = d.importDefinitions(sub, content)
+	if err != nil {
+		return err
+	}
+
+	styled := d.applyInsertParagraphStyle(string(content))
+
+	// replace from the end, so the positions of the preceding paragraphs stay valid
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Start > targets[j].Start
+	})
+	for _, target := range targets {
+		data = splice(data, int(target.Start), int(target.End), styled)
+	}
+	d.files[d.mainPart] = mergeNamespaces(data, sub.files[sub.mainPart])
+	return d.parseFile(d.mainPart)
+}
+
+// placeholderParagraphs returns the positions of all paragraphs of the replacer which contain the unreplaced
+// placeholder of the key, in document order. If a placeholder spans multiple paragraphs, the returned position
+// covers all of them.
+func placeholderParagraphs(replacer *Replacer, key string) ([]Position, error) {
+	data := replacer.document
+	placeholderKey := AddPlaceholderDelimiter(key)
+
+	paragraphs, err := findElements(data, ParagraphElementName)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []Position
+	for _, placeholder := range replacer.Unreplaced() {
+		if placeholder.Text(data) != placeholderKey {
+			continue
+		}
+		first := innermostElement(paragraphs, placeholder.StartPos())
+		last := innermostElement(paragraphs, placeholder.EndPos()-1)
+		if first == nil || last == nil {
+			continue
+		}
+		target := Position{Start: first.Start, End: last.End}
+		if len(targets) > 0 && targets[len(targets)-1].End > target.Start {
+			continue // same paragraph as the previous placeholder
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// innermostElement returns the innermost of the given elements which contains the position,
+// or nil if none of them does. The elements must be in document order.
+func innermostElement(elements []Position, pos int64) *Position {
+	var innermost *Position
+	for i, element := range elements {
+		if element.Start <= pos && pos < element.End {
+			innermost = &elements[i]
+		}
+	}
+	return innermost
+}
+
+// importDefinitions copies the styles and numbering definitions of sub which are referenced inside content into
+// the document and returns content with the references to the numbering instances rewritten to their new ids.
+// Styles are copied together with the styles they are based on, unless the document defines a style of the same id.
+func (d *Document) importDefinitions(sub *Document, content []byte) ([]byte, error) {
+	styles, err := d.importStyles(sub, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// the copied styles may reference numbering instances as well (e.g. list styles)
+	remapped, err := d.importNumbering(sub, append(append([]byte{}, content...), styles...))
+	if err != nil {
+		return nil, err
+	}
+	remap := func(data []byte) []byte {
+		return numberingReferenceRegex.ReplaceAllFunc(data, func(match []byte) []byte {
+			groups := numberingReferenceRegex.FindSubmatch(match)
+			if newId, ok := remapped[string(groups[2])]; ok {
+				return []byte(string(groups[1]) + newId + string(groups[3]))
+			}
+			return match
+		})
+	}
+
+	if len(styles) > 0 {
+		stylesPart, stylesBytes, err := d.readOrCreatePart(StylesRelationshipType, StylesXml, stylesContentType,
+			`<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"></w:styles>`)
+		if err != nil {
+			return nil, err
+		}
+		closeTag := strings.LastIndex(string(stylesBytes), "</w:styles>")
+		if closeTag < 0 {
+			return nil, fmt.Errorf("unable to modify %s: missing root element", stylesPart)
+		}
+		d.writePart(stylesPart, splice(stylesBytes, closeTag, closeTag, string(remap(styles))))
+	}
+	return remap(content), nil
+}
+
+// importStyles returns the definitions of all styles of sub which are referenced inside content, directly or by other
+// copied styles, and which are not defined by the document.
+func (d *Document) importStyles(sub *Document, content []byte) ([]byte, error) {
+	subPart, err := sub.referencedPart(StylesRelationshipType, StylesXml)
+	if err != nil {
+		return nil, err
+	}
+	subStyles, err := sub.readFile(subPart)
+	if err != nil {
+		return nil, nil
+	}
+	stylesPart, err := d.referencedPart(StylesRelationshipType, StylesXml)
+	if err != nil {
+		return nil, err
+	}
+	styles, _ := d.readFile(stylesPart)
+
+	var imported []byte
+	seen := make(map[string]bool)
+	pending := [][]byte{content}
+	for len(pending) > 0 {
+		data := pending[0]
+		pending = pending[1:]
+		for _, match := range styleReferenceRegex.FindAllSubmatch(data, -1) {
+			id := string(match[1])
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if definitionElement(styles, "w:style", "w:styleId", id) != "" {
+				continue
+			}
+			definition := definitionElement(subStyles, "w:style", "w:styleId", id)
+			if definition == "" {
+				continue
+			}
+			imported = append(imported, definition...)
+			pending = append(pending, []byte(definition))
+		}
+	}
+	return imported, nil
+}
+
+// importNumbering copies the numbering instances of sub which are referenced inside data and their abstract
+// numbering definitions into the document. All of them get new ids, the returned map maps the ids of the numbering
+// instances of sub to the new ids.
+func (d *Document) importNumbering(sub *Document, data []byte) (map[string]string, error) {
+	remapped := make(map[string]string)
+	references := numberingReferenceRegex.FindAllSubmatch(data, -1)
+	if len(references) == 0 {
+		return remapped, nil
+	}
+	subPart, err := sub.referencedPart(NumberingRelationshipType, NumberingXml)
+	if err != nil {
+		return nil, err
+	}
+	subNumbering, err := sub.readFile(subPart)
+	if err != nil {
+		return remapped, nil
+	}
+
+	numberingPart, numbering, err := d.readNumbering()
+	if err != nil {
+		return nil, err
+	}
+	abstractIds := make(map[string]string)
+	for _, reference := range references {
+		id := string(reference[2])
+		if _, ok := remapped[id]; ok {
+			continue
+		}
+		num := definitionElement(subNumbering, "w:num", "w:numId", id)
+		abstractReference := abstractNumReferenceRegex.FindStringSubmatch(num)
+		if abstractReference == nil {
+			continue // e.g. numId 0, which removes the numbering
+		}
+
+		abstractId, ok := abstractIds[abstractReference[1]]
+		if !ok {
+			abstract := definitionElement(subNumbering, "w:abstractNum", "w:abstractNumId", abstractReference[1])
+			if abstract == "" {
+				return nil, fmt.Errorf("missing abstract numbering definition %s in %s", abstractReference[1], subPart)
+			}
+			abstractId = strconv.Itoa(nextId(numbering, abstractNumIdRegex))
+			abstract = setAttribute(abstract[:strings.Index(abstract, ">")+1], "w:abstractNumId", abstractId) +
+				abstract[strings.Index(abstract, ">")+1:]
+			if numbering, err = insertNumbering(numbering, abstract, ""); err != nil {
+				return nil, err
+			}
+			abstractIds[abstractReference[1]] = abstractId
+		}
+
+		newId := strconv.Itoa(nextId(numbering, numIdRegex))
+		num = setAttribute(num[:strings.Index(num, ">")+1], "w:numId", newId) + num[strings.Index(num, ">")+1:]
+		num = abstractNumReferenceRegex.ReplaceAllString(num, fmt.Sprintf(`<w:abstractNumId w:val="%s"`, abstractId))
+		if numbering, err = insertNumbering(numbering, "", num); err != nil {
+			return nil, err
+		}
+		remapped[id] = newId
+	}
+	if len(remapped) > 0 {
+		d.writePart(numberingPart, numbering)
+	}
+	return remapped, nil
+}
+
+// definitionElement returns the element with the given tag whose attribute has the given value
+// (e.g. the style definition <w:style w:styleId="Heading1">), or an empty string if there is none.
+func definitionElement(data []byte, tag string, attribute string, value string) string {
+	element := regexp.MustCompile(fmt.Sprintf(`(?s)<%s\s[^>]*%s="%s"[^>]*?(?:/>|>.*?</%s>)`,
+		regexp.QuoteMeta(tag), regexp.QuoteMeta(attribute), regexp.QuoteMeta(value), regexp.QuoteMeta(tag)))
+	return string(element.Find(data))
+}
@@ -0,0 +1,144 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceWithDocument(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	// the template contains placeholders spanning paragraphs
+	if err = doc.SetAllowCrossParagraphPlaceholders(true); err != nil {
+		t.Error(err)
+		return
+	}
+	sub, err := Open("./test/hyperlink_b.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer sub.Close()
+
+	if err = doc.ReplaceWithDocument("key-with-dash", sub); err != nil {
+		t.Error("replacing with document failed", err)
+		return
+	}
+	if err = doc.ReplaceWithDocument("does-not-exist", sub); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("unexpected error, want=%s, have=%s", ErrPlaceholderNotFound, err)
+	}
+
+	// the remaining placeholders must still be replaceable
+	if err = doc.Replace("key", "value"); err != nil {
+		t.Error("replacing after inserting the document failed", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = doc.Write(&buf); err != nil {
+		t.Error("unable to write", err)
+		return
+	}
+	written, err := OpenBytes(buf.Bytes())
+	if err != nil {
+		t.Error("unable to open written document", err)
+		return
+	}
+
+	documentXml := string(written.GetFile(DocumentXml))
+	if strings.Contains(documentXml, "{key-with-dash}") {
+		t.Error("placeholder was not replaced")
+	}
+	if count := strings.Count(documentXml, "Document b: "); count != 2 {
+		t.Errorf("unexpected count of inserted bodies, want=%d, have=%d", 2, count)
+	}
+	if count := strings.Count(documentXml, "<w:sectPr"); count != 1 {
+		t.Errorf("section properties of the sub document must be dropped, want=%d, have=%d", 1, count)
+	}
+
+	rels, err := written.readRelationships(DocumentXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	found := false
+	for _, rel := range rels {
+		if rel.Target == "https://b.example.com/" && strings.Contains(documentXml, `r:id="`+rel.ID+`"`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("hyperlink of the sub document was not imported")
+	}
+}
+
+func TestDocument_ReplaceWithDocument_Definitions(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	sub, err := Open("./test/sub_styles.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer sub.Close()
+
+	// the document has numbering definitions of its own
+	if err = doc.ReplaceOutline("key", []ListNode{{Text: "Agenda"}}, ListDecimal); err != nil {
+		t.Error("replacing with outline failed", err)
+		return
+	}
+	if err = doc.ReplaceWithDocument("key-with-dash", sub); err != nil {
+		t.Error("replacing with document failed", err)
+		return
+	}
+
+	styles, _ := doc.readFile(StylesXml)
+	for id, expected := range map[string]int{"Clause": 1, "ClauseBase": 1, "2": 1} {
+		if count := strings.Count(string(styles), `w:styleId="`+id+`"`); count != expected {
+			t.Errorf("unexpected count of style %s, want=%d, have=%d", id, expected, count)
+		}
+	}
+
+	numbering, _ := doc.readFile(NumberingXml)
+	numId := func(data string) string {
+		match := regexp.MustCompile(`<w:numId w:val="(\d+)"/>`).FindStringSubmatch(data)
+		if match == nil {
+			t.Fatalf("missing numbering reference in %s", data)
+		}
+		return match[1]
+	}
+	format := func(numId string) string {
+		num := definitionElement(numbering, "w:num", "w:numId", numId)
+		abstractId := abstractNumReferenceRegex.FindStringSubmatch(num)
+		if abstractId == nil {
+			t.Fatalf("missing numbering instance %s", numId)
+		}
+		abstract := definitionElement(numbering, "w:abstractNum", "w:abstractNumId", abstractId[1])
+		return regexp.MustCompile(`<w:numFmt w:val="([^"]*)"`).FindStringSubmatch(abstract)[1]
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	item := documentXml[:strings.Index(documentXml, "Numbered item")]
+	itemNumId := numId(item[strings.LastIndex(item, "<w:p>"):])
+	if itemNumId == "1" {
+		t.Error("the numbering instance of the sub document must get a new id")
+	}
+	if format(itemNumId) != "decimal" {
+		t.Errorf("unexpected number format of the inserted list: %s", format(itemNumId))
+	}
+	clauseNumId := numId(definitionElement(styles, "w:style", "w:styleId", "Clause"))
+	if format(clauseNumId) != "upperRoman" {
+		t.Errorf("unexpected number format of the copied style: %s", format(clauseNumId))
+	}
+}
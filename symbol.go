@@ -0,0 +1,28 @@
+package docx
+
+import (
+	"fmt"
+	"html"
+)
+
+// Symbol is a replacement value which inserts a symbol character (<w:sym>) of a specific font,
+// e.g. a phone glyph of the 'Wingdings' font.
+// The symbol is inserted as a run of its own which keeps the properties of the placeholder run.
+// The optional Text is inserted right after the symbol, e.g. to pair the phone glyph with the phone number.
+type Symbol struct {
+	Font string // Font is the name of the symbol font, e.g. 'Wingdings'
+	Char rune   // Char is the character code of the symbol inside the font, e.g. 0xF028
+	Text string // Text is inserted after the symbol, it may be empty
+}
+
+// ReplaceSymbol will replace the given key with the symbol char of the given font in every file.
+// The char is the character code of the symbol inside the font. Symbol fonts usually use the
+// private use area, e.g. 0xF028 is the phone glyph of the 'Wingdings' font.
+func (d *Document) ReplaceSymbol(key string, font string, char rune) error {
+	return d.ReplaceAll(PlaceholderMap{key: Symbol{Font: font, Char: char}})
+}
+
+// run returns the run (<w:r>) which contains the symbol, using the given run properties.
+func (s Symbol) run(runProperties string) string {
+	return fmt.Sprintf(`<w:r>%s<w:sym w:font="%s" w:char="%04X"/></w:r>`, runProperties, html.EscapeString(s.Font), s.Char)
+}
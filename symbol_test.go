@@ -0,0 +1,39 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceSymbol(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceSymbol("key.with.dots", "Wingdings", 0xF028)
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	err = doc.ReplaceAll(PlaceholderMap{"key_with_underscore": Symbol{Font: "Wingdings", Char: 0xF029, Text: " +49 123"}})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `<w:sym w:font="Wingdings" w:char="F028"/></w:r>`) {
+		t.Error("symbol was not inserted")
+	}
+	if !strings.Contains(documentXml, `<w:sym w:font="Wingdings" w:char="F029"/></w:r>`) ||
+		!strings.Contains(documentXml, ` +49 123`) {
+		t.Error("symbol with text was not inserted")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
@@ -0,0 +1,147 @@
+package docx
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// TableElementName is the local name of the XML tag for tables (<w:tbl>)
+	TableElementName = "tbl"
+	// TableRowElementName is the local name of the XML tag for table rows (<w:tr>)
+	TableRowElementName = "tr"
+	// TableCellElementName is the local name of the XML tag for table cells (<w:tc>)
+	TableCellElementName = "tc"
+)
+
+var (
+	// tableCellPropertiesRegex matches the table cell properties element, including all properties
+	tableCellPropertiesRegex = regexp.MustCompile(`(?s)<w:tcPr>.*?</w:tcPr>|<w:tcPr/>`)
+)
+
+// FillTable fills the table with the given index (zero-based, in document order) of the main document with records.
+//
+// The first row of the table is the header row, the text of its cells declares the column names.
+// The row following the header row is the template row which defines the formatting of the data rows.
+// If the table only consists of the header row, the header row itself is used as template.
+// All rows after the header row are replaced by one row per record, the cells are matched to the columns positionally.
+//
+// Columns which are missing in a record produce empty cells, keys which do not match any column are ignored with a warning.
+func (d *Document) FillTable(tableIndex int, rows []map[string]string) error {
+	data := d.files[d.mainPart]
+
+	tables, err := findElements(data, TableElementName)
+	if err != nil {
+		return fmt.Errorf("unable to find tables: %s", err)
+	}
+	if tableIndex < 0 || tableIndex >= len(tables) {
+		return fmt.Errorf("table %d not found, the document has %d tables", tableIndex, len(tables))
+	}
+
+	tableRows, err := findChildElements(data, tables[tableIndex], TableRowElementName, TableElementName)
+	if err != nil {
+		return err
+	}
+	if len(tableRows) == 0 {
+		return fmt.Errorf("table %d does not have a header row", tableIndex)
+	}
+
+	// the header row declares the column names
+	headerCells, err := findChildElements(data, tableRows[0], TableCellElementName, TableRowElementName)
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]bool)
+	var columnNames []string
+	for _, cell := range headerCells {
+		name := strings.TrimSpace(elementText(data[cell.Start:cell.End]))
+		columnNames = append(columnNames, name)
+		columns[name] = true
+	}
+
+	// warn about keys which will never be used, once per key instead of once per row
+	unknown := make(map[string]bool)
+	var unknownKeys []string
+	for _, row := range rows {
+		for key := range row {
+			if !columns[key] && !unknown[key] {
+				unknown[key] = true
+				unknownKeys = append(unknownKeys, key)
+			}
+		}
+	}
+	sort.Strings(unknownKeys)
+	for _, key := range unknownKeys {
+		log.Printf("column %s does not exist in table %d, ignoring\n", key, tableIndex)
+	}
+
+	template := tableRows[0]
+	if len(tableRows) > 1 {
+		template = tableRows[1]
+	}
+	templateCells, err := findChildElements(data, template, TableCellElementName, TableRowElementName)
+	if err != nil {
+		return err
+	}
+
+	var newRows strings.Builder
+	for _, row := range rows {
+		newRows.WriteString(fillRow(data, template, templateCells, columnNames, row))
+	}
+
+	// replace all rows after the header row with the new ones
+	cutStart := tableRows[0].End
+	cutEnd := tableRows[0].End
+	if len(tableRows) > 1 {
+		cutStart = tableRows[1].Start
+		cutEnd = tableRows[len(tableRows)-1].End
+	}
+	d.files[d.mainPart] = splice(data, int(cutStart), int(cutEnd), newRows.String())
+
+	return d.parseFile(d.mainPart)
+}
+
+// fillRow returns a copy of the template row in which the text of every cell is replaced by the value
+// of the matching column inside the record.
+func fillRow(data []byte, template Position, cells []Position, columnNames []string, record map[string]string) string {
+	row := string(data[template.Start:template.End])
+
+	// replace from the last to the first cell, that way the cell positions stay valid
+	for i := len(cells) - 1; i >= 0; i-- {
+		value := ""
+		if i < len(columnNames) {
+			value = record[columnNames[i]]
+		}
+		cellStart := int(cells[i].Start - template.Start)
+		cellEnd := int(cells[i].End - template.Start)
+		row = row[:cellStart] + cellWithText([]byte(row[cellStart:cellEnd]), value) + row[cellEnd:]
+	}
+	return row
+}
+
+// cellWithText returns a table cell with a single paragraph containing the given text.
+// The cell properties, the properties of the first paragraph and of its first run are retained.
+func cellWithText(cell []byte, text string) string {
+	var paragraphProperties, runProperties []byte
+	paragraphs, err := findElements(cell, ParagraphElementName)
+	if err == nil && len(paragraphs) > 0 {
+		paragraph := cell[paragraphs[0].Start:paragraphs[0].End]
+		paragraphProperties = paragraphPropertiesRegex.Find(paragraph)
+
+		runs, err := findElements(paragraph, RunElementName)
+		if err == nil && len(runs) > 0 {
+			runProperties = RunPropertiesRegex.Find(paragraph[runs[0].Start:runs[0].End])
+		}
+	}
+
+	var run string
+	if text != "" {
+		textOpenTag := `<w:t xml:space="preserve">`
+		run = "<w:r>" + string(runProperties) + textOpenTag + escapeTextValue(text, textOpenTag) + "</w:t></w:r>"
+	}
+	return "<w:tc>" + string(tableCellPropertiesRegex.Find(cell)) +
+		"<w:p>" + string(paragraphProperties) + run + "</w:p></w:tc>"
+}
@@ -0,0 +1,167 @@
+package docx
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ExpandRowOptions configure the expansion of table rows, see ExpandTableRow.
+type ExpandRowOptions struct {
+	// SkipRow is called for every row before it is rendered. If it returns true, the row does not produce any output,
+	// e.g. to skip rows whose amount is zero. If nil, all rows are rendered.
+	SkipRow func(row PlaceholderMap) bool
+	// RemoveEmptyTable removes the whole table of the template row if no row is rendered, instead of leaving
+	// the other rows (e.g. the header row) behind.
+	RemoveEmptyTable bool
+	// RemoveHeading additionally removes the paragraph directly preceding a removed table, e.g. its heading.
+	// A paragraph which carries the section properties is kept.
+	RemoveHeading bool
+}
+
+// ExpandTableRow renders a table row of the main document once per row of values, e.g. to render the line items of an invoice.
+// The template row is marked by the placeholder of the key, which can be placed into any of its cells and is removed.
+// Every copy of the template row is replaced with the values of one row, placeholders whose key is not part
+// of the row (or whose value is a nil pointer) are kept and can be replaced afterwards (e.g. by ReplaceAll).
+//
+// The template row is always removed, even if there are no rows to render or all of them are skipped.
+// In that case, the whole table is removed if RemoveEmptyTable is set.
+// If multiple rows are marked by the key, every one of them is expanded.
+// If the key does not exist in a table row of the main document, ErrPlaceholderNotFound is returned.
+func (d *Document) ExpandTableRow(key string, rows []PlaceholderMap, opts ExpandRowOptions) error {
+	replacer := d.fileReplacers[d.mainPart]
+	data := replacer.document
+
+	tableRows, err := findElements(data, TableRowElementName)
+	if err != nil {
+		return err
+	}
+	placeholderKey := AddPlaceholderDelimiter(key)
+	var templates []Position
+	for _, placeholder := range replacer.Unreplaced() {
+		if placeholder.Text(data) != placeholderKey {
+			continue
+		}
+		row := innermostElement(tableRows, placeholder.StartPos())
+		if row == nil || (len(templates) > 0 && templates[len(templates)-1] == *row) {
+			continue
+		}
+		templates = append(templates, *row)
+	}
+	if len(templates) == 0 {
+		return ErrPlaceholderNotFound
+	}
+
+	if opts.RemoveEmptyTable && !renderAny(rows, opts.SkipRow) {
+		return d.removeTables(data, templates, opts.RemoveHeading)
+	}
+
+	// replace from the end, so the positions of the preceding rows stay valid
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Start > templates[j].Start
+	})
+	for _, template := range templates {
+		var rendered strings.Builder
+		for _, row := range rows {
+			if opts.SkipRow != nil && opts.SkipRow(row) {
+				continue
+			}
+			renderedRow, err := d.renderRow(data[template.Start:template.End], key, row)
+			if err != nil {
+				return err
+			}
+			rendered.Write(renderedRow)
+		}
+		data = splice(data, int(template.Start), int(template.End), rendered.String())
+	}
+
+	d.files[d.mainPart] = data
+	return d.parseFile(d.mainPart)
+}
+
+// renderAny returns true if at least one of the rows is not skipped.
+func renderAny(rows []PlaceholderMap, skip func(row PlaceholderMap) bool) bool {
+	for _, row := range rows {
+		if skip == nil || !skip(row) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeTables removes the tables of the main document which contain the given template rows. If removeHeading is set,
+// the paragraphs directly preceding the tables are removed as well, unless they carry the section properties.
+// A removed table which is nested inside of a table cell is replaced by an empty paragraph, since a cell must end with one.
+func (d *Document) removeTables(data []byte, templates []Position, removeHeading bool) error {
+	tables, err := findElements(data, TableElementName)
+	if err != nil {
+		return err
+	}
+	cells, err := findElements(data, TableCellElementName)
+	if err != nil {
+		return err
+	}
+	paragraphs, err := findElements(data, ParagraphElementName)
+	if err != nil {
+		return err
+	}
+
+	var removed []Position
+	for _, template := range templates {
+		table := innermostElement(tables, template.Start)
+		if table == nil || (len(removed) > 0 && removed[len(removed)-1].End == table.End) {
+			continue // the table of the previous template row
+		}
+		removed = append(removed, *table)
+	}
+
+	// remove from the end, so the positions of the preceding tables stay valid
+	for i := len(removed) - 1; i >= 0; i-- {
+		table := removed[i]
+		value := ""
+		if innermostElement(cells, table.Start) != nil {
+			value = "<w:p/>"
+		}
+		if removeHeading {
+			for _, paragraph := range paragraphs {
+				if paragraph.End <= table.Start && len(bytes.TrimSpace(data[paragraph.End:removed[i].Start])) == 0 &&
+					!sectionPropertiesRegex.Match(data[paragraph.Start:paragraph.End]) {
+					table.Start = paragraph.Start
+				}
+			}
+		}
+		data = splice(data, int(table.Start), int(table.End), value)
+	}
+
+	d.files[d.mainPart] = data
+	return d.parseFile(d.mainPart)
+}
+
+// renderRow returns a copy of the template row in which the marker placeholder of the key is removed
+// and the placeholders of the row are replaced by their values.
+func (d *Document) renderRow(template []byte, key string, row PlaceholderMap) ([]byte, error) {
+	rowBytes := append([]byte{}, template...)
+	parser := NewRunParser(rowBytes)
+	if err := parser.Execute(); err != nil {
+		return nil, err
+	}
+	placeholders, err := parsePlaceholders(parser.Runs(), rowBytes, d.crossParagraphPlaceholders)
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := NewReplacer(rowBytes, withoutOverlaps(placeholders, rowBytes))
+	if err := replacer.Replace(key, ""); err != nil {
+		return nil, err
+	}
+	for k, value := range row {
+		if k == key || isNilPointer(value) {
+			continue
+		}
+		if err := d.replaceValue(replacer, k, value, -1); err != nil && !errors.Is(err, ErrPlaceholderNotFound) {
+			return nil, err
+		}
+	}
+	return replacer.Bytes(), nil
+}
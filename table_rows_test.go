@@ -0,0 +1,152 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ExpandTableRow(t *testing.T) {
+	doc, err := Open("./test/table_rows.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	rows := []PlaceholderMap{
+		{"name": "Coffee", "price": 3.5},
+		{"name": "Free sample", "price": 0},
+		{"name": "Cake & Cream", "price": 4},
+	}
+	err = doc.ExpandTableRow("items", rows, ExpandRowOptions{
+		SkipRow: func(row PlaceholderMap) bool {
+			return row["price"] == 0
+		},
+	})
+	if err != nil {
+		t.Error("expanding failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{
+		`<w:tr><w:tc><w:tcPr><w:tcW w:w="4000" w:type="dxa"/></w:tcPr><w:p><w:r><w:t>Coffee</w:t></w:r></w:p></w:tc>` +
+			`<w:tc><w:p><w:r><w:rPr><w:b/></w:rPr><w:t>3.5 {currency}</w:t></w:r></w:p></w:tc></w:tr>`,
+		`<w:t>Cake &amp; Cream</w:t>`,
+	} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+	if strings.Contains(documentXml, "Free sample") {
+		t.Error("skipped row was rendered")
+	}
+	if strings.Contains(documentXml, "{items}") || strings.Contains(documentXml, "{name}") {
+		t.Error("template row was not removed")
+	}
+
+	// the placeholders which are not part of the rows can be replaced afterwards
+	err = doc.ReplaceAll(PlaceholderMap{"currency": "EUR", "total": "7.5", "footer": "Thanks"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	documentXml = string(doc.GetFile(DocumentXml))
+	if strings.Count(documentXml, " EUR</w:t>") != 2 {
+		t.Error("placeholders of the expanded rows were not replaced")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestDocument_ExpandTableRow_AllSkipped(t *testing.T) {
+	doc, err := Open("./test/table_rows.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ExpandTableRow("missing", nil, ExpandRowOptions{}); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, have=%v", err)
+	}
+
+	err = doc.ExpandTableRow("items", []PlaceholderMap{{"name": "Coffee"}}, ExpandRowOptions{
+		SkipRow: func(row PlaceholderMap) bool { return true },
+	})
+	if err != nil {
+		t.Error("expanding failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	if strings.Contains(documentXml, "{items}") || strings.Contains(documentXml, "Coffee") {
+		t.Error("template row was not removed")
+	}
+	if strings.Count(documentXml, "<w:tr>") != 2 {
+		t.Errorf("unexpected number of rows, want=%d, have=%d", 2, strings.Count(documentXml, "<w:tr>"))
+	}
+}
+
+func TestDocument_ExpandTableRow_RemoveEmptyTable(t *testing.T) {
+	doc, err := Open("./test/table_empty.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ExpandTableRow("items", nil, ExpandRowOptions{RemoveEmptyTable: true, RemoveHeading: true})
+	if err != nil {
+		t.Error("expanding failed", err)
+		return
+	}
+	// the nested table is replaced by an empty paragraph, the cell must end with a paragraph
+	err = doc.ExpandTableRow("notes", []PlaceholderMap{{"note": "none"}}, ExpandRowOptions{
+		SkipRow:          func(row PlaceholderMap) bool { return true },
+		RemoveEmptyTable: true,
+	})
+	if err != nil {
+		t.Error("expanding failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	expected := `<w:body><w:p><w:r><w:t>Invoice</w:t></w:r></w:p>` +
+		`<w:tbl><w:tblGrid><w:gridCol w:w="4000"/></w:tblGrid><w:tr><w:tc><w:p><w:r><w:t>Notes</w:t></w:r></w:p><w:p/></w:tc></w:tr></w:tbl>` +
+		`<w:p><w:r><w:t>{footer}</w:t></w:r></w:p>`
+	if !strings.Contains(documentXml, expected) {
+		t.Errorf("empty tables were not removed, have %s", documentXml)
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, expanding failed", err)
+	}
+
+	// the placeholders following the removed tables are still replaced
+	if err = doc.Replace("footer", "Thanks"); err != nil {
+		t.Error("replacing failed", err)
+	}
+}
+
+func TestDocument_ExpandTableRow_KeepNonEmptyTable(t *testing.T) {
+	doc, err := Open("./test/table_empty.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ExpandTableRow("items", []PlaceholderMap{{"name": "Coffee"}}, ExpandRowOptions{RemoveEmptyTable: true, RemoveHeading: true})
+	if err != nil {
+		t.Error("expanding failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, expected := range []string{"Line items", "<w:t>Item</w:t>", "<w:t>Coffee</w:t>"} {
+		if !strings.Contains(documentXml, expected) {
+			t.Errorf("expected %s inside the document", expected)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDocument_FillTable(t *testing.T) {
+	doc, err := Open("./test/table.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	rows := []map[string]string{
+		{"Name": "Foo", "Amount": "1.00"},
+		{"Name": "Bar & Baz", "unknown": "ignored"},
+		{"Amount": "3.00"},
+	}
+	err = doc.FillTable(0, rows)
+	if err != nil {
+		t.Error("filling table failed", err)
+		return
+	}
+
+	documentXml := doc.GetFile(DocumentXml)
+	if err = xml.Unmarshal(documentXml, new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, filling table failed", err)
+		return
+	}
+
+	expectedRows := 4 // header + one row per record
+	if count := strings.Count(string(documentXml), "<w:tr>"); count != expectedRows {
+		t.Errorf("unexpected row count, want=%d, have=%d", expectedRows, count)
+	}
+
+	// the formatting of the template row must be retained
+	expected := `<w:p><w:pPr><w:jc w:val="right"/></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t xml:space="preserve">1.00</w:t></w:r></w:p>`
+	if !strings.Contains(string(documentXml), expected) {
+		t.Error("template formatting was not retained")
+	}
+	for _, text := range []string{">Foo<", ">Bar &amp; Baz<", ">3.00<"} {
+		if !strings.Contains(string(documentXml), text) {
+			t.Errorf("value %s is missing", text)
+		}
+	}
+	if strings.Contains(string(documentXml), "example") || strings.Contains(string(documentXml), "ignored") {
+		t.Error("template row or unknown column was rendered")
+	}
+
+	// the document must still be replaceable after the table was filled
+	err = doc.ReplaceAll(PlaceholderMap{"title": "Invoice", "footer_text": "Thanks"})
+	if err != nil {
+		t.Error("replacing failed", err)
+	}
+
+	if err = doc.FillTable(1, rows); err == nil {
+		t.Error("expected an error for a missing table")
+	}
+}
+
+func TestDocument_FillTable_UnknownColumnWarning(t *testing.T) {
+	doc, err := Open("./test/table.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	rows := []map[string]string{
+		{"Name": "Foo", "unknown": "a"},
+		{"Name": "Bar", "unknown": "b"},
+		{"Name": "Baz", "unknown": "c", "other": "d"},
+	}
+	if err = doc.FillTable(0, rows); err != nil {
+		t.Error("filling table failed", err)
+		return
+	}
+
+	// every unknown key is reported once, not once per row
+	for _, key := range []string{"unknown", "other"} {
+		warning := "column " + key + " does not exist in table 0"
+		if count := strings.Count(logged.String(), warning); count != 1 {
+			t.Errorf("unexpected warning count for %s, want=%d, have=%d", key, 1, count)
+		}
+	}
+}
+
+func TestDocument_ReplaceAll_VerticallyMergedCell(t *testing.T) {
+	doc, err := Open("./test/vmerge.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"region": "North\nEast", "first": "1", "second": "2", "third": "3"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+		return
+	}
+
+	// the value is inserted into the first cell of the merge, the merge itself is left untouched
+	master := `<w:tcPr><w:tcW w:w="4000" w:type="dxa"/><w:vMerge w:val="restart"/><w:vAlign w:val="center"/></w:tcPr>` +
+		`<w:p><w:r><w:t>North</w:t><w:br/><w:t>East</w:t></w:r><w:r><w:rPr><w:b/></w:rPr><w:t></w:t></w:r></w:p></w:tc>`
+	if !strings.Contains(documentXml, master) {
+		t.Error("value was not inserted into the first cell of the merge")
+	}
+	continuations := []string{
+		`<w:tcPr><w:tcW w:w="4000" w:type="dxa"/><w:vMerge/><w:vAlign w:val="center"/></w:tcPr><w:p/></w:tc>`,
+		`<w:tcPr><w:tcW w:w="4000" w:type="dxa"/><w:vMerge w:val="continue"/></w:tcPr><w:p><w:pPr><w:jc w:val="center"/></w:pPr></w:p></w:tc>`,
+	}
+	for _, continuation := range continuations {
+		if !strings.Contains(documentXml, continuation) {
+			t.Errorf("continuation cell was modified, expected %s", continuation)
+		}
+	}
+	if count := strings.Count(documentXml, "<w:tc>"); count != 6 {
+		t.Errorf("unexpected cell count, want=6, have=%d", count)
+	}
+	for _, value := range []string{"<w:t>1</w:t>", "<w:t>2</w:t>", "<w:t>3</w:t>"} {
+		if !strings.Contains(documentXml, value) {
+			t.Errorf("expected %s in the cells of the merged rows", value)
+		}
+	}
+}
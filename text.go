@@ -0,0 +1,170 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Text returns the plain text of the document, e.g. for indexing.
+// The text of all headers, the main document and all footers is concatenated in reading order.
+// Paragraphs and breaks are terminated with a newline, the cells of a table row are separated by tabs.
+// Text which is marked as deleted by tracked changes (<w:del>) is skipped.
+func (d *Document) Text() string {
+	var text strings.Builder
+	for _, part := range d.readingOrder() {
+		text.WriteString(plainText(d.files[part]))
+	}
+	return text.String()
+}
+
+// readingOrder returns the paths of all text parts in reading order: the headers, the main document and the footers.
+// Headers and footers are sorted by their path.
+func (d *Document) readingOrder() []string {
+	headers := append([]string{}, d.headerFiles...)
+	footers := append([]string{}, d.footerFiles...)
+	sort.Strings(headers)
+	sort.Strings(footers)
+	return append(append(headers, d.mainPart), footers...)
+}
+
+// replaceOrder returns the paths of all parsed files in the order they are replaced: the text parts in reading order,
+// followed by the additional parts in the order they were loaded and all other files (e.g. media) sorted by their path.
+// Replacing in a fixed order assigns the same media names and relationship ids to inserted images on every run.
+func (d *Document) replaceOrder() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range append(d.readingOrder(), d.additionalFiles...) {
+		if _, loaded := d.files[name]; loaded && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	var others []string
+	for name := range d.files {
+		if !seen[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append(names, others...)
+}
+
+// plainText extracts the plain text of a single file.
+// If the file cannot be parsed completely, the text extracted up to that point is returned.
+func plainText(data []byte) string {
+	text, _ := mapPlainText(data, nil)
+	return text
+}
+
+// textBuffer collects the text of a table cell (or of the whole file) together with the marks of the mapped offsets.
+type textBuffer struct {
+	bytes.Buffer
+	marks map[int]int // marks maps the index of an offset to its byte position inside the buffer
+}
+
+// mapPlainText extracts the plain text of a single file like plainText. Additionally, the given byte offsets
+// of the file are mapped to byte positions inside the extracted text. An offset can only be mapped if it
+// lies within (or at the end of) the content of a text element which is part of the text, the position of all other
+// offsets is -1. The positions are returned in the order of the offsets.
+func mapPlainText(data []byte, offsets []int64) (string, []int) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	// every table cell is collected into its own buffer, the buffer of the innermost cell is the last one
+	buffers := []*textBuffer{{marks: make(map[int]int)}}
+	current := func() *textBuffer {
+		return buffers[len(buffers)-1]
+	}
+
+	inText := false
+	runDepth := 0
+	deletedDepth := 0
+
+	for {
+		start := decoder.InputOffset()
+		tok, err := decoder.Token()
+		if tok == nil || err == io.EOF || err != nil {
+			break
+		}
+
+		switch elem := tok.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case DeletedElementName:
+				deletedDepth++
+			case RunElementName:
+				runDepth++
+			case TextElementName:
+				inText = true
+			case TableCellElementName:
+				buffers = append(buffers, &textBuffer{marks: make(map[int]int)})
+			case "tab":
+				// tabs inside of paragraph properties are tab stops, not text
+				if runDepth > 0 && deletedDepth == 0 {
+					current().WriteString("\t")
+				}
+			case "br", "cr":
+				if runDepth > 0 && deletedDepth == 0 {
+					current().WriteString("\n")
+				}
+			}
+
+		case xml.EndElement:
+			switch elem.Name.Local {
+			case DeletedElementName:
+				deletedDepth--
+			case RunElementName:
+				runDepth--
+			case TextElementName:
+				inText = false
+			case ParagraphElementName:
+				if deletedDepth == 0 {
+					current().WriteString("\n")
+				}
+			case TableCellElementName:
+				if len(buffers) > 1 {
+					cell := current()
+					text := strings.TrimSuffix(cell.String(), "\n")
+					buffers = buffers[:len(buffers)-1]
+					for i, pos := range cell.marks {
+						if pos > len(text) {
+							pos = len(text)
+						}
+						current().marks[i] = current().Len() + pos
+					}
+					current().WriteString(text + "\t")
+				}
+			case TableRowElementName:
+				// the last cell of the row is not followed by a tab
+				if bytes.HasSuffix(current().Bytes(), []byte("\t")) {
+					current().Truncate(current().Len() - 1)
+				}
+				current().WriteString("\n")
+			}
+
+		case xml.CharData:
+			if inText && deletedDepth == 0 {
+				end := decoder.InputOffset()
+				for i, offset := range offsets {
+					if _, marked := current().marks[i]; !marked && start <= offset && offset <= end {
+						// the raw content may contain entities, only the unescaped prefix is part of the text
+						current().marks[i] = current().Len() + len(html.UnescapeString(string(data[start:offset])))
+					}
+				}
+				current().Write(elem)
+			}
+		}
+	}
+
+	positions := make([]int, len(offsets))
+	for i := range positions {
+		positions[i] = -1
+		if pos, ok := buffers[0].marks[i]; ok {
+			positions[i] = pos
+		}
+	}
+	return buffers[0].String(), positions
+}
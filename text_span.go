@@ -0,0 +1,52 @@
+package docx
+
+import (
+	"unicode/utf8"
+)
+
+// TextSpan is the location of a placeholder inside the plain text of the document (see Text).
+// Start and End are offsets in runes, End is exclusive.
+type TextSpan struct {
+	Key   string
+	Start int
+	End   int
+}
+
+// PlaceholderTextSpans returns the location of every placeholder which is not replaced yet inside the plain text
+// of the document as returned by Text, in reading order. This allows to align external annotations
+// (e.g. highlighting the placeholders of a preview) with the plain text instead of the XML offsets.
+// Placeholders whose position cannot be mapped onto the plain text are omitted.
+func (d *Document) PlaceholderTextSpans() []TextSpan {
+	var (
+		spans  []TextSpan
+		offset int // offset of the current part inside the plain text, in runes
+	)
+	for _, part := range d.readingOrder() {
+		replacer, ok := d.fileReplacers[part]
+		if !ok {
+			offset += utf8.RuneCountInString(plainText(d.files[part]))
+			continue
+		}
+
+		placeholders := replacer.Unreplaced()
+		offsets := make([]int64, 0, 2*len(placeholders))
+		for _, placeholder := range placeholders {
+			offsets = append(offsets, placeholder.StartPos(), placeholder.EndPos())
+		}
+		text, positions := mapPlainText(replacer.document, offsets)
+
+		for i, placeholder := range placeholders {
+			start, end := positions[2*i], positions[2*i+1]
+			if start < 0 || end < start {
+				continue
+			}
+			spans = append(spans, TextSpan{
+				Key:   RemovePlaceholderDelimiter(placeholder.Text(replacer.document)),
+				Start: offset + utf8.RuneCountInString(text[:start]),
+				End:   offset + utf8.RuneCountInString(text[:end]),
+			})
+		}
+		offset += utf8.RuneCountInString(text)
+	}
+	return spans
+}
@@ -0,0 +1,39 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocument_PlaceholderTextSpans(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	// non-ASCII values shift the following spans by runes, not bytes
+	if err = doc.Replace("key.with.dots", "\u00e4\u00f6\u00fc & <\u00df>"); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	spans := doc.PlaceholderTextSpans()
+	if len(spans) == 0 {
+		t.Error("no spans returned")
+		return
+	}
+	text := []rune(doc.Text())
+	for _, span := range spans {
+		if span.Start < 0 || span.End > len(text) || span.Start >= span.End {
+			t.Errorf("invalid span %+v", span)
+			continue
+		}
+		// placeholders which span multiple paragraphs contain their line breaks
+		have := strings.Replace(string(text[span.Start:span.End]), "\n", "", -1)
+		if have != AddPlaceholderDelimiter(span.Key) {
+			t.Errorf("span does not match the placeholder, want=%s, have=%s", AddPlaceholderDelimiter(span.Key), have)
+		}
+	}
+}
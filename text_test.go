@@ -0,0 +1,39 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlainText(t *testing.T) {
+	docBytes := readFile(t, "./test/text.xml")
+
+	expected := "Hello\tWorld & inserted\n" +
+		"Name\tAmount\n" +
+		"Foo\t1.00\nEUR\n" +
+		"The end\n"
+	if text := plainText(docBytes); text != expected {
+		t.Errorf("unexpected plain text, want=%q, have=%q", expected, text)
+	}
+}
+
+func TestDocument_Text(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{"key-with-dashes": "REPLACED"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	text := doc.Text()
+	expected := "REPLACED\n{key_with_underscore}\n"
+	if !strings.Contains(text, expected) {
+		t.Errorf("text does not contain the replaced paragraphs %q", expected)
+	}
+}
@@ -0,0 +1,85 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"log"
+)
+
+const (
+	// ThemeXml is the relative path of the theme inside the docx-archive.
+	ThemeXml = "word/theme/theme1.xml"
+	// ThemeRelationshipType is the type of the relationship which references the theme.
+	ThemeRelationshipType = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
+)
+
+// ThemeColorNames are the theme colors of WordprocessingML which are accepted by FormattedText.ThemeColor.
+// The text and background colors are mapped to the dark and light colors of the theme by the document settings,
+// usually 'text1' is 'dark1' and 'background1' is 'light1'.
+var ThemeColorNames = []string{
+	"dark1", "light1", "dark2", "light2", "accent1", "accent2", "accent3", "accent4", "accent5", "accent6",
+	"hyperlink", "followedHyperlink", "background1", "text1", "background2", "text2",
+}
+
+// ErrInvalidThemeColor is returned if the theme color of a FormattedText is not one of ThemeColorNames.
+var ErrInvalidThemeColor = errors.New("invalid theme color")
+
+// themeColorNames maps the elements of the color scheme of a theme to the names of the theme colors.
+var themeColorNames = map[string]string{
+	"dk1": "dark1", "lt1": "light1", "dk2": "dark2", "lt2": "light2",
+	"accent1": "accent1", "accent2": "accent2", "accent3": "accent3",
+	"accent4": "accent4", "accent5": "accent5", "accent6": "accent6",
+	"hlink": "hyperlink", "folHlink": "followedHyperlink",
+}
+
+// documentTheme is the subset of the theme (<a:theme>) which is read by the lib.
+type documentTheme struct {
+	ColorScheme struct {
+		Colors []struct {
+			XMLName xml.Name
+			RGB     *struct {
+				Value string `xml:"val,attr"`
+			} `xml:"srgbClr"`
+			System *struct {
+				LastColor string `xml:"lastClr,attr"`
+			} `xml:"sysClr"`
+		} `xml:",any"`
+	} `xml:"themeElements>clrScheme"`
+}
+
+// ThemeColors returns the colors of the color scheme of the document theme as hex RGB values (e.g. '4F81BD'),
+// keyed by the names of the theme colors (e.g. 'accent1', see ThemeColorNames). System colors (like the text color
+// of the window) are returned with the value they had when the document was saved.
+// If the document does not have a theme, nil is returned. If the theme cannot be parsed, a warning is logged
+// and nil is returned as well.
+func (d *Document) ThemeColors() map[string]string {
+	themePart, err := d.referencedPart(ThemeRelationshipType, ThemeXml)
+	if err != nil {
+		log.Printf("unable to read theme colors: %s\n", err)
+		return nil
+	}
+	themeBytes, err := d.readFile(themePart)
+	if err != nil {
+		return nil
+	}
+	theme := new(documentTheme)
+	if err := xml.Unmarshal(themeBytes, theme); err != nil {
+		log.Printf("unable to parse %s: %s\n", themePart, err)
+		return nil
+	}
+
+	colors := make(map[string]string)
+	for _, color := range theme.ColorScheme.Colors {
+		name, ok := themeColorNames[color.XMLName.Local]
+		if !ok {
+			continue
+		}
+		switch {
+		case color.RGB != nil:
+			colors[name] = color.RGB.Value
+		case color.System != nil && color.System.LastColor != "":
+			colors[name] = color.System.LastColor
+		}
+	}
+	return colors
+}
@@ -0,0 +1,65 @@
+package docx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ThemeColors(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	colors := doc.ThemeColors()
+	expected := map[string]string{
+		"dark1": "000000", "light1": "FFFFFF", "dark2": "1F497D", "light2": "EEECE1",
+		"accent1": "4F81BD", "accent2": "C0504D", "accent3": "9BBB59",
+		"accent4": "8064A2", "accent5": "4BACC6", "accent6": "F79646",
+		"hyperlink": "0000FF", "followedHyperlink": "800080",
+	}
+	if len(colors) != len(expected) {
+		t.Errorf("unexpected number of theme colors, want=%d, have=%d", len(expected), len(colors))
+	}
+	for name, color := range expected {
+		if colors[name] != color {
+			t.Errorf("unexpected theme color %s, want=%s, have=%s", name, color, colors[name])
+		}
+	}
+}
+
+func TestFormattedText_ThemeColor(t *testing.T) {
+	docBytes := readFile(t, "./test/placeholder.xml")
+	replacer := newTestReplacer(t, docBytes)
+
+	err := replacer.ReplaceFormatted("yet-another-placeholder", FormattedText{Text: "themed", ThemeColor: "accent1"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	err = replacer.ReplaceFormatted("single", FormattedText{Text: "fallback", Color: "4F81BD", ThemeColor: "accent2"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	for _, expected := range []string{
+		`<w:rPr><w:color w:val="auto" w:themeColor="accent1"/></w:rPr><w:t xml:space="preserve">themed</w:t>`,
+		`<w:rPr><w:color w:val="4F81BD" w:themeColor="accent2"/></w:rPr><w:t xml:space="preserve">fallback</w:t>`,
+	} {
+		if !strings.Contains(string(replacer.Bytes()), expected) {
+			t.Errorf("expected %s in the replaced document", expected)
+		}
+	}
+
+	if err := (FormattedText{ThemeColor: "Accent1"}).Validate(); !errors.Is(err, ErrInvalidThemeColor) {
+		t.Errorf("expected ErrInvalidThemeColor, got %v", err)
+	}
+	for _, name := range ThemeColorNames {
+		if err := (FormattedText{ThemeColor: name}).Validate(); err != nil {
+			t.Errorf("theme color %s must be valid: %s", name, err)
+		}
+	}
+}
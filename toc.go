@@ -0,0 +1,102 @@
+package docx
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultTOCUpdateText is the result of a table of contents field until Word updates it, see ReplaceWithTOC.
+	DefaultTOCUpdateText = "Right-click to update the table of contents."
+
+	// maxHeadingLevel is the last of the built-in heading levels
+	maxHeadingLevel = 9
+)
+
+// TOCOptions configure the table of contents inserted by ReplaceWithTOC.
+// The zero value results in a table of contents of the heading levels 1 to 3 with hyperlinked entries.
+type TOCOptions struct {
+	FromLevel    int    // FromLevel is the first heading level included, defaults to 1
+	ToLevel      int    // ToLevel is the last heading level included, defaults to 3
+	NoHyperlinks bool   // NoHyperlinks disables the hyperlinks from the entries to the headings
+	UpdateText   string // UpdateText is shown until the field is updated, defaults to DefaultTOCUpdateText
+}
+
+// instruction returns the field instruction of the table of contents, e.g. 'TOC \o "1-3" \h'.
+func (o TOCOptions) instruction() (string, error) {
+	from, to := o.FromLevel, o.ToLevel
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = 3
+	}
+	if from < 1 || to > maxHeadingLevel || from > to {
+		return "", fmt.Errorf("invalid heading levels %d-%d, must be within 1-%d", from, to, maxHeadingLevel)
+	}
+	instruction := fmt.Sprintf(`TOC \o "%d-%d"`, from, to)
+	if !o.NoHyperlinks {
+		instruction += ` \h`
+	}
+	return instruction, nil
+}
+
+// ReplaceWithTOC replaces every paragraph of the main document which contains the placeholder of the key
+// by a table of contents field. The field is marked as dirty and the document settings instruct Word
+// to update all fields when the document is opened (<w:updateFields>), so the entries are generated on open.
+// Until then, the field shows the update text of the options.
+//
+// All other content of these paragraphs is removed, a placeholder should therefore be the only content of its paragraph.
+// If the key does not exist in the main document, ErrPlaceholderNotFound is returned.
+func (d *Document) ReplaceWithTOC(key string, opts TOCOptions) error {
+	instruction, err := opts.instruction()
+	if err != nil {
+		return err
+	}
+	updateText := opts.UpdateText
+	if updateText == "" {
+		updateText = DefaultTOCUpdateText
+	}
+
+	replacer := d.fileReplacers[d.mainPart]
+	data := replacer.document
+
+	targets, err := placeholderParagraphs(replacer, key)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return ErrPlaceholderNotFound
+	}
+
+	// replace from the end, so the positions of the preceding paragraphs stay valid
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Start > targets[j].Start
+	})
+	for _, target := range targets {
+		data = splice(data, int(target.Start), int(target.End), d.applyInsertParagraphStyle(tableOfContents(instruction, updateText)))
+	}
+
+	if err := d.setSettingsElement("updateFields", `<w:updateFields w:val="true"/>`); err != nil {
+		return err
+	}
+	d.files[d.mainPart] = data
+	return d.parseFile(d.mainPart)
+}
+
+// tableOfContents returns a structured document tag with a single paragraph, containing a dirty table of contents field
+// of the given instruction with the update text as its result.
+func tableOfContents(instruction, updateText string) string {
+	var toc strings.Builder
+	toc.WriteString(`<w:sdt><w:sdtPr><w:docPartObj><w:docPartGallery w:val="Table of Contents"/><w:docPartUnique/></w:docPartObj></w:sdtPr>`)
+	toc.WriteString(`<w:sdtContent><w:p>`)
+	toc.WriteString(`<w:r><w:fldChar w:fldCharType="begin" w:dirty="true"/></w:r>`)
+	toc.WriteString(`<w:r><w:instrText xml:space="preserve"> ` + html.EscapeString(instruction) + ` </w:instrText></w:r>`)
+	toc.WriteString(`<w:r><w:fldChar w:fldCharType="separate"/></w:r>`)
+	toc.WriteString(`<w:r><w:t xml:space="preserve">` + escapeTextValue(updateText, `<w:t xml:space="preserve">`) + `</w:t></w:r>`)
+	toc.WriteString(`<w:r><w:fldChar w:fldCharType="end"/></w:r>`)
+	toc.WriteString(`</w:p></w:sdtContent></w:sdt>`)
+	return toc.String()
+}
@@ -0,0 +1,83 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_ReplaceWithTOC(t *testing.T) {
+	doc, err := Open("./test/toc.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceWithTOC("toc", TOCOptions{}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	for _, element := range []string{
+		`<w:docPartGallery w:val="Table of Contents"/>`,
+		`<w:fldChar w:fldCharType="begin" w:dirty="true"/>`,
+		`<w:instrText xml:space="preserve"> TOC \o &#34;1-3&#34; \h </w:instrText>`,
+		`<w:t xml:space="preserve">` + DefaultTOCUpdateText + `</w:t>`,
+	} {
+		if !strings.Contains(documentXml, element) {
+			t.Errorf("table of contents does not contain %s", element)
+		}
+	}
+	if strings.Contains(documentXml, "{toc}") {
+		t.Error("placeholder paragraph was not replaced")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+
+	settings, err := doc.readFile(SettingsXml)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !strings.Contains(string(settings), `<w:updateFields w:val="true"/>`) {
+		t.Error("document is not updating fields on open")
+	}
+
+	// the remaining placeholders are still replaceable
+	if err = doc.Replace("body", "content"); err != nil {
+		t.Error("replacing after inserting the table of contents failed", err)
+	}
+}
+
+func TestDocument_ReplaceWithTOC_Options(t *testing.T) {
+	doc, err := Open("./test/toc.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceWithTOC("toc", TOCOptions{FromLevel: 4, ToLevel: 2}); err == nil {
+		t.Error("expected an error for invalid heading levels")
+	}
+	if err = doc.ReplaceWithTOC("missing", TOCOptions{}); !errors.Is(err, ErrPlaceholderNotFound) {
+		t.Errorf("expected ErrPlaceholderNotFound, have=%v", err)
+	}
+
+	err = doc.ReplaceWithTOC("toc", TOCOptions{FromLevel: 2, ToLevel: 4, NoHyperlinks: true, UpdateText: "Update <me>"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `> TOC \o &#34;2-4&#34; </w:instrText>`) {
+		t.Error("table of contents instruction does not match the options")
+	}
+	if !strings.Contains(documentXml, `>Update &lt;me&gt;</w:t>`) {
+		t.Error("update text was not inserted")
+	}
+}
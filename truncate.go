@@ -0,0 +1,89 @@
+package docx
+
+import (
+	"unicode/utf8"
+)
+
+// DefaultTruncateSuffix is appended to values which are truncated, unless another suffix is set.
+const DefaultTruncateSuffix = "…"
+
+// SetMaxValueRunes sets the maximum length of all values, counted in runes.
+// Longer values are truncated and end with the truncate suffix (see SetTruncateSuffix), the suffix is
+// included in the maximum length. This prevents excessively long values from breaking the layout, e.g. of table cells.
+// A maximum of 0 disables truncation, which is the default.
+func (d *Document) SetMaxValueRunes(maxRunes int) {
+	d.maxValueRunes = maxRunes
+}
+
+// SetKeyMaxValueRunes sets the maximum length of the values of the given key, overriding the maximum set
+// with SetMaxValueRunes. A maximum of 0 disables truncation for the key.
+func (d *Document) SetKeyMaxValueRunes(key string, maxRunes int) {
+	d.keyMaxValueRunes[key] = maxRunes
+}
+
+// SetTruncateSuffix sets the suffix which marks truncated values, it defaults to DefaultTruncateSuffix.
+func (d *Document) SetTruncateSuffix(suffix string) {
+	d.truncateSuffix = suffix
+}
+
+// truncateValue truncates the text of the value according to the maximum length of the key.
+// The value must either be a string, a FormattedText or a []FormattedText. The segments of a []FormattedText are
+// truncated as a whole, segments following the cut are dropped.
+func (d *Document) truncateValue(key string, value interface{}) interface{} {
+	maxRunes := d.maxValueRunes
+	if keyMax, ok := d.keyMaxValueRunes[key]; ok {
+		maxRunes = keyMax
+	}
+	if maxRunes <= 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case string:
+		return truncateRunes(v, maxRunes, d.truncateSuffix)
+	case FormattedText:
+		v.Text = truncateRunes(v.Text, maxRunes, d.truncateSuffix)
+		return v
+	case []FormattedText:
+		var length int
+		for _, segment := range v {
+			length += utf8.RuneCountInString(segment.Text)
+		}
+		if length <= maxRunes {
+			return v
+		}
+
+		suffix := d.truncateSuffix
+		if utf8.RuneCountInString(suffix) > maxRunes {
+			suffix = ""
+		}
+
+		var segments []FormattedText
+		remaining := maxRunes - utf8.RuneCountInString(suffix)
+		for _, segment := range v {
+			if utf8.RuneCountInString(segment.Text) >= remaining {
+				segment.Text = truncateRunes(segment.Text, remaining, "") + suffix
+				return append(segments, segment)
+			}
+			remaining -= utf8.RuneCountInString(segment.Text)
+			segments = append(segments, segment)
+		}
+		return segments
+	}
+	return value
+}
+
+// truncateRunes truncates the text to at most maxRunes runes, including the suffix which is appended
+// to truncated texts. If the suffix itself exceeds the maximum, the text is cut without a suffix.
+func truncateRunes(text string, maxRunes int, suffix string) string {
+	if utf8.RuneCountInString(text) <= maxRunes {
+		return text
+	}
+	suffixRunes := utf8.RuneCountInString(suffix)
+	if suffixRunes > maxRunes {
+		suffix, suffixRunes = "", 0
+	}
+
+	runes := []rune(text)
+	return string(runes[:maxRunes-suffixRunes]) + suffix
+}
@@ -0,0 +1,67 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxRunes int
+		suffix   string
+		expected string
+	}{
+		{"short", 10, "…", "short"},
+		{"exactly10!", 10, "…", "exactly10!"},
+		{"this is too long", 10, "…", "this is t…"},
+		{"äöüäöüäöüäöü", 5, "…", "äöüä…"},
+		{"this is too long", 10, "...", "this is..."},
+		{"this is too long", 2, "...", "th"},
+	}
+
+	for _, tt := range tests {
+		if result := truncateRunes(tt.text, tt.maxRunes, tt.suffix); result != tt.expected {
+			t.Errorf("truncateRunes(%q, %d, %q), want=%q, have=%q", tt.text, tt.maxRunes, tt.suffix, tt.expected, result)
+		}
+	}
+}
+
+func TestDocument_truncateValue(t *testing.T) {
+	doc := &Document{keyMaxValueRunes: make(map[string]int), truncateSuffix: DefaultTruncateSuffix}
+	doc.SetMaxValueRunes(6)
+	doc.SetKeyMaxValueRunes("unlimited", 0)
+
+	if v := doc.truncateValue("key", "too long"); v != "too l…" {
+		t.Errorf("string not truncated, have=%v", v)
+	}
+	if v := doc.truncateValue("unlimited", "too long"); v != "too long" {
+		t.Errorf("per-key override ignored, have=%v", v)
+	}
+
+	segments := []FormattedText{{Text: "H"}, {Text: "2", VertAlign: "subscript"}, {Text: "O is water"}}
+	expected := []FormattedText{{Text: "H"}, {Text: "2", VertAlign: "subscript"}, {Text: "O i…"}}
+	if v := doc.truncateValue("key", segments); !reflect.DeepEqual(v, expected) {
+		t.Errorf("segments not truncated, want=%v, have=%v", expected, v)
+	}
+}
+
+func TestDocument_SetMaxValueRunes(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetMaxValueRunes(8)
+	err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": "a value which is far too long"})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(doc.Text(), "a value…") {
+		t.Error("value was not truncated")
+	}
+}
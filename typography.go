@@ -0,0 +1,74 @@
+package docx
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dashReplacer converts double and triple hyphens into dashes, the longest sequence is replaced first.
+var dashReplacer = strings.NewReplacer("---", "—", "--", "–")
+
+// SetSmartTypography enables or disables the typographic normalization of string values.
+// If enabled, the text of string and FormattedText values is converted as follows:
+//   - '---' becomes an em dash (—) and '--' becomes an en dash (–)
+//   - a straight double quote (") becomes an opening quote (“) at the start of the text or after a space,
+//     an opening bracket or a dash, and a closing quote (”) everywhere else
+//   - a straight single quote (') becomes an opening quote (‘) at the same positions and a closing quote (’)
+//     everywhere else, which is also the typographic apostrophe (e.g. don’t)
+//
+// Other values (e.g. []byte) are inserted as they are. It is disabled by default.
+func (d *Document) SetSmartTypography(enabled bool) {
+	d.smartTypography = enabled
+}
+
+// applySmartTypography converts the text of the value according to the rules of SetSmartTypography.
+// The segments of a []FormattedText are converted as one text.
+func applySmartTypography(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return smartTypography(v, 0)
+	case FormattedText:
+		v.Text = smartTypography(v.Text, 0)
+		return v
+	case []FormattedText:
+		segments := make([]FormattedText, len(v))
+		var previous rune
+		for i, segment := range v {
+			segment.Text = smartTypography(segment.Text, previous)
+			if r, _ := utf8.DecodeLastRuneInString(segment.Text); segment.Text != "" {
+				previous = r
+			}
+			segments[i] = segment
+		}
+		return segments
+	}
+	return value
+}
+
+// smartTypography converts the dashes and quotes of the text. The previous rune is the rune preceding the text,
+// 0 if the text is at the start.
+func smartTypography(text string, previous rune) string {
+	text = dashReplacer.Replace(text)
+	if !strings.ContainsAny(text, `"'`) {
+		return text
+	}
+
+	var result strings.Builder
+	for _, r := range text {
+		opening := previous == 0 || unicode.IsSpace(previous) || strings.ContainsRune("([{<–—“‘", previous)
+		switch {
+		case r == '"' && opening:
+			r = '“'
+		case r == '"':
+			r = '”'
+		case r == '\'' && opening:
+			r = '‘'
+		case r == '\'':
+			r = '’'
+		}
+		result.WriteRune(r)
+		previous = r
+	}
+	return result.String()
+}
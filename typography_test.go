@@ -0,0 +1,55 @@
+package docx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSmartTypography(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: `"Hello" -- she said`, expected: "“Hello” – she said"},
+		{text: `don't stop---ever`, expected: "don’t stop—ever"},
+		{text: `He said: "'Tis 'quoted'"`, expected: "He said: “‘Tis ‘quoted’”"},
+		{text: `("nested")`, expected: "(“nested”)"},
+		{text: "plain text", expected: "plain text"},
+	}
+	for _, tt := range tests {
+		if result := smartTypography(tt.text, 0); result != tt.expected {
+			t.Errorf("unexpected result for %s, want=%s, have=%s", tt.text, tt.expected, result)
+		}
+	}
+
+	// segments are converted as one text
+	segments := applySmartTypography([]FormattedText{{Text: `say "`}, {Text: `hi"`, Bold: true}})
+	expected := []FormattedText{{Text: "say “"}, {Text: "hi”", Bold: true}}
+	if !reflect.DeepEqual(segments, expected) {
+		t.Errorf("unexpected segments, want=%v, have=%v", expected, segments)
+	}
+}
+
+func TestDocument_SetSmartTypography(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetSmartTypography(true)
+	err = doc.ReplaceAll(PlaceholderMap{"key": `"Quoted" -- value`, "key-with-dash": []byte(`"raw"`)})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	if !strings.Contains(text, "“Quoted” – value") {
+		t.Error("string value was not converted")
+	}
+	if !strings.Contains(text, `"raw"`) {
+		t.Error("byte value must be inserted as it is")
+	}
+}
@@ -0,0 +1,83 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrEmptyPlaceholder is returned by Validate if the document contains placeholders without a key (e.g. '{}').
+	ErrEmptyPlaceholder = errors.New("empty placeholder")
+	// ErrPlaceholdersRemaining is returned by AssertFullyReplaced if the document still contains placeholders.
+	ErrPlaceholdersRemaining = errors.New("placeholders remaining")
+)
+
+// Validate checks the document for placeholders which cannot be replaced due to authoring errors.
+// Currently, empty placeholders (e.g. '{}') are detected. They are skipped while parsing since there is no
+// key they could be mapped to, but they are most likely left in the document by accident.
+// If any are found, an error wrapping ErrEmptyPlaceholder is returned.
+func (d *Document) Validate() error {
+	emptyPlaceholder := regexp.MustCompile(regexp.QuoteMeta(string(OpenDelimiter)) + `[ \t]*` + regexp.QuoteMeta(string(CloseDelimiter)))
+
+	var problems []string
+	for _, name := range d.readingOrder() {
+		if count := len(emptyPlaceholder.FindAllStringIndex(plainText(d.files[name]), -1)); count > 0 {
+			problems = append(problems, fmt.Sprintf("%d in %s", count, name))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrEmptyPlaceholder, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// DelimiterBalance counts the open and close delimiters in the text of the main document, the headers and the footers.
+// The text is reconstructed across runs, deleted text is ignored. The delimiters are balanced if both counts are equal.
+// This is a cheap sanity check of a template (e.g. when it is uploaded), it does not verify that every
+// delimiter is matched by the other one in the right order.
+func (d *Document) DelimiterBalance() (open, close int, balanced bool) {
+	for _, name := range d.readingOrder() {
+		text := plainText(d.files[name])
+		open += strings.Count(text, string(OpenDelimiter))
+		close += strings.Count(text, string(CloseDelimiter))
+	}
+	return open, close, open == close
+}
+
+// AssertFullyReplaced checks that no placeholders remain in the main document, the headers, the footers and
+// the loaded additional parts, e.g. as the final gate before a rendered document is delivered.
+// Instead of relying on the parsed placeholders, the text of every part is reconstructed across runs and searched
+// for delimited text. This also catches placeholders which were never parsed (e.g. because they are fragmented
+// in an unsupported way or were inserted by a value). Deleted text is ignored.
+// If any are found, an error wrapping ErrPlaceholdersRemaining and listing them per part is returned.
+func (d *Document) AssertFullyReplaced() error {
+	var problems []string
+	for _, name := range append(d.readingOrder(), d.additionalFiles...) {
+		if remaining := remainingPlaceholders(plainText(d.files[name])); len(remaining) > 0 {
+			problems = append(problems, fmt.Sprintf("%s in %s", strings.Join(remaining, ", "), name))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrPlaceholdersRemaining, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// remainingPlaceholders returns the text of all delimited placeholders inside the text, in order.
+// If DelimiterPairs are set, all pairs are searched.
+func remainingPlaceholders(text string) []string {
+	var remaining []string
+	if len(DelimiterPairs) > 0 {
+		for _, loc := range findDelimited(text) {
+			remaining = append(remaining, text[loc[0]:loc[1]])
+		}
+		return remaining
+	}
+
+	open, close := regexp.QuoteMeta(string(OpenDelimiter)), regexp.QuoteMeta(string(CloseDelimiter))
+	quote := regexp.QuoteMeta(string(KeyQuote))
+	placeholder := regexp.MustCompile(open + quote + `.*?` + quote + close + `|` + open + `[^` + open + close + `]*` + close)
+	return placeholder.FindAllString(text, -1)
+}
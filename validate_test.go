@@ -0,0 +1,132 @@
+package docx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDocument_Validate(t *testing.T) {
+	doc, err := Open("./test/empty_placeholder.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.Validate()
+	if !errors.Is(err, ErrEmptyPlaceholder) {
+		t.Errorf("expected ErrEmptyPlaceholder, got %v", err)
+		return
+	}
+	if !strings.Contains(err.Error(), "3 in "+DocumentXml) {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	// the empty placeholders are left untouched
+	if err = doc.ReplaceAll(PlaceholderMap{"": "value", "foo": "FOO", "bar": "BAR"}); err != nil {
+		t.Error("replacing failed", err)
+	}
+
+	valid, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer valid.Close()
+	if err = valid.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+}
+
+func TestDocument_DelimiterBalance(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	open, close, balanced := doc.DelimiterBalance()
+	if !balanced || open == 0 || open != close {
+		t.Errorf("expected balanced delimiters, have open=%d, close=%d", open, close)
+	}
+
+	// the value leaves an open delimiter without a matching close delimiter
+	if err = doc.ReplaceAll(PlaceholderMap{"key": "{unclosed"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	newOpen, newClose, balanced := doc.DelimiterBalance()
+	if balanced {
+		t.Error("expected unbalanced delimiters")
+	}
+	if newOpen != open || newClose >= close {
+		t.Errorf("unexpected delimiter counts, open=%d, close=%d", newOpen, newClose)
+	}
+}
+
+func TestDocument_AssertFullyReplaced(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	placeholders, err := doc.GetPlaceHoldersList()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	placeholderMap := PlaceholderMap{}
+	for _, placeholder := range placeholders {
+		placeholderMap[RemovePlaceholderDelimiter(placeholder)] = "value"
+	}
+
+	if err = doc.AssertFullyReplaced(); !errors.Is(err, ErrPlaceholdersRemaining) {
+		t.Errorf("expected ErrPlaceholdersRemaining before replacing, have=%v", err)
+	}
+
+	// the value contains a placeholder which was never parsed
+	placeholderMap["key"] = "{injected}"
+	if err = doc.ReplaceAll(placeholderMap); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	err = doc.AssertFullyReplaced()
+	if !errors.Is(err, ErrPlaceholdersRemaining) {
+		t.Errorf("expected ErrPlaceholdersRemaining, have=%v", err)
+		return
+	}
+	if !strings.Contains(err.Error(), "{injected} in "+DocumentXml) {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	replaced, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer replaced.Close()
+	// the template contains placeholders spanning paragraphs
+	if err = replaced.SetAllowCrossParagraphPlaceholders(true); err != nil {
+		t.Error(err)
+		return
+	}
+	placeholders, err = replaced.GetPlaceHoldersList()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, placeholder := range placeholders {
+		placeholderMap[RemovePlaceholderDelimiter(placeholder)] = "value"
+	}
+	if err = replaced.ReplaceAll(placeholderMap); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if err = replaced.AssertFullyReplaced(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
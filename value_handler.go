@@ -0,0 +1,160 @@
+package docx
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ValueHandler inserts values of a registered type in place of the placeholders of a key, see RegisterValueHandler.
+// It must replace the placeholders using the methods of the ReplaceContext. If the part does not contain
+// the key, the methods return ErrPlaceholderNotFound, which the handler should return as well.
+type ValueHandler func(ctx ReplaceContext, v interface{}) error
+
+// ReplaceContext is passed to a ValueHandler, it replaces the placeholders of a single key in a single part.
+type ReplaceContext struct {
+	Key  string // Key is the key of the value which is replaced
+	Part string // Part is the name of the replaced part, e.g. 'word/document.xml'. It is empty for table rows.
+
+	doc      *Document
+	replacer *Replacer
+	n        int
+}
+
+var (
+	valueHandlersMutex sync.RWMutex
+	valueHandlers      = make(map[reflect.Type]ValueHandler)
+)
+
+func init() {
+	RegisterValueHandler(reflect.TypeOf(""), replaceTextValue)
+	RegisterValueHandler(reflect.TypeOf(FormattedText{}), replaceFormattedValue)
+	RegisterValueHandler(reflect.TypeOf([]FormattedText{}), replaceFormattedValue)
+	RegisterValueHandler(reflect.TypeOf(Symbol{}), replaceSymbolValue)
+	RegisterValueHandler(reflect.TypeOf(ImageData{}), replaceImageValue)
+}
+
+// RegisterValueHandler registers the handler for all values of the given type. Whenever a value of this type
+// is replaced (e.g. by ReplaceAll), the handler is called for every part of the document instead of converting
+// the value into text. The handlers of the built-in types (string, FormattedText, []FormattedText, Symbol and
+// ImageData) can be replaced as well. Passing a nil handler removes the handler of the type.
+//
+// Values of types without a handler are formatted using fmt.Sprint. The ValueFormatter is applied before the
+// handler is looked up, it may therefore convert values into a type with a handler.
+// Note that the handlers are registered globally, they apply to all documents.
+func RegisterValueHandler(t reflect.Type, handler ValueHandler) {
+	valueHandlersMutex.Lock()
+	defer valueHandlersMutex.Unlock()
+	if handler == nil {
+		delete(valueHandlers, t)
+		return
+	}
+	valueHandlers[t] = handler
+}
+
+// lookupValueHandler returns the handler registered for the type of the value or nil.
+func lookupValueHandler(value interface{}) ValueHandler {
+	valueHandlersMutex.RLock()
+	defer valueHandlersMutex.RUnlock()
+	return valueHandlers[reflect.TypeOf(value)]
+}
+
+// ReplaceValue dispatches the value to the handler of its type, values of types without a handler are inserted
+// as text using fmt.Sprint. It allows handlers to delegate to the built-in handlers, e.g. by converting their value
+// into a FormattedText. The value has been formatted according to the options of the document (including the
+// ValueFormatter) before the handler was called, hence it is not formatted again.
+func (c ReplaceContext) ReplaceValue(value interface{}) error {
+	if handler := lookupValueHandler(value); handler != nil {
+		return handler(c, value)
+	}
+	return replaceTextValue(c, fmt.Sprint(value))
+}
+
+// ReplaceText inserts the text into the run of each placeholder. The text is not formatted any further.
+func (c ReplaceContext) ReplaceText(text string) error {
+	return replaceTextValue(c, text)
+}
+
+// ReplaceRuns inserts the runs (<w:r>) in place of each placeholder by splitting its run.
+// The runs are inserted as-is, they must be valid WordprocessingML.
+func (c ReplaceContext) ReplaceRuns(runs string) error {
+	return c.replacer.replace(c.Key, c.n, func(placeholder *Placeholder) string {
+		return c.replacer.splitRun(placeholder.Fragments[0].Run, runs)
+	})
+}
+
+// replaceTextValue is the handler of strings, it inserts the text into the run of the placeholder.
+// If paragraphs are expanded, multi-paragraph texts are split into paragraphs of their own.
+func replaceTextValue(c ReplaceContext, v interface{}) error {
+	d, replacer, text := c.doc, c.replacer, v.(string)
+	if d.expandParagraphs && paragraphBreakRegex.MatchString(text) {
+		return replacer.replace(c.Key, c.n, func(placeholder *Placeholder) string {
+			return replacer.splitParagraph(placeholder.Fragments[0].Run, text, d.applyInsertParagraphStyle)
+		})
+	}
+	return replacer.replace(c.Key, c.n, func(placeholder *Placeholder) string {
+		run := placeholder.Fragments[0].Run
+		if d.defaultFont != "" || d.defaultFontSize > 0 || d.highlightColor != "" || d.language != "" {
+			if fontRun := d.defaultFontRun(replacer.document, run, text); fontRun != "" {
+				return replacer.splitRun(run, fontRun)
+			}
+		}
+		return replacer.textValue(run, text)
+	})
+}
+
+// replaceFormattedValue is the handler of FormattedText and []FormattedText, each segment is inserted as a run of its own.
+// The properties of the segments are merged into the properties of the placeholder run,
+// the default font and size are applied unless the merged properties specify them.
+func replaceFormattedValue(c ReplaceContext, v interface{}) error {
+	var texts []FormattedText
+	switch value := v.(type) {
+	case FormattedText:
+		texts = []FormattedText{value}
+	case []FormattedText:
+		texts = value
+	}
+	if err := validateFormattedTexts(texts); err != nil {
+		return fmt.Errorf("invalid value of %s: %w", c.Key, err)
+	}
+	d, replacer := c.doc, c.replacer
+	return replacer.replace(c.Key, c.n, func(placeholder *Placeholder) string {
+		run := placeholder.Fragments[0].Run
+		base := runProperties(replacer.document, run)
+		var runs strings.Builder
+		for _, text := range texts {
+			runs.WriteString(text.runWith(d.applyDefaultFont(text.runProperties(base))))
+		}
+		return replacer.splitRun(run, runs.String())
+	})
+}
+
+// replaceSymbolValue is the handler of Symbol, the symbol is inserted as a run of its own, followed by its text.
+func replaceSymbolValue(c ReplaceContext, v interface{}) error {
+	symbol, replacer := v.(Symbol), c.replacer
+	return replacer.replace(c.Key, c.n, func(placeholder *Placeholder) string {
+		run := placeholder.Fragments[0].Run
+		symbolRun := symbol.run(runProperties(replacer.document, run))
+		// the text is inserted into the split run following the symbol, keeping its properties
+		return replacer.splitRun(run, symbolRun) + replacer.textValue(run, symbol.Text)
+	})
+}
+
+// replaceImageValue is the handler of ImageData, the image is inserted as an inline drawing, see ReplaceImage.
+// The image is added to the archive once per replacement call, all parts which contain the key reference it.
+func replaceImageValue(c ReplaceContext, v interface{}) error {
+	if !c.replacer.contains(c.Key) {
+		return ErrPlaceholderNotFound
+	}
+	if c.Part == "" {
+		return fmt.Errorf("unable to insert image %s: images are not supported here", c.Key)
+	}
+	img := v.(ImageData)
+	media, err := c.doc.insertImage(c.Part, c.replacer, c.Key, c.n, img, c.doc.sharedMediaName(img))
+	if err == nil && c.doc.sharedMedia != nil {
+		c.doc.sharedMedia[sha256.Sum256(img.Data)] = media
+	}
+	return err
+}
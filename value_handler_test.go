@@ -0,0 +1,157 @@
+package docx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testAmount struct {
+	cents int
+}
+
+func TestRegisterValueHandler(t *testing.T) {
+	RegisterValueHandler(reflect.TypeOf(testAmount{}), func(ctx ReplaceContext, v interface{}) error {
+		amount := v.(testAmount)
+		return ctx.ReplaceValue(FormattedText{Text: fmt.Sprintf("%d.%02d EUR", amount.cents/100, amount.cents%100), Bold: amount.cents < 0})
+	})
+	defer RegisterValueHandler(reflect.TypeOf(testAmount{}), nil)
+
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	err = doc.ReplaceAll(PlaceholderMap{
+		"key":           testAmount{cents: 1250},
+		"key.with.dots": ImageData{Data: testImage(t, 20, 10)},
+	})
+	if err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+
+	documentXml := string(doc.GetFile(DocumentXml))
+	if !strings.Contains(documentXml, `<w:t xml:space="preserve">12.50 EUR</w:t>`) {
+		t.Error("value was not inserted by the registered handler")
+	}
+	if strings.Contains(documentXml, "{12") {
+		t.Error("value was formatted using fmt.Sprint")
+	}
+	if !strings.Contains(documentXml, `<wp:extent cx="190500" cy="95250"/>`) {
+		t.Error("image was not inserted by the built-in handler")
+	}
+	if err = xml.Unmarshal([]byte(documentXml), new(interface{})); err != nil {
+		t.Error("failed to unmarshal xml, replacing failed", err)
+	}
+}
+
+func TestReplaceImageValue_SharedMedia(t *testing.T) {
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+	before, err := doc.Media()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	// the key is contained in the main document, a header and a footer
+	if err = doc.ReplaceAll(PlaceholderMap{"key": ImageData{Data: testImage(t, 20, 10)}}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	media, err := doc.Media()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if added := len(media) - len(before); added != 1 {
+		t.Errorf("unexpected count of added media files, want=%d, have=%d", 1, added)
+	}
+	for _, info := range media {
+		if !info.Referenced {
+			t.Errorf("media file %s is not referenced", info.Name)
+		}
+	}
+}
+
+func TestRegisterValueHandler_Error(t *testing.T) {
+	errInvalid := errors.New("invalid amount")
+	RegisterValueHandler(reflect.TypeOf(testAmount{}), func(ctx ReplaceContext, v interface{}) error {
+		if v.(testAmount).cents < 0 {
+			return errInvalid
+		}
+		return ctx.ReplaceText("ok")
+	})
+	defer RegisterValueHandler(reflect.TypeOf(testAmount{}), nil)
+
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err := doc.ReplaceAll(PlaceholderMap{"key": testAmount{cents: -1}}); !errors.Is(err, errInvalid) {
+		t.Errorf("expected error of the handler, got %v", err)
+	}
+}
+
+func TestRegisterValueHandler_Removed(t *testing.T) {
+	RegisterValueHandler(reflect.TypeOf(testAmount{}), func(ctx ReplaceContext, v interface{}) error {
+		return ctx.ReplaceText("handled")
+	})
+	RegisterValueHandler(reflect.TypeOf(testAmount{}), nil)
+
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err := doc.ReplaceAll(PlaceholderMap{"key": testAmount{cents: 5}}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if documentXml := string(doc.GetFile(DocumentXml)); !strings.Contains(documentXml, "{5}") {
+		t.Error("value of a type without handler was not formatted using fmt.Sprint")
+	}
+}
+
+func TestReplaceContext_ReplaceValue_NotFormattedAgain(t *testing.T) {
+	RegisterValueHandler(reflect.TypeOf(testAmount{}), func(ctx ReplaceContext, v interface{}) error {
+		return ctx.ReplaceValue(fmt.Sprintf("%d cents", v.(testAmount).cents))
+	})
+	defer RegisterValueHandler(reflect.TypeOf(testAmount{}), nil)
+
+	doc, err := Open("./test/template.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	doc.SetValueFormatter(func(key string, value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			t.Errorf("the delegated value %s must not be formatted again", s)
+		}
+		return value
+	})
+	if err = doc.ReplaceAll(PlaceholderMap{"key.with.dots": testAmount{cents: 5}}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if !strings.Contains(string(doc.GetFile(DocumentXml)), ">5 cents<") {
+		t.Error("value was not inserted by the delegated handler")
+	}
+}
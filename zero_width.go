@@ -0,0 +1,22 @@
+package docx
+
+import "strings"
+
+// StripZeroWidth defines whether zero-width characters are ignored inside of placeholders.
+// Templates pasted from the web often contain zero-width spaces (U+200B), e.g. '{cust\u200Bomer}' would
+// not match the key 'customer' otherwise. The placeholder is still replaced including the zero-width characters.
+// It is disabled by default, since the characters may be intentional.
+var StripZeroWidth = false
+
+// zeroWidthReplacer removes the zero-width space, non-joiner, joiner, the word joiner and the zero-width no-break space
+var zeroWidthReplacer = strings.NewReplacer("\u200B", "", "\u200C", "", "\u200D", "", "\u2060", "", "\uFEFF", "")
+
+// normalizePlaceholderText normalizes the hyphens of the placeholder text and,
+// if StripZeroWidth is set, removes all zero-width characters.
+func normalizePlaceholderText(text string) string {
+	text = normalizeHyphens(text)
+	if StripZeroWidth {
+		text = zeroWidthReplacer.Replace(text)
+	}
+	return text
+}
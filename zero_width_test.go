@@ -0,0 +1,49 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripZeroWidth(t *testing.T) {
+	StripZeroWidth = true
+	defer func() {
+		StripZeroWidth = false
+	}()
+
+	doc, err := Open("./test/zero_width.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceAll(PlaceholderMap{"customer": "ACME", "name": "John"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	text := doc.Text()
+	if !strings.Contains(text, "Dear ACME,") || !strings.Contains(text, "John") {
+		t.Errorf("placeholders with zero-width characters were not replaced: %s", text)
+	}
+	if strings.Contains(text, "\u200B") {
+		t.Error("the zero-width characters of the placeholders must be replaced as well")
+	}
+}
+
+func TestStripZeroWidth_Disabled(t *testing.T) {
+	doc, err := Open("./test/zero_width.docx")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer doc.Close()
+
+	if err = doc.ReplaceAll(PlaceholderMap{"customer": "ACME"}); err != nil {
+		t.Error("replacing failed", err)
+		return
+	}
+	if strings.Contains(doc.Text(), "ACME") {
+		t.Error("zero-width characters must not be stripped by default")
+	}
+}